@@ -0,0 +1,46 @@
+package avltrees
+
+import "cmp"
+
+// Ascend calls visit for every node in ascending key order until visit returns false.
+func Ascend[K cmp.Ordered, V any](t *Tree[K, V], visit func(n *Node[K, V]) bool) {
+	n, ok := Min(t)
+	for ok && visit(n) {
+		n, ok = Successor(n)
+	}
+}
+
+// AscendGreaterOrEqual calls visit for every node with key >= pivot in ascending order
+// until visit returns false.
+func AscendGreaterOrEqual[K cmp.Ordered, V any](t *Tree[K, V], pivot K, visit func(n *Node[K, V]) bool) {
+	n, ok := Ceiling(t, pivot)
+	for ok && visit(n) {
+		n, ok = Successor(n)
+	}
+}
+
+// AscendRange calls visit for every node with key in the range [greaterOrEqual, lessThan)
+// in ascending order until visit returns false.
+func AscendRange[K cmp.Ordered, V any](t *Tree[K, V], greaterOrEqual, lessThan K, visit func(n *Node[K, V]) bool) {
+	n, ok := Ceiling(t, greaterOrEqual)
+	for ok && n.key < lessThan && visit(n) {
+		n, ok = Successor(n)
+	}
+}
+
+// Descend calls visit for every node in descending key order until visit returns false.
+func Descend[K cmp.Ordered, V any](t *Tree[K, V], visit func(n *Node[K, V]) bool) {
+	n, ok := Max(t)
+	for ok && visit(n) {
+		n, ok = Predecessor(n)
+	}
+}
+
+// DescendLessOrEqual calls visit for every node with key <= pivot in descending order
+// until visit returns false.
+func DescendLessOrEqual[K cmp.Ordered, V any](t *Tree[K, V], pivot K, visit func(n *Node[K, V]) bool) {
+	n, ok := Floor(t, pivot)
+	for ok && visit(n) {
+		n, ok = Predecessor(n)
+	}
+}
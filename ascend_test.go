@@ -0,0 +1,142 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+)
+
+func collectKeys(dst *[]int, limit int) func(n *avlts.Node[int, string]) bool {
+	return func(n *avlts.Node[int, string]) bool {
+		*dst = append(*dst, n.Key())
+		return len(*dst) < limit
+	}
+}
+
+func TestAscend(t *testing.T) {
+	tree := treeOf[int, string]([]int{30, 10, 50, 20, 40}, nil)
+
+	var keys []int
+	avlts.Ascend(tree, collectKeys(&keys, 10))
+	assert.Equal(t, []int{10, 20, 30, 40, 50}, keys)
+
+	keys = nil
+	avlts.Ascend(tree, collectKeys(&keys, 2))
+	assert.Equal(t, []int{10, 20}, keys)
+
+	keys = nil
+	avlts.Ascend(avlts.New[int, string](), collectKeys(&keys, 10))
+	assert.Empty(t, keys)
+}
+
+func TestAscendGreaterOrEqual(t *testing.T) {
+	tree := treeOf[int, string]([]int{30, 10, 50, 20, 40}, nil)
+
+	var keys []int
+	avlts.AscendGreaterOrEqual(tree, 25, collectKeys(&keys, 10))
+	assert.Equal(t, []int{30, 40, 50}, keys)
+
+	keys = nil
+	avlts.AscendGreaterOrEqual(tree, 30, collectKeys(&keys, 1))
+	assert.Equal(t, []int{30}, keys)
+
+	keys = nil
+	avlts.AscendGreaterOrEqual(tree, 60, collectKeys(&keys, 10))
+	assert.Empty(t, keys)
+}
+
+func TestAscendRange(t *testing.T) {
+	tree := treeOf[int, string]([]int{30, 10, 50, 20, 40}, nil)
+
+	var keys []int
+	avlts.AscendRange(tree, 20, 50, collectKeys(&keys, 10))
+	assert.Equal(t, []int{20, 30, 40}, keys)
+
+	keys = nil
+	avlts.AscendRange(tree, 20, 50, collectKeys(&keys, 2))
+	assert.Equal(t, []int{20, 30}, keys)
+
+	keys = nil
+	avlts.AscendRange(tree, 31, 39, collectKeys(&keys, 10))
+	assert.Empty(t, keys)
+}
+
+func TestDescend(t *testing.T) {
+	tree := treeOf[int, string]([]int{30, 10, 50, 20, 40}, nil)
+
+	var keys []int
+	avlts.Descend(tree, collectKeys(&keys, 10))
+	assert.Equal(t, []int{50, 40, 30, 20, 10}, keys)
+
+	keys = nil
+	avlts.Descend(tree, collectKeys(&keys, 3))
+	assert.Equal(t, []int{50, 40, 30}, keys)
+}
+
+func TestDescendLessOrEqual(t *testing.T) {
+	tree := treeOf[int, string]([]int{30, 10, 50, 20, 40}, nil)
+
+	var keys []int
+	avlts.DescendLessOrEqual(tree, 35, collectKeys(&keys, 10))
+	assert.Equal(t, []int{30, 20, 10}, keys)
+
+	keys = nil
+	avlts.DescendLessOrEqual(tree, 40, collectKeys(&keys, 10))
+	assert.Equal(t, []int{40, 30, 20, 10}, keys)
+
+	keys = nil
+	avlts.DescendLessOrEqual(tree, 5, collectKeys(&keys, 10))
+	assert.Empty(t, keys)
+}
+
+func ExampleAscend() {
+	tree := treeOf[int, string]([]int{30, 10, 50, 20, 40}, nil)
+	avlts.Ascend(tree, func(n *avlts.Node[int, string]) bool {
+		fmt.Print(n.Key(), " ")
+		return n.Key() < 30
+	})
+	fmt.Println()
+	// Output: 10 20 30
+}
+
+func ExampleAscendGreaterOrEqual() {
+	tree := treeOf[int, string]([]int{30, 10, 50, 20, 40}, nil)
+	avlts.AscendGreaterOrEqual(tree, 35, func(n *avlts.Node[int, string]) bool {
+		fmt.Print(n.Key(), " ")
+		return true
+	})
+	fmt.Println()
+	// Output: 40 50
+}
+
+func ExampleAscendRange() {
+	tree := treeOf[int, string]([]int{30, 10, 50, 20, 40}, nil)
+	avlts.AscendRange(tree, 20, 40, func(n *avlts.Node[int, string]) bool {
+		fmt.Print(n.Key(), " ")
+		return true
+	})
+	fmt.Println()
+	// Output: 20 30
+}
+
+func ExampleDescend() {
+	tree := treeOf[int, string]([]int{30, 10, 50, 20, 40}, nil)
+	avlts.Descend(tree, func(n *avlts.Node[int, string]) bool {
+		fmt.Print(n.Key(), " ")
+		return true
+	})
+	fmt.Println()
+	// Output: 50 40 30 20 10
+}
+
+func ExampleDescendLessOrEqual() {
+	tree := treeOf[int, string]([]int{30, 10, 50, 20, 40}, nil)
+	avlts.DescendLessOrEqual(tree, 25, func(n *avlts.Node[int, string]) bool {
+		fmt.Print(n.Key(), " ")
+		return true
+	})
+	fmt.Println()
+	// Output: 20 10
+}
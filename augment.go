@@ -0,0 +1,177 @@
+package avltrees
+
+import (
+	"cmp"
+	"unsafe"
+)
+
+// augment describes the optional per-subtree aggregates a tree maintains in
+// addition to height and size. Trees that enable at least one of them
+// allocate augNodes, which carry the aggregates in a nodeExt placed right
+// after the Node, so plain trees do not pay for them.
+type augment[K cmp.Ordered, V any] struct {
+	weight     func(key K, value V) int64
+	valueOrder func(a, b V) int
+	hash       func(key K, value V) uint64
+	add        func(a, b V) V
+}
+
+type nodeExt[K cmp.Ordered, V any] struct {
+	weight             int64
+	minValue, maxValue *Node[K, V] // nodes holding the extreme values of the subtree
+	hash               uint64
+	sum                V
+}
+
+type augNode[K cmp.Ordered, V any] struct {
+	Node[K, V]
+	ext nodeExt[K, V]
+}
+
+// ext returns the aggregates of n, which must have been allocated as an
+// augNode, as every node of a tree with a non-nil augment is.
+func ext[K cmp.Ordered, V any](n *Node[K, V]) *nodeExt[K, V] {
+	return &(*augNode[K, V])(unsafe.Pointer(n)).ext
+}
+
+// newAugment returns the augment requested by o, or nil if o requests none.
+// It panics if an option was instantiated for a different key or value type.
+func newAugment[K cmp.Ordered, V any](o *options) *augment[K, V] {
+	var a augment[K, V]
+	var ok bool
+	if o.valueOrder != nil {
+		if a.valueOrder, ok = o.valueOrder.(func(V, V) int); !ok {
+			panic("avltrees: WithValueOrder comparator does not match the tree's value type")
+		}
+	}
+	if o.hash != nil {
+		if a.hash, ok = o.hash.(func(K, V) uint64); !ok {
+			panic("avltrees: WithMultisetHash function does not match the tree's key and value types")
+		}
+	}
+	if o.weight != nil {
+		if a.weight, ok = o.weight.(func(K, V) int64); !ok {
+			panic("avltrees: WithWeight function does not match the tree's key and value types")
+		}
+	}
+	if o.sum != nil {
+		if a.add, ok = o.sum.(func(V, V) V); !ok {
+			panic("avltrees: WithPrefixSums does not match the tree's value type")
+		}
+	}
+	if a.valueOrder == nil && a.hash == nil && a.weight == nil && a.add == nil {
+		return nil
+	}
+	return &a
+}
+
+func newNode[K cmp.Ordered, V any](t *Tree[K, V], key K, value V, parent *Node[K, V]) *Node[K, V] {
+	n := allocNode(t)
+	n.key, n.value, n.balance, n.size, n.parent = key, value, 0, 1, parent
+	if t.aug != nil {
+		t.aug.update(n)
+	}
+	return n
+}
+
+// update recomputes the aggregates of n from its own entry and its children.
+func (a *augment[K, V]) update(n *Node[K, V]) {
+	e := ext(n)
+	if a.weight != nil {
+		e.weight = a.weight(n.key, n.value) + weightSum(n.left) + weightSum(n.right)
+	}
+	if a.valueOrder != nil {
+		e.minValue, e.maxValue = n, n
+		if n.left != nil {
+			e.minValue = a.lesser(ext(n.left).minValue, n)
+			e.maxValue = a.greater(ext(n.left).maxValue, n)
+		}
+		if n.right != nil {
+			e.minValue = a.lesser(e.minValue, ext(n.right).minValue)
+			e.maxValue = a.greater(e.maxValue, ext(n.right).maxValue)
+		}
+	}
+	if a.hash != nil {
+		e.hash = mix64(a.hash(n.key, n.value)) + hashSum(n.left) + hashSum(n.right)
+	}
+	if a.add != nil {
+		e.sum = n.value
+		if n.left != nil {
+			e.sum = a.add(ext(n.left).sum, e.sum)
+		}
+		if n.right != nil {
+			e.sum = a.add(e.sum, ext(n.right).sum)
+		}
+	}
+}
+
+// lesser returns the node with the smaller value, preferring x on ties.
+func (a *augment[K, V]) lesser(x, y *Node[K, V]) *Node[K, V] {
+	if x == nil || (y != nil && a.valueOrder(y.value, x.value) < 0) {
+		return y
+	}
+	return x
+}
+
+// greater returns the node with the larger value, preferring x on ties.
+func (a *augment[K, V]) greater(x, y *Node[K, V]) *Node[K, V] {
+	if x == nil || (y != nil && a.valueOrder(y.value, x.value) > 0) {
+		return y
+	}
+	return x
+}
+
+// refreshUp recomputes the aggregates of n and its ancestors after the value
+// of n changed in place.
+func refreshUp[K cmp.Ordered, V any](t *Tree[K, V], n *Node[K, V]) {
+	if t.aug == nil {
+		return
+	}
+	for ; n != nil; n = n.parent {
+		t.aug.update(n)
+	}
+}
+
+func weightSum[K cmp.Ordered, V any](n *Node[K, V]) int64 {
+	if n == nil {
+		return 0
+	}
+	return ext(n).weight
+}
+
+// weightBefore returns the total weight of the entries with keys less than key.
+func weightBefore[K cmp.Ordered, V any](t *Tree[K, V], key K) int64 {
+	var w int64
+	curr := t.Root
+	for curr != nil {
+		if key <= curr.key {
+			curr = curr.left
+		} else {
+			w += ext(curr).weight - weightSum(curr.right)
+			curr = curr.right
+		}
+	}
+	return w
+}
+
+// seekWeight returns the node whose weight covers offset w, counted from the
+// smallest key, along with the offset of w within that node's own weight.
+// Returns nil if w is negative or not less than the total weight.
+func seekWeight[K cmp.Ordered, V any](t *Tree[K, V], w int64) (*Node[K, V], int64) {
+	if w < 0 || w >= weightSum(t.Root) {
+		return nil, 0
+	}
+	curr := t.Root
+	for {
+		left := weightSum(curr.left)
+		own := ext(curr).weight - left - weightSum(curr.right)
+		if w < left {
+			curr = curr.left
+		} else if w < left+own {
+			return curr, w - left
+		} else {
+			w -= left + own
+			curr = curr.right
+		}
+	}
+}
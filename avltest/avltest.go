@@ -0,0 +1,101 @@
+// Package avltest provides helpers for property-based testing of code that
+// uses avltrees: a testing/quick generator for random trees and reusable
+// checks of the tree invariants.
+package avltest
+
+import (
+	"cmp"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	avlts "github.com/byExist/avltrees"
+)
+
+// RandomTree wraps an AVL tree so testing/quick can generate random
+// instances of it. Keys and values are produced with quick.Value, and the
+// number of entries is chosen uniformly from [0, size].
+type RandomTree[K cmp.Ordered, V any] struct {
+	*avlts.Tree[K, V]
+}
+
+// Generate implements quick.Generator.
+func (RandomTree[K, V]) Generate(r *rand.Rand, size int) reflect.Value {
+	tree := avlts.New[K, V]()
+	kt, vt := reflect.TypeFor[K](), reflect.TypeFor[V]()
+	for range r.Intn(size + 1) {
+		k, ok := quick.Value(kt, r)
+		if !ok {
+			panic("avltest: cannot generate keys of type " + kt.String())
+		}
+		v, ok := quick.Value(vt, r)
+		if !ok {
+			panic("avltest: cannot generate values of type " + vt.String())
+		}
+		avlts.Insert(tree, k.Interface().(K), v.Interface().(V))
+	}
+	return reflect.ValueOf(RandomTree[K, V]{tree})
+}
+
+// Sorted reports whether the tree yields strictly increasing keys.
+func Sorted[K cmp.Ordered, V any](t *avlts.Tree[K, V]) bool {
+	first := true
+	var prev K
+	for n := range avlts.InOrder(t) {
+		if !first && n.Key() <= prev {
+			return false
+		}
+		prev, first = n.Key(), false
+	}
+	return true
+}
+
+// Balanced reports whether the height of the tree is within the AVL bound
+// for its number of entries, and every node satisfies the AVL balance
+// condition.
+func Balanced[K cmp.Ordered, V any](t *avlts.Tree[K, V]) bool {
+	// minNodes[h] is the fewest nodes an AVL tree of height h can have.
+	minNodes := []int{0, 1}
+	n, h := avlts.Len(t), avlts.Height(t)
+	for len(minNodes) <= h {
+		k := len(minNodes)
+		minNodes = append(minNodes, minNodes[k-1]+minNodes[k-2]+1)
+	}
+	return n >= minNodes[h] && avlts.Validate(t) == nil
+}
+
+// SizeConsistent reports whether Len matches the number of entries
+// visited in order, and Rank and Kth agree with in-order positions.
+func SizeConsistent[K cmp.Ordered, V any](t *avlts.Tree[K, V]) bool {
+	i := 0
+	for n := range avlts.InOrder(t) {
+		if avlts.Rank(t, n.Key()) != i {
+			return false
+		}
+		if k, ok := avlts.Kth(t, i); !ok || k.Key() != n.Key() {
+			return false
+		}
+		i++
+	}
+	return i == avlts.Len(t)
+}
+
+// AssertInvariants reports a test error for each invariant the tree
+// violates: sortedness, balance, size consistency, and anything else
+// checked by avltrees.Validate.
+func AssertInvariants[K cmp.Ordered, V any](tb testing.TB, t *avlts.Tree[K, V]) {
+	tb.Helper()
+	if !Sorted(t) {
+		tb.Error("avltest: keys are not in increasing order")
+	}
+	if !Balanced(t) {
+		tb.Errorf("avltest: height %d is not balanced for %d entries", avlts.Height(t), avlts.Len(t))
+	}
+	if !SizeConsistent(t) {
+		tb.Error("avltest: Len, Rank, and Kth disagree with in-order traversal")
+	}
+	if err := avlts.Validate(t); err != nil {
+		tb.Error(err)
+	}
+}
@@ -0,0 +1,59 @@
+package avltest_test
+
+import (
+	"fmt"
+	"testing"
+	"testing/quick"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/byExist/avltrees/avltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	prop := func(rt avltest.RandomTree[int, string]) bool {
+		return avltest.Sorted(rt.Tree) && avltest.Balanced(rt.Tree) && avltest.SizeConsistent(rt.Tree)
+	}
+	assert.NoError(t, quick.Check(prop, &quick.Config{MaxCount: 200}))
+}
+
+func TestDeleteKeepsInvariants(t *testing.T) {
+	prop := func(rt avltest.RandomTree[uint8, int], victims []uint8) bool {
+		for _, k := range victims {
+			avlts.Delete(rt.Tree, k)
+		}
+		return avltest.Sorted(rt.Tree) && avltest.Balanced(rt.Tree) && avltest.SizeConsistent(rt.Tree)
+	}
+	assert.NoError(t, quick.Check(prop, nil))
+}
+
+func TestPredicatesDetectViolations(t *testing.T) {
+	tree := avlts.New[int, int]()
+	for i := range 10 {
+		avlts.Insert(tree, i, i)
+	}
+	other := avlts.New[int, int]()
+	avlts.Insert(other, 1, 1)
+	tree.Root = other.Root
+
+	assert.True(t, avltest.Sorted(tree))
+	assert.False(t, avltest.Balanced(tree), "Validate sees the stale entry count")
+}
+
+func TestAssertInvariants(t *testing.T) {
+	tree := avlts.New[string, int]()
+	for _, k := range []string{"m", "c", "x", "a"} {
+		avlts.Insert(tree, k, 0)
+	}
+	avltest.AssertInvariants(t, tree)
+}
+
+func ExampleRandomTree() {
+	prop := func(rt avltest.RandomTree[int, int]) bool {
+		avlts.Clear(rt.Tree)
+		return avlts.Len(rt.Tree) == 0
+	}
+	fmt.Println(quick.Check(prop, nil))
+	// Output:
+	// <nil>
+}
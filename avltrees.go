@@ -3,17 +3,23 @@ package avltrees
 import (
 	"cmp"
 	"iter"
+	"slices"
 )
 
 // Node represents a node in the AVL tree.
+//
+// A node stores its balance factor, the height of its left subtree minus
+// that of its right subtree, instead of its height. The balance factor
+// fits in an int8 and shares a single word with the subtree size, which
+// limits trees to math.MaxUint32 nodes.
 type Node[K cmp.Ordered, V any] struct {
-	key    K
-	value  V
-	height int
-	size   int
-	left   *Node[K, V]
-	right  *Node[K, V]
-	parent *Node[K, V]
+	key     K
+	value   V
+	balance int8
+	size    uint32
+	left    *Node[K, V]
+	right   *Node[K, V]
+	parent  *Node[K, V]
 }
 
 // Key returns the key of the node.
@@ -26,43 +32,277 @@ func (n *Node[K, V]) Value() V {
 	return n.value
 }
 
+// SetValue replaces the value of the node in place. It does not refresh
+// aggregates, run OnUpdate hooks, or check whether the tree is frozen; use
+// the package-level SetValue for trees with augments or hooks.
+func (n *Node[K, V]) SetValue(value V) {
+	n.value = value
+}
+
+// Left returns the left child of the node, or nil if it has none.
+func (n *Node[K, V]) Left() *Node[K, V] {
+	return n.left
+}
+
+// Right returns the right child of the node, or nil if it has none.
+func (n *Node[K, V]) Right() *Node[K, V] {
+	return n.right
+}
+
+// Parent returns the parent of the node, or nil if it is the root.
+func (n *Node[K, V]) Parent() *Node[K, V] {
+	return n.parent
+}
+
+// Size returns the number of nodes in the subtree rooted at the node.
+// It is not maintained for trees created with WithoutOrderStatistics.
+func (n *Node[K, V]) Size() int {
+	return int(n.size)
+}
+
+// Height returns the height of the subtree rooted at the node.
+// A leaf has height 1. Nodes do not store their height, so it is found in
+// O(log n) by following the taller child of each node down to a leaf.
+func (n *Node[K, V]) Height() int {
+	return height(n)
+}
+
+// Item is a key-value pair.
+type Item[K cmp.Ordered, V any] struct {
+	Key   K
+	Value V
+}
+
 // Tree represents an AVL tree.
 type Tree[K cmp.Ordered, V any] struct {
-	Root *Node[K, V]
+	Root         *Node[K, V]
+	count        int
+	capacity     int
+	evict        EvictPolicy
+	noOrderStats bool
+	hooks        *hooks[K, V]
+	aug          *augment[K, V]
+	version      uint64
+	frozen       bool
+	appliedSeq   uint64
+	spare        []Node[K, V]    // preallocated nodes not yet in use
+	spareAug     []augNode[K, V] // the same, for trees with aggregates
+	metrics      *Metrics
+}
+
+// Option configures a Tree at construction time.
+type Option func(*options)
+
+type options struct {
+	noOrderStats bool
+	valueOrder   any // func(V, V) int
+	hash         any // func(K, V) uint64
+	weight       any // func(K, V) int64
+	sum          any // func(V, V) V
+	metrics      *Metrics
+}
+
+// WithoutOrderStatistics disables maintenance of subtree sizes.
+// Rank returns -1 and Kth returns false on such a tree; all other
+// operations, including Len, keep working.
+func WithoutOrderStatistics() Option {
+	return func(o *options) {
+		o.noOrderStats = true
+	}
+}
+
+func newTree[K cmp.Ordered, V any](opts []Option) *Tree[K, V] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Tree[K, V]{noOrderStats: o.noOrderStats, aug: newAugment[K, V](&o), metrics: o.metrics}
+}
+
+// newTreeLike returns an empty tree with the order-statistics setting and
+// aggregates of t, but no capacity bound, hooks or metrics.
+func newTreeLike[K cmp.Ordered, V any](t *Tree[K, V]) *Tree[K, V] {
+	return &Tree[K, V]{noOrderStats: t.noOrderStats, aug: t.aug}
+}
+
+// EvictPolicy selects which entry a bounded tree evicts when it is full.
+type EvictPolicy int
+
+const (
+	// EvictMin evicts the entry with the smallest key.
+	EvictMin EvictPolicy = iota
+	// EvictMax evicts the entry with the largest key.
+	EvictMax
+)
+
+// New returns a new empty AVL Tree configured by opts.
+func New[K cmp.Ordered, V any](opts ...Option) *Tree[K, V] {
+	return newTree[K, V](opts)
 }
 
-// New returns a new empty AVL Tree.
-func New[K cmp.Ordered, V any]() *Tree[K, V] {
-	return &Tree[K, V]{}
+// NewBounded returns a new empty AVL Tree holding at most maxEntries entries.
+// Inserting a new key into a full tree evicts the smallest or largest key
+// according to policy. Panics if maxEntries is less than 1.
+func NewBounded[K cmp.Ordered, V any](maxEntries int, policy EvictPolicy, opts ...Option) *Tree[K, V] {
+	if maxEntries < 1 {
+		panic("avltrees: NewBounded requires maxEntries >= 1")
+	}
+	t := newTree[K, V](opts)
+	t.capacity, t.evict = maxEntries, policy
+	return t
+}
+
+// NewWithCapacity returns a new empty AVL Tree configured by opts, with
+// storage for n nodes allocated up front in a single block. The first n
+// inserted keys take their nodes from the block instead of allocating one
+// each, which speeds up the initial load of a tree of known size. The
+// block stays in memory for as long as any of its nodes does, even after
+// most of their keys are deleted. Panics if n is negative.
+func NewWithCapacity[K cmp.Ordered, V any](n int, opts ...Option) *Tree[K, V] {
+	if n < 0 {
+		panic("avltrees: NewWithCapacity requires n >= 0")
+	}
+	t := newTree[K, V](opts)
+	if n > 0 && t.aug != nil {
+		t.spareAug = make([]augNode[K, V], n)
+	} else if n > 0 {
+		t.spare = make([]Node[K, V], n)
+	}
+	return t
+}
+
+// allocNode returns a zeroed node, from the block reserved by
+// NewWithCapacity while it lasts. Trees with aggregates get an augNode.
+func allocNode[K cmp.Ordered, V any](t *Tree[K, V]) *Node[K, V] {
+	if t.aug != nil {
+		if len(t.spareAug) == 0 {
+			return &new(augNode[K, V]).Node
+		}
+		n := &t.spareAug[0].Node
+		t.spareAug = t.spareAug[1:]
+		return n
+	}
+	if len(t.spare) == 0 {
+		return new(Node[K, V])
+	}
+	n := &t.spare[0]
+	t.spare = t.spare[1:]
+	return n
 }
 
 // Clear removes all nodes from the AVL tree.
 func Clear[K cmp.Ordered, V any](t *Tree[K, V]) {
+	checkMutable(t)
+	root := t.Root
+	recordDeletes(t, t.count)
 	t.Root = nil
+	t.count = 0
+	if root != nil {
+		t.version++
+	}
+	if t.hooks != nil && len(t.hooks.onDelete) > 0 {
+		for _, n := range appendNodes(nil, root) {
+			notifyDelete(t, n.key, n.value)
+		}
+	}
 }
 
 // Insert inserts a key-value pair into the AVL tree.
 // Returns true if the key was inserted, or false if it replaced an existing key.
 func Insert[K cmp.Ordered, V any](t *Tree[K, V], key K, value V) bool {
-	var inserted bool
-	t.Root, inserted = insertRec(t.Root, key, value, nil)
+	checkMutable(t)
+	inserted := insert(t, key, value)
+	if inserted {
+		evictOverflow(t)
+	}
 	return inserted
 }
 
+// InsertIfAbsent inserts a key-value pair into the AVL tree only if the key
+// is not already present, leaving an existing value untouched. It descends
+// the tree once, like Entry(t, key).OrInsert(value).
+// Returns true if the key was inserted.
+func InsertIfAbsent[K cmp.Ordered, V any](t *Tree[K, V], key K, value V) bool {
+	checkMutable(t)
+	e := Entry(t, key)
+	if e.node != nil {
+		return false
+	}
+	e.attach(value)
+	return true
+}
+
+// InsertEvict inserts a key-value pair like Insert. If the tree is bounded and
+// the insertion exceeds its capacity, the entry selected by the tree's EvictPolicy
+// is removed and returned as a detached node.
+// Returns the evicted node and true if an entry was evicted, or nil and false otherwise.
+// The evicted entry may be the one just inserted.
+func InsertEvict[K cmp.Ordered, V any](t *Tree[K, V], key K, value V) (*Node[K, V], bool) {
+	checkMutable(t)
+	if !insert(t, key, value) {
+		return nil, false
+	}
+	return evictOverflow(t)
+}
+
 // Delete removes the node with the specified key from the AVL tree.
 // Returns true if the key existed and was deleted.
 func Delete[K cmp.Ordered, V any](t *Tree[K, V], key K) bool {
+	checkMutable(t)
+	var value V
 	var deleted bool
-	t.Root, deleted = deleteRec(t.Root, key)
+	t.Root, value, deleted, _ = deleteRec(t, t.Root, key)
 	if t.Root != nil {
 		t.Root.parent = nil
 	}
+	if deleted {
+		t.count--
+		recordDeletes(t, 1)
+		notifyDelete(t, key, value)
+	}
 	return deleted
 }
 
+// ReplaceKey moves the value stored under oldKey to newKey.
+// Returns true if the key was replaced, or false if oldKey does not exist
+// or newKey already exists. Hooks and metrics see the move as a delete of
+// oldKey followed by an insert of newKey.
+func ReplaceKey[K cmp.Ordered, V any](t *Tree[K, V], oldKey, newKey K) bool {
+	checkMutable(t)
+	if _, exists := lookup(t, newKey); exists {
+		return false
+	}
+	n, found := lookup(t, oldKey)
+	if !found {
+		return false
+	}
+	value := n.value
+	Delete(t, oldKey)
+	insert(t, newKey, value)
+	return true
+}
+
+// SetValue replaces the value of n, a node of the AVL tree, without
+// searching for its key again. Aggregates are refreshed and OnUpdate hooks
+// run as for Insert, which Node.SetValue cannot do because nodes do not
+// reference their tree.
+func SetValue[K cmp.Ordered, V any](t *Tree[K, V], n *Node[K, V], value V) {
+	checkMutable(t)
+	old := n.value
+	n.value = value
+	refreshUp(t, n)
+	notifyUpdate(t, n.key, old, value)
+}
+
 // Search finds and returns the node with the given key in the AVL tree.
 // Returns the node and true if found, or nil and false otherwise.
 func Search[K cmp.Ordered, V any](t *Tree[K, V], key K) (*Node[K, V], bool) {
+	recordSearch(t)
+	return lookup(t, key)
+}
+
+// lookup is Search for internal use, which does not count toward metrics.
+func lookup[K cmp.Ordered, V any](t *Tree[K, V], key K) (*Node[K, V], bool) {
 	curr := t.Root
 	for curr != nil {
 		if key < curr.key {
@@ -76,6 +316,40 @@ func Search[K cmp.Ordered, V any](t *Tree[K, V], key K) (*Node[K, V], bool) {
 	return nil, false
 }
 
+// Contains reports whether key is present in the AVL tree.
+func Contains[K cmp.Ordered, V any](t *Tree[K, V], key K) bool {
+	recordSearch(t)
+	curr := t.Root
+	for curr != nil {
+		if key < curr.key {
+			curr = curr.left
+		} else if key > curr.key {
+			curr = curr.right
+		} else {
+			return true
+		}
+	}
+	return false
+}
+
+// Get returns the value stored under key.
+// Returns the value and true if found, or the zero value and false otherwise.
+func Get[K cmp.Ordered, V any](t *Tree[K, V], key K) (V, bool) {
+	if n, found := Search(t, key); found {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// GetOrDefault returns the value stored under key, or def if key is not present.
+func GetOrDefault[K cmp.Ordered, V any](t *Tree[K, V], key K, def V) V {
+	if n, found := Search(t, key); found {
+		return n.value
+	}
+	return def
+}
+
 // Min returns the node with the smallest key in the AVL tree.
 // Returns the node and true if the tree is not empty, or nil and false otherwise.
 func Min[K cmp.Ordered, V any](t *Tree[K, V]) (*Node[K, V], bool) {
@@ -195,63 +469,113 @@ func Successor[K cmp.Ordered, V any](n *Node[K, V]) (*Node[K, V], bool) {
 }
 
 // InOrder returns an iterator for in-order traversal of the AVL tree.
+// It follows parent links instead of keeping a stack, so starting an
+// iteration does not allocate.
 func InOrder[K cmp.Ordered, V any](t *Tree[K, V]) iter.Seq[Node[K, V]] {
 	return func(yield func(Node[K, V]) bool) {
-		stack := []*Node[K, V]{}
-		curr := t.Root
-		for curr != nil || len(stack) > 0 {
-			for curr != nil {
-				stack = append(stack, curr)
-				curr = curr.left
-			}
-			n := stack[len(stack)-1]
-			stack = stack[:len(stack)-1]
+		for n, ok := Min(t); ok; n, ok = Successor(n) {
 			if !yield(*n) {
 				return
 			}
-			curr = n.right
 		}
 	}
 }
 
+// AppendKeys appends the keys of the AVL tree to dst in ascending order and
+// returns the extended slice. It follows parent links instead of keeping a
+// stack, so it allocates only if dst needs to grow.
+func AppendKeys[K cmp.Ordered, V any](t *Tree[K, V], dst []K) []K {
+	dst = slices.Grow(dst, Len(t))
+	for n, ok := Min(t); ok; n, ok = Successor(n) {
+		dst = append(dst, n.key)
+	}
+	return dst
+}
+
+// AppendValues appends the values of the AVL tree to dst in ascending key
+// order and returns the extended slice. Like AppendKeys, it allocates only
+// if dst needs to grow.
+func AppendValues[K cmp.Ordered, V any](t *Tree[K, V], dst []V) []V {
+	dst = slices.Grow(dst, Len(t))
+	for n, ok := Min(t); ok; n, ok = Successor(n) {
+		dst = append(dst, n.value)
+	}
+	return dst
+}
+
 // Range returns an iterator for nodes with keys in the range [from, to).
+// It descends to from in O(log n) and then follows parent links like
+// InOrder, so it does not allocate.
 func Range[K cmp.Ordered, V any](t *Tree[K, V], from, to K) iter.Seq[Node[K, V]] {
 	return func(yield func(Node[K, V]) bool) {
-		stack := []*Node[K, V]{}
-		curr := t.Root
-		for curr != nil || len(stack) > 0 {
-			for curr != nil {
-				stack = append(stack, curr)
-				curr = curr.left
+		for n, ok := Ceiling(t, from); ok && n.key < to; n, ok = Successor(n) {
+			if !yield(*n) {
+				return
 			}
-			n := stack[len(stack)-1]
-			stack = stack[:len(stack)-1]
-			if n.key >= from && n.key < to {
-				if !yield(*n) {
-					return
-				}
+		}
+	}
+}
+
+// InOrderFrom returns an iterator for in-order traversal of the AVL tree
+// starting at the smallest key greater than or equal to the given key.
+func InOrderFrom[K cmp.Ordered, V any](t *Tree[K, V], key K) iter.Seq[Node[K, V]] {
+	return func(yield func(Node[K, V]) bool) {
+		for n, ok := Ceiling(t, key); ok; n, ok = Successor(n) {
+			if !yield(*n) {
+				return
 			}
-			if n.key >= to {
-				curr = nil
-			} else {
-				curr = n.right
+		}
+	}
+}
+
+// ReverseFrom returns an iterator for reverse in-order traversal of the AVL tree
+// starting at the largest key less than or equal to the given key.
+func ReverseFrom[K cmp.Ordered, V any](t *Tree[K, V], key K) iter.Seq[Node[K, V]] {
+	return func(yield func(Node[K, V]) bool) {
+		for n, ok := Floor(t, key); ok; n, ok = Predecessor(n) {
+			if !yield(*n) {
+				return
 			}
 		}
 	}
 }
 
+// PrefixRange returns an iterator for nodes whose keys start with prefix,
+// in key order. It is Range with the smallest string greater than every
+// key with the prefix as the upper bound, or with no upper bound if the
+// prefix is empty or consists only of 0xFF bytes.
+func PrefixRange[K ~string, V any](t *Tree[K, V], prefix K) iter.Seq[Node[K, V]] {
+	end, ok := prefixEnd(prefix)
+	if !ok {
+		return InOrderFrom(t, prefix)
+	}
+	return Range(t, prefix, end)
+}
+
+// prefixEnd returns the smallest string greater than every string starting
+// with prefix. Returns false if there is none.
+func prefixEnd[K ~string](prefix K) (K, bool) {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xFF {
+			return K(append([]byte(prefix[:i]), prefix[i]+1)), true
+		}
+	}
+	return "", false
+}
+
 // Rank returns the number of nodes with keys less than the given key.
+// Returns -1 if the tree was created WithoutOrderStatistics.
 func Rank[K cmp.Ordered, V any](t *Tree[K, V], key K) int {
+	if t.noOrderStats {
+		return -1
+	}
 	rank := 0
 	curr := t.Root
 	for curr != nil {
 		if key < curr.key {
 			curr = curr.left
 		} else {
-			leftSize := 0
-			if curr.left != nil {
-				leftSize = curr.left.size
-			}
+			leftSize := size(curr.left)
 			if key == curr.key {
 				rank += leftSize
 				break
@@ -265,13 +589,14 @@ func Rank[K cmp.Ordered, V any](t *Tree[K, V], key K) int {
 
 // Kth returns the node with the given 0-based rank.
 // Returns the node and true if such rank exists, or nil and false otherwise.
+// Always returns false if the tree was created WithoutOrderStatistics.
 func Kth[K cmp.Ordered, V any](t *Tree[K, V], k int) (*Node[K, V], bool) {
+	if t.noOrderStats {
+		return nil, false
+	}
 	curr := t.Root
 	for curr != nil {
-		leftSize := 0
-		if curr.left != nil {
-			leftSize = curr.left.size
-		}
+		leftSize := size(curr.left)
 		if k < leftSize {
 			curr = curr.left
 		} else if k > leftSize {
@@ -286,41 +611,99 @@ func Kth[K cmp.Ordered, V any](t *Tree[K, V], k int) (*Node[K, V], bool) {
 
 // Len returns the number of nodes in the AVL tree.
 func Len[K cmp.Ordered, V any](t *Tree[K, V]) int {
-	if t.Root == nil {
-		return 0
+	if t.noOrderStats {
+		return t.count
+	}
+	return size(t.Root)
+}
+
+// Version returns a counter that increases whenever the AVL tree is
+// modified: on every insert, overwrite, and delete, including Clear,
+// eviction, and bulk operations. Callers can remember the version a derived
+// value was computed at and recompute it only when the version changes.
+func Version[K cmp.Ordered, V any](t *Tree[K, V]) uint64 {
+	return t.version
+}
+
+// Height returns the height of the AVL tree, or 0 if the tree is empty.
+func Height[K cmp.Ordered, V any](t *Tree[K, V]) int {
+	return height(t.Root)
+}
+
+func evictOverflow[K cmp.Ordered, V any](t *Tree[K, V]) (*Node[K, V], bool) {
+	if t.capacity == 0 || Len(t) <= t.capacity {
+		return nil, false
+	}
+	var victim *Node[K, V]
+	if t.evict == EvictMax {
+		victim = maxNode(t.Root)
+	} else {
+		victim = minNode(t.Root)
 	}
-	return t.Root.size
+	Delete(t, victim.key)
+	victim.left, victim.right, victim.parent = nil, nil, nil
+	victim.balance, victim.size = 0, 1
+	return victim, true
 }
 
-func insertRec[K cmp.Ordered, V any](n *Node[K, V], key K, value V, parent *Node[K, V]) (*Node[K, V], bool) {
+func insert[K cmp.Ordered, V any](t *Tree[K, V], key K, value V) bool {
+	var n *Node[K, V]
+	var inserted bool
+	t.Root, n, inserted, _ = insertRec(t, t.Root, key, value, nil)
+	if inserted {
+		t.count++
+		recordInserts(t, 1)
+		notifyInsert(t, key, value)
+		return true
+	}
+	old := n.value
+	n.value = value
+	refreshUp(t, n)
+	notifyUpdate(t, key, old, value)
+	return false
+}
+
+// insertRec inserts key into the subtree rooted at n unless it already exists.
+// It returns the new subtree root, the node holding key, whether a node was
+// added, and the change in the height of the subtree.
+func insertRec[K cmp.Ordered, V any](t *Tree[K, V], n *Node[K, V], key K, value V, parent *Node[K, V]) (*Node[K, V], *Node[K, V], bool, int) {
 	if n == nil {
-		return &Node[K, V]{key: key, value: value, height: 1, size: 1, parent: parent}, true
+		n = newNode(t, key, value, parent)
+		return n, n, true, 1
 	}
+	var target *Node[K, V]
+	var inserted bool
+	var grown int
 	if key < n.key {
-		var inserted bool
-		n.left, inserted = insertRec(n.left, key, value, n)
-		return rebalance(n), inserted
+		n.left, target, inserted, grown = insertRec(t, n.left, key, value, n)
+		n, grown = rebalanceAfter(t, n, true, grown)
 	} else if key > n.key {
-		var inserted bool
-		n.right, inserted = insertRec(n.right, key, value, n)
-		return rebalance(n), inserted
+		n.right, target, inserted, grown = insertRec(t, n.right, key, value, n)
+		n, grown = rebalanceAfter(t, n, false, grown)
 	} else {
-		n.value = value
-		return n, false
+		return n, n, false, 0
 	}
+	return n, target, inserted, grown
 }
 
-func deleteRec[K cmp.Ordered, V any](n *Node[K, V], key K) (*Node[K, V], bool) {
+// deleteRec deletes key from the subtree rooted at n. It returns the new
+// subtree root, the deleted value, whether key was found, and the change in
+// the height of the subtree.
+func deleteRec[K cmp.Ordered, V any](t *Tree[K, V], n *Node[K, V], key K) (*Node[K, V], V, bool, int) {
+	var value V
 	if n == nil {
-		return nil, false
+		return nil, value, false, 0
 	}
 	var deleted bool
+	var grown int
 	if key < n.key {
-		n.left, deleted = deleteRec(n.left, key)
+		n.left, value, deleted, grown = deleteRec(t, n.left, key)
+		n, grown = rebalanceAfter(t, n, true, grown)
 	} else if key > n.key {
-		n.right, deleted = deleteRec(n.right, key)
+		n.right, value, deleted, grown = deleteRec(t, n.right, key)
+		n, grown = rebalanceAfter(t, n, false, grown)
 	} else {
-		deleted = true
+		value, deleted = n.value, true
 		if n.left == nil || n.right == nil {
 			var child *Node[K, V]
 			if n.left != nil {
@@ -331,59 +714,108 @@ func deleteRec[K cmp.Ordered, V any](n *Node[K, V], key K) (*Node[K, V], bool) {
 			if child != nil {
 				child.parent = n.parent
 			}
-			return child, true
+			return child, value, true, -1
 		}
 		successor := n.right
 		for successor.left != nil {
 			successor = successor.left
 		}
 		n.key, n.value = successor.key, successor.value
-		n.right, _ = deleteRec(n.right, successor.key)
+		n.right, _, _, grown = deleteRec(t, n.right, successor.key)
+		n, grown = rebalanceAfter(t, n, false, grown)
 	}
-	return rebalance(n), deleted
+	return n, value, deleted, grown
 }
 
+// height returns the height of the subtree rooted at n by following the
+// taller child of each node down to a leaf, in O(log n).
 func height[K cmp.Ordered, V any](n *Node[K, V]) int {
+	h := 0
+	for n != nil {
+		h++
+		if n.balance < 0 {
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return h
+}
+
+// childHeights returns the heights of the left and right subtrees of n,
+// given the height h of n itself.
+func childHeights[K cmp.Ordered, V any](n *Node[K, V], h int) (int, int) {
+	if n.balance < 0 {
+		return h - 1 + int(n.balance), h - 1
+	}
+	return h - 1, h - 1 - int(n.balance)
+}
+
+func size[K cmp.Ordered, V any](n *Node[K, V]) int {
 	if n == nil {
 		return 0
 	}
-	return n.height
+	return int(n.size)
 }
 
-func updateSize[K cmp.Ordered, V any](n *Node[K, V]) {
-	n.height = max(height(n.left), height(n.right)) + 1
-	n.size = 1
-	if n.left != nil {
-		n.size += n.left.size
+// updateSize recomputes the subtree size and aggregates of n from its
+// children. The balance factor is maintained separately.
+func updateSize[K cmp.Ordered, V any](t *Tree[K, V], n *Node[K, V]) {
+	if !t.noOrderStats {
+		n.size = uint32(size(n.left) + size(n.right) + 1)
 	}
-	if n.right != nil {
-		n.size += n.right.size
+	if t.aug != nil {
+		t.aug.update(n)
 	}
 }
 
 func balanceFactor[K cmp.Ordered, V any](n *Node[K, V]) int {
-	return height(n.left) - height(n.right)
+	return int(n.balance)
+}
+
+// rebalanceAfter updates n after its left subtree, or its right one if
+// left is false, changed height by grown, which is -1, 0 or 1. It refreshes
+// the size and aggregates of n, rebalances it, and returns the new subtree
+// root and the change in the height of the subtree.
+func rebalanceAfter[K cmp.Ordered, V any](t *Tree[K, V], n *Node[K, V], left bool, grown int) (*Node[K, V], int) {
+	old := int(n.balance)
+	if left {
+		n.balance += int8(grown)
+		grown = max(int(n.balance), 0) - max(old, 0)
+	} else {
+		n.balance -= int8(grown)
+		grown = max(-int(n.balance), 0) - max(-old, 0)
+	}
+	updateSize(t, n)
+	n, shrunk := rebalance(t, n)
+	if shrunk {
+		grown--
+	}
+	return n, grown
 }
 
-func rebalance[K cmp.Ordered, V any](n *Node[K, V]) *Node[K, V] {
-	updateSize(n)
-	balance := balanceFactor(n)
-
-	if balance > 1 {
-		if balanceFactor(n.left) < 0 {
-			n.left = rotateLeft(n.left)
+// rebalance rotates n if its balance factor is 2 or -2 and returns the new
+// subtree root and whether the rotations reduced the height of the subtree.
+// They do unless the taller child of n is itself balanced.
+func rebalance[K cmp.Ordered, V any](t *Tree[K, V], n *Node[K, V]) (*Node[K, V], bool) {
+	if n.balance > 1 {
+		shrunk := n.left.balance != 0
+		if n.left.balance < 0 {
+			n.left = rotateLeft(t, n.left)
 		}
-		return rotateRight(n)
-	} else if balance < -1 {
-		if balanceFactor(n.right) > 0 {
-			n.right = rotateRight(n.right)
+		return rotateRight(t, n), shrunk
+	} else if n.balance < -1 {
+		shrunk := n.right.balance != 0
+		if n.right.balance > 0 {
+			n.right = rotateRight(t, n.right)
 		}
-		return rotateLeft(n)
+		return rotateLeft(t, n), shrunk
 	}
-	return n
+	return n, false
 }
 
-func rotateLeft[K cmp.Ordered, V any](z *Node[K, V]) *Node[K, V] {
+func rotateLeft[K cmp.Ordered, V any](t *Tree[K, V], z *Node[K, V]) *Node[K, V] {
+	recordRotation(t)
 	y := z.right
 	z.right = y.left
 	if y.left != nil {
@@ -392,12 +824,15 @@ func rotateLeft[K cmp.Ordered, V any](z *Node[K, V]) *Node[K, V] {
 	y.left = z
 	y.parent = z.parent
 	z.parent = y
-	updateSize(z)
-	updateSize(y)
+	z.balance += int8(1 - min(int(y.balance), 0))
+	y.balance += int8(1 + max(int(z.balance), 0))
+	updateSize(t, z)
+	updateSize(t, y)
 	return y
 }
 
-func rotateRight[K cmp.Ordered, V any](z *Node[K, V]) *Node[K, V] {
+func rotateRight[K cmp.Ordered, V any](t *Tree[K, V], z *Node[K, V]) *Node[K, V] {
+	recordRotation(t)
 	y := z.left
 	z.left = y.right
 	if y.right != nil {
@@ -406,8 +841,10 @@ func rotateRight[K cmp.Ordered, V any](z *Node[K, V]) *Node[K, V] {
 	y.right = z
 	y.parent = z.parent
 	z.parent = y
-	updateSize(z)
-	updateSize(y)
+	z.balance -= int8(1 + max(int(y.balance), 0))
+	y.balance -= int8(1 - min(int(z.balance), 0))
+	updateSize(t, z)
+	updateSize(t, y)
 	return y
 }
 
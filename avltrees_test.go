@@ -3,7 +3,9 @@ package avltrees_test
 import (
 	"fmt"
 	"math/rand"
+	"strconv"
 	"testing"
+	"unsafe"
 
 	avlts "github.com/byExist/avltrees"
 	"github.com/stretchr/testify/assert"
@@ -16,6 +18,122 @@ func TestNew(t *testing.T) {
 	assert.Equal(t, 0, avlts.Len(tree), "New tree should have size 0")
 }
 
+func TestWithoutOrderStatistics(t *testing.T) {
+	tree := avlts.New[int, string](avlts.WithoutOrderStatistics())
+	for i := range 100 {
+		avlts.Insert(tree, i, "")
+	}
+	avlts.Insert(tree, 5, "again")
+	for i := 0; i < 100; i += 2 {
+		avlts.Delete(tree, i)
+	}
+	avlts.InsertBatch(tree, []avlts.Item[int, string]{{Key: 200}, {Key: 1}})
+	assert.Equal(t, 51, avlts.Len(tree))
+
+	assert.Equal(t, -1, avlts.Rank(tree, 10))
+	_, ok := avlts.Kth(tree, 0)
+	assert.False(t, ok)
+
+	n, ok := avlts.Min(tree)
+	require.True(t, ok)
+	assert.Equal(t, 1, n.Key())
+
+	avlts.Clear(tree)
+	assert.Equal(t, 0, avlts.Len(tree))
+
+	bounded := avlts.NewBounded[int, string](2, avlts.EvictMin, avlts.WithoutOrderStatistics())
+	for i := range 5 {
+		avlts.Insert(bounded, i, "")
+	}
+	assert.Equal(t, 2, avlts.Len(bounded))
+}
+
+func TestNewBounded(t *testing.T) {
+	tree := avlts.NewBounded[int, string](3, avlts.EvictMin)
+	for _, v := range []int{10, 20, 30, 40, 5} {
+		avlts.Insert(tree, v, "")
+	}
+	assert.Equal(t, 3, avlts.Len(tree))
+
+	var keys []int
+	for n := range avlts.InOrder(tree) {
+		keys = append(keys, n.Key())
+	}
+	assert.Equal(t, []int{20, 30, 40}, keys)
+
+	assert.Panics(t, func() { avlts.NewBounded[int, string](0, avlts.EvictMin) })
+}
+
+func TestNewWithCapacity(t *testing.T) {
+	const n = 100
+	empty := testing.AllocsPerRun(10, func() { avlts.New[int, int]() })
+	var tree *avlts.Tree[int, int]
+	allocs := testing.AllocsPerRun(10, func() {
+		tree = avlts.NewWithCapacity[int, int](n)
+		for i := range n {
+			avlts.Insert(tree, i, i)
+		}
+	})
+	assert.Equal(t, empty+1, allocs, "all nodes come from one block")
+	assert.Equal(t, n, avlts.Len(tree))
+	require.NoError(t, avlts.Validate(tree))
+
+	// Past the reserved block, nodes are allocated one at a time.
+	avlts.Insert(tree, n, n)
+	avlts.InsertBatch(tree, []avlts.Item[int, int]{{Key: n + 1}, {Key: n + 2}})
+	assert.Equal(t, n+3, avlts.Len(tree))
+	require.NoError(t, avlts.Validate(tree))
+
+	sums := avlts.NewWithCapacity[int, int](2, avlts.WithPrefixSums[int]())
+	avlts.InsertBatch(sums, []avlts.Item[int, int]{{Key: 1, Value: 1}, {Key: 2, Value: 2}, {Key: 3, Value: 3}})
+	sum, _ := avlts.PrefixSum(sums, 3)
+	assert.Equal(t, 6, sum)
+
+	assert.Panics(t, func() { avlts.NewWithCapacity[int, int](-1) })
+}
+
+func TestInsertIfAbsent(t *testing.T) {
+	tree := avlts.New[string, int]()
+	var updates int
+	avlts.OnUpdate(tree, func(string, int, int) { updates++ })
+
+	assert.True(t, avlts.InsertIfAbsent(tree, "a", 1))
+	assert.False(t, avlts.InsertIfAbsent(tree, "a", 2))
+	assert.Equal(t, 1, avlts.GetOrDefault(tree, "a", 0), "the first value is kept")
+	assert.Zero(t, updates)
+	assert.Equal(t, 1, avlts.Len(tree))
+
+	bounded := avlts.NewBounded[int, string](2, avlts.EvictMin)
+	for i := range 3 {
+		assert.True(t, avlts.InsertIfAbsent(bounded, i, ""))
+	}
+	assert.Equal(t, 2, avlts.Len(bounded), "capacity is enforced")
+}
+
+func TestInsertEvict(t *testing.T) {
+	tree := avlts.NewBounded[int, string](2, avlts.EvictMax)
+
+	_, evicted := avlts.InsertEvict(tree, 10, "ten")
+	assert.False(t, evicted)
+	_, evicted = avlts.InsertEvict(tree, 20, "twenty")
+	assert.False(t, evicted)
+
+	n, evicted := avlts.InsertEvict(tree, 5, "five")
+	require.True(t, evicted)
+	assert.Equal(t, 20, n.Key())
+	assert.Equal(t, "twenty", n.Value())
+	assert.Equal(t, 2, avlts.Len(tree))
+
+	_, evicted = avlts.InsertEvict(tree, 5, "FIVE")
+	assert.False(t, evicted, "overwriting an existing key should not evict")
+
+	unbounded := avlts.New[int, string]()
+	for i := range 100 {
+		_, evicted = avlts.InsertEvict(unbounded, i, "")
+		assert.False(t, evicted)
+	}
+}
+
 func TestInsert(t *testing.T) {
 	tree := avlts.New[int, string]()
 
@@ -46,6 +164,46 @@ func TestDelete(t *testing.T) {
 	assert.False(t, found, "Key 10 should have been deleted")
 }
 
+func TestSetValue(t *testing.T) {
+	tree := avlts.New[int, int](avlts.WithPrefixSums[int]())
+	for i := 1; i <= 5; i++ {
+		avlts.Insert(tree, i, i)
+	}
+	var updates []int
+	avlts.OnUpdate(tree, func(key, old, new int) { updates = append(updates, key, old, new) })
+	version := avlts.Version(tree)
+
+	n, ok := avlts.Search(tree, 1)
+	require.True(t, ok)
+	avlts.SetValue(tree, n, 100)
+
+	assert.Equal(t, 100, avlts.GetOrDefault(tree, 1, 0))
+	assert.Equal(t, []int{1, 1, 100}, updates)
+	assert.Greater(t, avlts.Version(tree), version)
+	sum, _ := avlts.PrefixSum(tree, 5)
+	assert.Equal(t, 114, sum, "aggregates see the new value")
+	assert.NoError(t, avlts.Validate(tree))
+}
+
+func TestReplaceKey(t *testing.T) {
+	tree := avlts.New[int, string]()
+	avlts.Insert(tree, 10, "ten")
+	avlts.Insert(tree, 20, "twenty")
+
+	assert.True(t, avlts.ReplaceKey(tree, 10, 30))
+	_, found := avlts.Search(tree, 10)
+	assert.False(t, found)
+	node, found := avlts.Search(tree, 30)
+	require.True(t, found)
+	assert.Equal(t, "ten", node.Value())
+	assert.Equal(t, 2, avlts.Len(tree))
+
+	assert.False(t, avlts.ReplaceKey(tree, 30, 20), "newKey already exists")
+	assert.False(t, avlts.ReplaceKey(tree, 40, 50), "oldKey does not exist")
+	assert.False(t, avlts.ReplaceKey(tree, 20, 20))
+	assert.Equal(t, 2, avlts.Len(tree))
+}
+
 func TestSearch(t *testing.T) {
 	tree := avlts.New[int, string]()
 	avlts.Insert(tree, 10, "ten")
@@ -59,6 +217,68 @@ func TestSearch(t *testing.T) {
 	assert.False(t, found, "Search should fail for non-existent key 30")
 }
 
+func TestAppendKeys(t *testing.T) {
+	tree := avlts.New[int, string]()
+	assert.Empty(t, avlts.AppendKeys(tree, nil))
+	for _, k := range []int{30, 10, 20} {
+		avlts.Insert(tree, k, strconv.Itoa(k))
+	}
+	assert.Equal(t, []int{10, 20, 30}, avlts.AppendKeys(tree, nil))
+	assert.Equal(t, []int{1, 10, 20, 30}, avlts.AppendKeys(tree, []int{1}))
+
+	buf := make([]int, 0, 8)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = avlts.AppendKeys(tree, buf[:0])
+	})
+	assert.Zero(t, allocs, "reusing a large enough buffer does not allocate")
+}
+
+func TestAppendValues(t *testing.T) {
+	tree := avlts.New[int, string]()
+	for _, k := range []int{30, 10, 20} {
+		avlts.Insert(tree, k, strconv.Itoa(k))
+	}
+	assert.Equal(t, []string{"10", "20", "30"}, avlts.AppendValues(tree, nil))
+
+	buf := make([]string, 0, 8)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = avlts.AppendValues(tree, buf[:0])
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestContains(t *testing.T) {
+	tree := avlts.New[int, string]()
+	assert.False(t, avlts.Contains(tree, 10))
+	avlts.Insert(tree, 10, "ten")
+	avlts.Insert(tree, 5, "five")
+	assert.True(t, avlts.Contains(tree, 10))
+	assert.True(t, avlts.Contains(tree, 5))
+	assert.False(t, avlts.Contains(tree, 7))
+	avlts.Delete(tree, 10)
+	assert.False(t, avlts.Contains(tree, 10))
+}
+
+func TestGet(t *testing.T) {
+	tree := avlts.New[int, string]()
+	avlts.Insert(tree, 10, "ten")
+
+	value, found := avlts.Get(tree, 10)
+	require.True(t, found)
+	assert.Equal(t, "ten", value)
+
+	value, found = avlts.Get(tree, 30)
+	assert.False(t, found)
+	assert.Equal(t, "", value)
+}
+
+func TestGetOrDefault(t *testing.T) {
+	tree := avlts.New[string, int]()
+	avlts.Insert(tree, "a", 0)
+	assert.Equal(t, 0, avlts.GetOrDefault(tree, "a", 7), "stored zero values win over the default")
+	assert.Equal(t, 7, avlts.GetOrDefault(tree, "b", 7))
+}
+
 func TestInOrder(t *testing.T) {
 	tree := avlts.New[int, string]()
 	values := []int{20, 10, 30, 5, 15, 25, 35}
@@ -73,6 +293,17 @@ func TestInOrder(t *testing.T) {
 		}
 		prev = n.Key()
 	}
+
+	// Deep enough that a traversal stack would not fit in a small buffer.
+	big := avlts.New[int, string]()
+	for i := range 1 << 12 {
+		avlts.Insert(big, i, "")
+	}
+	allocs := testing.AllocsPerRun(10, func() {
+		for range avlts.InOrder(big) {
+		}
+	})
+	assert.Zero(t, allocs)
 }
 
 func TestCeiling(t *testing.T) {
@@ -160,6 +391,102 @@ func TestRange(t *testing.T) {
 	for i, v := range expected {
 		assert.Equal(t, v, collected[i], "Expected value at position")
 	}
+
+	for range avlts.Range(tree, 45, 15) {
+		t.Fatal("expected no keys in an empty range")
+	}
+
+	big := avlts.New[int, string]()
+	for i := range 1 << 12 {
+		avlts.Insert(big, i, "")
+	}
+	allocs := testing.AllocsPerRun(10, func() {
+		for range avlts.Range(big, 1000, 1010) {
+		}
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestInOrderFrom(t *testing.T) {
+	tree := avlts.New[int, string]()
+	for _, v := range []int{10, 20, 30, 40, 50} {
+		avlts.Insert(tree, v, "")
+	}
+
+	var collected []int
+	for n := range avlts.InOrderFrom(tree, 25) {
+		collected = append(collected, n.Key())
+	}
+	assert.Equal(t, []int{30, 40, 50}, collected)
+
+	collected = nil
+	for n := range avlts.InOrderFrom(tree, 10) {
+		collected = append(collected, n.Key())
+		if len(collected) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []int{10, 20}, collected)
+
+	for range avlts.InOrderFrom(tree, 60) {
+		t.Fatal("expected no keys after the maximum")
+	}
+}
+
+func TestPrefixRange(t *testing.T) {
+	tree := avlts.New[string, int]()
+	for _, k := range []string{"", "a", "ab", "abc", "abd", "ac", "b", "a\x7f", "a\x80", "\xff", "\xff\xff", "\xff\xffz"} {
+		avlts.Insert(tree, k, 0)
+	}
+	prefixed := func(prefix string) []string {
+		var keys []string
+		for n := range avlts.PrefixRange(tree, prefix) {
+			keys = append(keys, n.Key())
+		}
+		return keys
+	}
+	assert.Equal(t, []string{"ab", "abc", "abd"}, prefixed("ab"))
+	assert.Equal(t, []string{"a", "ab", "abc", "abd", "ac", "a\x7f", "a\x80"}, prefixed("a"))
+	assert.Equal(t, []string{"\xff\xff", "\xff\xffz"}, prefixed("\xff\xff"))
+	assert.Len(t, prefixed(""), 12)
+	assert.Nil(t, prefixed("abz"))
+
+	type path string
+	paths := avlts.New[path, bool]()
+	avlts.Insert(paths, "/usr/bin", true)
+	avlts.Insert(paths, "/usr/lib", true)
+	avlts.Insert(paths, "/var", true)
+	var got []path
+	for n := range avlts.PrefixRange(paths, "/usr/") {
+		got = append(got, n.Key())
+	}
+	assert.Equal(t, []path{"/usr/bin", "/usr/lib"}, got)
+}
+
+func TestReverseFrom(t *testing.T) {
+	tree := avlts.New[int, string]()
+	for _, v := range []int{10, 20, 30, 40, 50} {
+		avlts.Insert(tree, v, "")
+	}
+
+	var collected []int
+	for n := range avlts.ReverseFrom(tree, 35) {
+		collected = append(collected, n.Key())
+	}
+	assert.Equal(t, []int{30, 20, 10}, collected)
+
+	collected = nil
+	for n := range avlts.ReverseFrom(tree, 50) {
+		collected = append(collected, n.Key())
+		if len(collected) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []int{50, 40}, collected)
+
+	for range avlts.ReverseFrom(tree, 5) {
+		t.Fatal("expected no keys before the minimum")
+	}
 }
 
 func TestRank(t *testing.T) {
@@ -250,6 +577,82 @@ func TestLen(t *testing.T) {
 	assert.Equal(t, 3, avlts.Len(tree))
 }
 
+func TestNodeSize(t *testing.T) {
+	ptr := unsafe.Sizeof(uintptr(0))
+	expected := 2*unsafe.Sizeof(int(0)) + 8 + 3*ptr
+	assert.Equal(t, expected, unsafe.Sizeof(avlts.Node[int, int]{}),
+		"balance factor and size should share a single word")
+}
+
+func TestNodeLinks(t *testing.T) {
+	tree := avlts.New[int, string]()
+	for i := 1; i <= 3; i++ {
+		avlts.Insert(tree, i, "")
+	}
+	root := tree.Root
+	assert.Equal(t, 2, root.Key())
+	assert.Nil(t, root.Parent())
+	require.NotNil(t, root.Left())
+	require.NotNil(t, root.Right())
+	assert.Equal(t, 1, root.Left().Key())
+	assert.Equal(t, 3, root.Right().Key())
+	assert.Same(t, root, root.Left().Parent())
+	assert.Same(t, root, root.Right().Parent())
+	assert.Nil(t, root.Left().Left())
+	assert.Nil(t, root.Right().Right())
+}
+
+func TestNodeSizeAndHeight(t *testing.T) {
+	tree := avlts.New[int, string]()
+	for i := 1; i <= 7; i++ {
+		avlts.Insert(tree, i, "")
+	}
+	root := tree.Root
+	assert.Equal(t, 7, root.Size())
+	assert.Equal(t, 3, root.Height())
+	assert.Equal(t, 3, root.Left().Size())
+	assert.Equal(t, 2, root.Left().Height())
+	leaf, _ := avlts.Min(tree)
+	assert.Equal(t, 1, leaf.Size())
+	assert.Equal(t, 1, leaf.Height())
+}
+
+func TestHeight(t *testing.T) {
+	tree := avlts.New[int, string]()
+	assert.Equal(t, 0, avlts.Height(tree))
+	avlts.Insert(tree, 1, "")
+	assert.Equal(t, 1, avlts.Height(tree))
+	for i := 2; i <= 7; i++ {
+		avlts.Insert(tree, i, "")
+	}
+	assert.Equal(t, 3, avlts.Height(tree))
+}
+
+func ExampleNode_Left() {
+	tree := avlts.New[int, string]()
+	for i := 1; i <= 7; i++ {
+		avlts.Insert(tree, i, "")
+	}
+
+	// Walk the left spine of the tree.
+	for n := tree.Root; n != nil; n = n.Left() {
+		fmt.Print(n.Key(), " ")
+	}
+	fmt.Println()
+	// Output: 4 2 1
+}
+
+func ExampleNode_Size() {
+	tree := avlts.New[int, string]()
+	for i := 1; i <= 10; i++ {
+		avlts.Insert(tree, i, "")
+	}
+
+	// Count the keys below the root's key from its left subtree.
+	fmt.Println(tree.Root.Key(), tree.Root.Left().Size())
+	// Output: 4 3
+}
+
 func ExampleNew() {
 	tree := avlts.New[int, string]()
 	fmt.Println(avlts.Len(tree))
@@ -265,6 +668,67 @@ func ExampleInsert() {
 	// Output: 3
 }
 
+func ExampleWithoutOrderStatistics() {
+	tree := avlts.New[int, string](avlts.WithoutOrderStatistics())
+	avlts.Insert(tree, 10, "")
+	avlts.Insert(tree, 20, "")
+	_, ok := avlts.Kth(tree, 0)
+	fmt.Println(avlts.Len(tree), avlts.Rank(tree, 20), ok)
+	// Output: 2 -1 false
+}
+
+func ExamplePrefixRange() {
+	tree := avlts.New[string, int]()
+	for _, k := range []string{"apple", "apricot", "banana", "app"} {
+		avlts.Insert(tree, k, len(k))
+	}
+	for n := range avlts.PrefixRange(tree, "app") {
+		fmt.Println(n.Key(), n.Value())
+	}
+	// Output:
+	// app 3
+	// apple 5
+}
+
+func ExampleNewBounded() {
+	top := avlts.NewBounded[int, string](3, avlts.EvictMin)
+	for _, score := range []int{50, 90, 70, 10, 80} {
+		avlts.Insert(top, score, "")
+	}
+	for n := range avlts.InOrder(top) {
+		fmt.Print(n.Key(), " ")
+	}
+	fmt.Println()
+	// Output: 70 80 90
+}
+
+func ExampleNewWithCapacity() {
+	ids := []string{"u3", "u1", "u2"}
+	users := avlts.NewWithCapacity[string, int](len(ids))
+	for i, id := range ids {
+		avlts.Insert(users, id, i)
+	}
+	fmt.Println(avlts.AppendKeys(users, nil))
+	// Output: [u1 u2 u3]
+}
+
+func ExampleInsertIfAbsent() {
+	cache := avlts.New[string, string]()
+	avlts.InsertIfAbsent(cache, "session", "first")
+	avlts.InsertIfAbsent(cache, "session", "second")
+	fmt.Println(avlts.GetOrDefault(cache, "session", ""))
+	// Output: first
+}
+
+func ExampleInsertEvict() {
+	tree := avlts.NewBounded[int, string](2, avlts.EvictMin)
+	avlts.Insert(tree, 1, "one")
+	avlts.Insert(tree, 2, "two")
+	evicted, ok := avlts.InsertEvict(tree, 3, "three")
+	fmt.Println(evicted.Key(), ok)
+	// Output: 1 true
+}
+
 func ExampleDelete() {
 	tree := avlts.New[int, string]()
 	avlts.Insert(tree, 10, "ten")
@@ -273,6 +737,37 @@ func ExampleDelete() {
 	// Output: 0
 }
 
+func TestNodeSetValue(t *testing.T) {
+	tree := treeOf([]int{1, 2, 3}, []string{"a", "b", "c"})
+	n, ok := avlts.Search(tree, 2)
+	require.True(t, ok)
+	n.SetValue("z")
+
+	assert.Equal(t, "z", avlts.GetOrDefault(tree, 2, ""))
+	assert.Equal(t, []int{1, 2, 3}, treeKeys(tree))
+	assert.NoError(t, avlts.Validate(tree))
+}
+
+func ExampleSetValue() {
+	tree := avlts.New[string, int]()
+	avlts.Insert(tree, "hits", 1)
+
+	if n, ok := avlts.Search(tree, "hits"); ok {
+		avlts.SetValue(tree, n, n.Value()+1)
+	}
+	fmt.Println(avlts.GetOrDefault(tree, "hits", 0))
+	// Output: 2
+}
+
+func ExampleReplaceKey() {
+	tree := avlts.New[string, int]()
+	avlts.Insert(tree, "draft", 42)
+	avlts.ReplaceKey(tree, "draft", "final")
+	node, _ := avlts.Search(tree, "final")
+	fmt.Println(node.Value(), avlts.Len(tree))
+	// Output: 42 1
+}
+
 func ExampleSearch() {
 	tree := avlts.New[int, string]()
 	avlts.Insert(tree, 20, "twenty")
@@ -281,6 +776,47 @@ func ExampleSearch() {
 	// Output: true twenty
 }
 
+func ExampleAppendKeys() {
+	tree := avlts.New[int, string]()
+	for _, k := range []int{3, 1, 2} {
+		avlts.Insert(tree, k, "")
+	}
+	var buf []int
+	buf = avlts.AppendKeys(tree, buf[:0])
+	fmt.Println(buf)
+	// Output: [1 2 3]
+}
+
+func ExampleAppendValues() {
+	tree := avlts.New[int, string]()
+	avlts.Insert(tree, 2, "b")
+	avlts.Insert(tree, 1, "a")
+	fmt.Println(avlts.AppendValues(tree, []string{"start"}))
+	// Output: [start a b]
+}
+
+func ExampleContains() {
+	tree := avlts.New[string, struct{}]()
+	avlts.Insert(tree, "admin", struct{}{})
+	fmt.Println(avlts.Contains(tree, "admin"), avlts.Contains(tree, "guest"))
+	// Output: true false
+}
+
+func ExampleGet() {
+	tree := avlts.New[int, string]()
+	avlts.Insert(tree, 20, "twenty")
+	value, found := avlts.Get(tree, 20)
+	fmt.Println(found, value)
+	// Output: true twenty
+}
+
+func ExampleGetOrDefault() {
+	retries := avlts.New[string, int]()
+	avlts.Insert(retries, "upload", 5)
+	fmt.Println(avlts.GetOrDefault(retries, "upload", 3), avlts.GetOrDefault(retries, "download", 3))
+	// Output: 5 3
+}
+
 func ExampleMin() {
 	tree := avlts.New[int, string]()
 	avlts.Insert(tree, 20, "")
@@ -387,6 +923,30 @@ func ExampleRange() {
 	// Output: 20
 }
 
+func ExampleInOrderFrom() {
+	tree := avlts.New[int, string]()
+	for _, v := range []int{10, 20, 30} {
+		avlts.Insert(tree, v, "")
+	}
+	for n := range avlts.InOrderFrom(tree, 15) {
+		fmt.Print(n.Key(), " ")
+	}
+	fmt.Println()
+	// Output: 20 30
+}
+
+func ExampleReverseFrom() {
+	tree := avlts.New[int, string]()
+	for _, v := range []int{10, 20, 30} {
+		avlts.Insert(tree, v, "")
+	}
+	for n := range avlts.ReverseFrom(tree, 25) {
+		fmt.Print(n.Key(), " ")
+	}
+	fmt.Println()
+	// Output: 20 10
+}
+
 func ExamplePredecessor() {
 	tree := avlts.New[int, string]()
 	avlts.Insert(tree, 20, "")
@@ -435,6 +995,15 @@ func ExampleLen() {
 	// Output: 0
 }
 
+func ExampleHeight() {
+	tree := avlts.New[int, string]()
+	for i := range 7 {
+		avlts.Insert(tree, i, "")
+	}
+	fmt.Println(avlts.Height(tree))
+	// Output: 3
+}
+
 func BenchmarkInsertRandom(b *testing.B) {
 	r := rand.New(rand.NewSource(42))
 	tree := avlts.New[int, string]()
@@ -467,6 +1036,16 @@ func BenchmarkSearchHit(b *testing.B) {
 	}
 }
 
+func BenchmarkContains(b *testing.B) {
+	tree := avlts.New[int, string]()
+	for i := range 1000 {
+		avlts.Insert(tree, i, "value")
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		avlts.Contains(tree, i%1000)
+	}
+}
 func BenchmarkSearchMiss(b *testing.B) {
 	tree := avlts.New[int, string]()
 	for i := range 1000 {
@@ -492,3 +1071,76 @@ func BenchmarkDeleteRandom(b *testing.B) {
 		avlts.Delete(tree, keys[perm[i%1000]])
 	}
 }
+
+// BenchmarkNodeLayout measures avlts.Node itself: its size, reported as
+// B/node, and the cost of filling and searching a tree of such nodes.
+func BenchmarkNodeLayout(b *testing.B) {
+	const n = 1 << 16
+	r := rand.New(rand.NewSource(42))
+	keys := r.Perm(n)
+	nodeSize := float64(unsafe.Sizeof(avlts.Node[int, int]{}))
+
+	b.Run("insert", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ReportMetric(nodeSize, "B/node")
+		for i := 0; i < b.N; i++ {
+			tree := avlts.New[int, int]()
+			for _, k := range keys {
+				avlts.Insert(tree, k, k)
+			}
+		}
+	})
+	b.Run("search", func(b *testing.B) {
+		tree := avlts.New[int, int]()
+		for _, k := range keys {
+			avlts.Insert(tree, k, k)
+		}
+		b.ResetTimer()
+		b.ReportMetric(nodeSize, "B/node")
+		for i := 0; i < b.N; i++ {
+			avlts.Search(tree, keys[i%n])
+		}
+	})
+}
+
+func TestVersion(t *testing.T) {
+	tree := avlts.New[int, string]()
+	assert.Zero(t, avlts.Version(tree))
+
+	steps := []func(){
+		func() { avlts.Insert(tree, 1, "a") },
+		func() { avlts.Insert(tree, 1, "b") },
+		func() { avlts.InsertBatch(tree, []avlts.Item[int, string]{{Key: 2, Value: "c"}}) },
+		func() { avlts.DeleteBefore(tree, 2) },
+		func() { avlts.Delete(tree, 2) },
+		func() { avlts.Insert(tree, 3, "d") },
+		func() { avlts.Clear(tree) },
+	}
+	last := avlts.Version(tree)
+	for i, step := range steps {
+		step()
+		v := avlts.Version(tree)
+		assert.Greater(t, v, last, "step %d", i)
+		last = v
+	}
+
+	avlts.Delete(tree, 42)
+	avlts.Search(tree, 1)
+	avlts.DeleteAfter(tree, 0)
+	avlts.Clear(tree)
+	assert.Equal(t, last, avlts.Version(tree), "no-ops keep the version")
+}
+
+func ExampleVersion() {
+	tree := avlts.New[string, int]()
+	avlts.Insert(tree, "a", 1)
+
+	cachedAt := avlts.Version(tree)
+	avlts.Search(tree, "a")
+	fmt.Println(avlts.Version(tree) == cachedAt)
+	avlts.Insert(tree, "a", 2)
+	fmt.Println(avlts.Version(tree) == cachedAt)
+	// Output:
+	// true
+	// false
+}
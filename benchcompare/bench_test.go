@@ -0,0 +1,204 @@
+package benchcompare
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/google/btree"
+)
+
+// orderedMap is the common surface exercised by the benchmarks.
+type orderedMap interface {
+	Insert(key, value int)
+	Search(key int) (int, bool)
+	Range(from, to int, visit func(key, value int) bool)
+}
+
+type impl struct {
+	name string
+	new  func() orderedMap
+}
+
+var impls = []impl{
+	{"avltrees", func() orderedMap { return &avlMap{avlts.New[int, int]()} }},
+	{"map+sort", func() orderedMap { return &sortedMap{m: map[int]int{}} }},
+	{"btree", func() orderedMap {
+		return &btreeMap{btree.NewG(32, func(a, b avlts.Item[int, int]) bool { return a.Key < b.Key })}
+	}},
+	{"skiplist", func() orderedMap { return newSkiplist[int, int]() }},
+}
+
+var sizes = []int{1_000, 10_000, 100_000}
+
+const rangeWidth = 100
+
+type avlMap struct {
+	t *avlts.Tree[int, int]
+}
+
+func (m *avlMap) Insert(key, value int) { avlts.Insert(m.t, key, value) }
+
+func (m *avlMap) Search(key int) (int, bool) {
+	n, ok := avlts.Search(m.t, key)
+	if !ok {
+		return 0, false
+	}
+	return n.Value(), true
+}
+
+func (m *avlMap) Range(from, to int, visit func(int, int) bool) {
+	for n := range avlts.Range(m.t, from, to) {
+		if !visit(n.Key(), n.Value()) {
+			return
+		}
+	}
+}
+
+// sortedMap is a Go map that sorts its keys on the first range query
+// after a modification.
+type sortedMap struct {
+	m      map[int]int
+	sorted []int
+}
+
+func (m *sortedMap) Insert(key, value int) {
+	if _, ok := m.m[key]; !ok {
+		m.sorted = nil
+	}
+	m.m[key] = value
+}
+
+func (m *sortedMap) Search(key int) (int, bool) {
+	v, ok := m.m[key]
+	return v, ok
+}
+
+func (m *sortedMap) Range(from, to int, visit func(int, int) bool) {
+	if m.sorted == nil {
+		m.sorted = make([]int, 0, len(m.m))
+		for k := range m.m {
+			m.sorted = append(m.sorted, k)
+		}
+		slices.Sort(m.sorted)
+	}
+	i, _ := slices.BinarySearch(m.sorted, from)
+	for ; i < len(m.sorted) && m.sorted[i] < to; i++ {
+		if !visit(m.sorted[i], m.m[m.sorted[i]]) {
+			return
+		}
+	}
+}
+
+type btreeMap struct {
+	t *btree.BTreeG[avlts.Item[int, int]]
+}
+
+func (m *btreeMap) Insert(key, value int) {
+	m.t.ReplaceOrInsert(avlts.Item[int, int]{Key: key, Value: value})
+}
+
+func (m *btreeMap) Search(key int) (int, bool) {
+	it, ok := m.t.Get(avlts.Item[int, int]{Key: key})
+	return it.Value, ok
+}
+
+func (m *btreeMap) Range(from, to int, visit func(int, int) bool) {
+	m.t.AscendRange(avlts.Item[int, int]{Key: from}, avlts.Item[int, int]{Key: to}, func(it avlts.Item[int, int]) bool {
+		return visit(it.Key, it.Value)
+	})
+}
+
+func randomKeys(n int) []int {
+	r := rand.New(rand.NewPCG(42, uint64(n)))
+	keys := make([]int, n)
+	for i := range keys {
+		keys[i] = r.IntN(n * 10)
+	}
+	return keys
+}
+
+func build(im impl, keys []int) orderedMap {
+	m := im.new()
+	for _, k := range keys {
+		m.Insert(k, k)
+	}
+	return m
+}
+
+func forEach(b *testing.B, run func(b *testing.B, im impl, n int)) {
+	for _, im := range impls {
+		for _, n := range sizes {
+			b.Run(fmt.Sprintf("%s/n=%d", im.name, n), func(b *testing.B) {
+				b.ReportAllocs()
+				run(b, im, n)
+			})
+		}
+	}
+}
+
+func BenchmarkInsert(b *testing.B) {
+	forEach(b, func(b *testing.B, im impl, n int) {
+		keys := randomKeys(n)
+		b.ResetTimer()
+		for i := 0; i < b.N; i += n {
+			b.StopTimer()
+			m := im.new()
+			b.StartTimer()
+			for _, k := range keys[:min(n, b.N-i)] {
+				m.Insert(k, k)
+			}
+		}
+	})
+}
+
+func BenchmarkSearch(b *testing.B) {
+	forEach(b, func(b *testing.B, im impl, n int) {
+		keys := randomKeys(n)
+		m := build(im, keys)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			m.Search(keys[i%n])
+		}
+	})
+}
+
+func BenchmarkRange(b *testing.B) {
+	forEach(b, func(b *testing.B, im impl, n int) {
+		keys := randomKeys(n)
+		m := build(im, keys)
+		m.Range(0, 1, func(int, int) bool { return true })
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			seen := 0
+			m.Range(keys[i%n], n*10, func(int, int) bool {
+				seen++
+				return seen < rangeWidth
+			})
+		}
+	})
+}
+
+func BenchmarkFootprint(b *testing.B) {
+	for _, im := range impls {
+		n := sizes[len(sizes)-1]
+		b.Run(fmt.Sprintf("%s/n=%d", im.name, n), func(b *testing.B) {
+			keys := randomKeys(n)
+			var perEntry float64
+			for range b.N {
+				var before, after runtime.MemStats
+				runtime.GC()
+				runtime.ReadMemStats(&before)
+				m := build(im, keys)
+				runtime.GC()
+				runtime.ReadMemStats(&after)
+				perEntry = float64(after.HeapAlloc-before.HeapAlloc) / float64(n)
+				runtime.KeepAlive(m)
+			}
+			b.ReportMetric(perEntry, "heapB/entry")
+		})
+	}
+}
@@ -0,0 +1,13 @@
+// Package benchcompare benchmarks avltrees against other ordered-map
+// implementations: a Go map whose keys are sorted on demand,
+// github.com/google/btree, and a skiplist.
+//
+// It is a separate module so that its dependencies do not leak into
+// avltrees itself. Run the benchmarks from this directory with
+//
+//	go test -bench . -benchmem
+//
+// Each benchmark is named Benchmark<Workload>/<impl>/n=<size>, and reports
+// allocations per operation. BenchmarkFootprint additionally reports the
+// heap bytes retained per entry after building a container of each size.
+package benchcompare
@@ -0,0 +1,17 @@
+module github.com/byExist/avltrees/benchcompare
+
+go 1.23.0
+
+require (
+	github.com/byExist/avltrees v0.0.0
+	github.com/google/btree v1.1.3
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/byExist/avltrees => ../
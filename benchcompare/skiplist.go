@@ -0,0 +1,99 @@
+package benchcompare
+
+import (
+	"cmp"
+	"math/rand/v2"
+)
+
+const skiplistMaxLevel = 24
+
+// skiplist is a plain probabilistic skiplist with p = 1/4, used as a
+// baseline ordered map.
+type skiplist[K cmp.Ordered, V any] struct {
+	head  skipNode[K, V]
+	level int
+	len   int
+	rng   *rand.Rand
+}
+
+type skipNode[K cmp.Ordered, V any] struct {
+	key   K
+	value V
+	next  []*skipNode[K, V]
+}
+
+func newSkiplist[K cmp.Ordered, V any]() *skiplist[K, V] {
+	return &skiplist[K, V]{
+		head:  skipNode[K, V]{next: make([]*skipNode[K, V], skiplistMaxLevel)},
+		level: 1,
+		rng:   rand.New(rand.NewPCG(1, 1)),
+	}
+}
+
+func (s *skiplist[K, V]) randomLevel() int {
+	level := 1
+	for level < skiplistMaxLevel && s.rng.Uint32()&3 == 0 {
+		level++
+	}
+	return level
+}
+
+func (s *skiplist[K, V]) Insert(key K, value V) {
+	var update [skiplistMaxLevel]*skipNode[K, V]
+	x := &s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key < key {
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+	if n := x.next[0]; n != nil && n.key == key {
+		n.value = value
+		return
+	}
+	level := s.randomLevel()
+	for i := s.level; i < level; i++ {
+		update[i] = &s.head
+	}
+	s.level = max(s.level, level)
+	n := &skipNode[K, V]{key: key, value: value, next: make([]*skipNode[K, V], level)}
+	for i := range level {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+	}
+	s.len++
+}
+
+func (s *skiplist[K, V]) Search(key K) (V, bool) {
+	x := &s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key < key {
+			x = x.next[i]
+		}
+	}
+	if n := x.next[0]; n != nil && n.key == key {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Range calls visit for each key in [from, to) in order until visit
+// returns false.
+func (s *skiplist[K, V]) Range(from, to K, visit func(K, V) bool) {
+	x := &s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key < from {
+			x = x.next[i]
+		}
+	}
+	for n := x.next[0]; n != nil && n.key < to; n = n.next[0] {
+		if !visit(n.key, n.value) {
+			return
+		}
+	}
+}
+
+func (s *skiplist[K, V]) Len() int {
+	return s.len
+}
@@ -0,0 +1,55 @@
+package benchcompare
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImplementationsAgree(t *testing.T) {
+	keys := randomKeys(2000)
+	want := slices.Clone(keys)
+	slices.Sort(want)
+	want = slices.Compact(want)
+
+	for _, im := range impls {
+		t.Run(im.name, func(t *testing.T) {
+			m := build(im, keys)
+			for _, k := range keys {
+				v, ok := m.Search(k)
+				require.True(t, ok)
+				require.Equal(t, k, v)
+			}
+			_, ok := m.Search(-1)
+			assert.False(t, ok)
+
+			var got []int
+			m.Range(want[10], want[60], func(k, _ int) bool {
+				got = append(got, k)
+				return true
+			})
+			assert.Equal(t, want[10:60], got)
+		})
+	}
+}
+
+func TestSkiplistOverwrite(t *testing.T) {
+	s := newSkiplist[int, string]()
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 1000 {
+		k := r.IntN(100)
+		s.Insert(k, "a")
+		s.Insert(k, "b")
+	}
+	assert.LessOrEqual(t, s.Len(), 100)
+	count := 0
+	s.Range(0, 100, func(_ int, v string) bool {
+		assert.Equal(t, "b", v)
+		count++
+		return true
+	})
+	assert.Equal(t, s.Len(), count)
+}
@@ -0,0 +1,74 @@
+package avltrees
+
+import "cmp"
+
+// BTree adapts an AVL tree to the method set of github.com/google/btree's BTreeG,
+// so code written against that package can switch implementations without
+// changing call sites. Items are ordered by Key only.
+type BTree[K cmp.Ordered, V any] struct {
+	tree Tree[K, V]
+}
+
+// NewBTree returns a new empty BTree adapter.
+func NewBTree[K cmp.Ordered, V any]() *BTree[K, V] {
+	return &BTree[K, V]{}
+}
+
+// ReplaceOrInsert adds the item to the tree. If an item with the same key
+// already exists, it is replaced and returned along with true.
+func (b *BTree[K, V]) ReplaceOrInsert(item Item[K, V]) (Item[K, V], bool) {
+	var old Item[K, V]
+	n, found := Search(&b.tree, item.Key)
+	if found {
+		old = Item[K, V]{n.key, n.value}
+	}
+	Insert(&b.tree, item.Key, item.Value)
+	return old, found
+}
+
+// Get returns the item whose key equals key.Key, if any.
+func (b *BTree[K, V]) Get(key Item[K, V]) (Item[K, V], bool) {
+	n, found := Search(&b.tree, key.Key)
+	if !found {
+		return Item[K, V]{}, false
+	}
+	return Item[K, V]{n.key, n.value}, true
+}
+
+// Has reports whether an item with the key of key.Key exists.
+func (b *BTree[K, V]) Has(key Item[K, V]) bool {
+	_, found := Search(&b.tree, key.Key)
+	return found
+}
+
+// Delete removes the item whose key equals item.Key and returns it, if any.
+func (b *BTree[K, V]) Delete(item Item[K, V]) (Item[K, V], bool) {
+	old, found := b.Get(item)
+	if found {
+		Delete(&b.tree, item.Key)
+	}
+	return old, found
+}
+
+// Len returns the number of items in the tree.
+func (b *BTree[K, V]) Len() int {
+	return Len(&b.tree)
+}
+
+// Min returns the item with the smallest key, if any.
+func (b *BTree[K, V]) Min() (Item[K, V], bool) {
+	n, ok := Min(&b.tree)
+	if !ok {
+		return Item[K, V]{}, false
+	}
+	return Item[K, V]{n.key, n.value}, true
+}
+
+// Max returns the item with the largest key, if any.
+func (b *BTree[K, V]) Max() (Item[K, V], bool) {
+	n, ok := Max(&b.tree)
+	if !ok {
+		return Item[K, V]{}, false
+	}
+	return Item[K, V]{n.key, n.value}, true
+}
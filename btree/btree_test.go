@@ -0,0 +1,231 @@
+package btree_test
+
+import (
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/byExist/avltrees/btree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func keys[V any](t *btree.Tree[int, V]) []int {
+	return seqKeys(btree.All(t))
+}
+
+func seqKeys[V any](seq iter.Seq2[int, V]) []int {
+	var out []int
+	for k := range seq {
+		out = append(out, k)
+	}
+	return out
+}
+
+func values(t *btree.Tree[int, int]) []int {
+	var out []int
+	for _, v := range btree.All(t) {
+		out = append(out, v)
+	}
+	return out
+}
+
+func evens(n int) *btree.Tree[int, string] {
+	tree := btree.New[int, string]()
+	for i := 0; i < n; i += 2 {
+		btree.Insert(tree, i, fmt.Sprint(i))
+	}
+	return tree
+}
+
+func TestInsert(t *testing.T) {
+	tree := btree.New[int, string]()
+	assert.True(t, btree.Insert(tree, 2, "b"))
+	assert.True(t, btree.Insert(tree, 1, "a"))
+	assert.False(t, btree.Insert(tree, 2, "B"), "existing keys are updated")
+	assert.Equal(t, 2, btree.Len(tree))
+
+	v, ok := btree.Get(tree, 2)
+	require.True(t, ok)
+	assert.Equal(t, "B", v)
+	assert.Equal(t, []int{1, 2}, keys(tree))
+
+	for i := range 1000 {
+		btree.Insert(tree, i, "")
+	}
+	assert.Equal(t, 1000, btree.Len(tree))
+	assert.Equal(t, 3, btree.Height(tree), "wide nodes keep the tree shallow")
+}
+
+func TestZeroValue(t *testing.T) {
+	var tree btree.Tree[int, int]
+	_, _, ok := btree.Min(&tree)
+	assert.False(t, ok)
+	assert.False(t, btree.Delete(&tree, 1))
+	assert.Equal(t, 0, btree.Height(&tree))
+	assert.True(t, btree.Insert(&tree, 1, 1))
+	assert.True(t, btree.Contains(&tree, 1))
+}
+
+func TestDelete(t *testing.T) {
+	tree := evens(1000)
+	assert.False(t, btree.Delete(tree, 3))
+	for i := 0; i < 1000; i += 4 {
+		assert.True(t, btree.Delete(tree, i))
+	}
+	assert.False(t, btree.Delete(tree, 0))
+	assert.Equal(t, 250, btree.Len(tree))
+	assert.False(t, btree.Contains(tree, 4))
+	assert.True(t, btree.Contains(tree, 6))
+
+	for _, k := range keys(tree) {
+		btree.Delete(tree, k)
+	}
+	assert.Equal(t, 0, btree.Len(tree))
+	assert.Equal(t, 0, btree.Height(tree))
+}
+
+func TestClear(t *testing.T) {
+	tree := evens(100)
+	btree.Clear(tree)
+	assert.Equal(t, 0, btree.Len(tree))
+	assert.Empty(t, keys(tree))
+}
+
+func TestNeighbours(t *testing.T) {
+	tree := evens(1000)
+	k, _, ok := btree.Min(tree)
+	assert.True(t, ok)
+	assert.Equal(t, 0, k)
+	k, _, _ = btree.Max(tree)
+	assert.Equal(t, 998, k)
+
+	k, v, ok := btree.Ceiling(tree, 501)
+	assert.True(t, ok)
+	assert.Equal(t, 502, k)
+	assert.Equal(t, "502", v)
+	k, _, _ = btree.Ceiling(tree, 500)
+	assert.Equal(t, 500, k)
+	_, _, ok = btree.Ceiling(tree, 999)
+	assert.False(t, ok)
+
+	k, _, ok = btree.Floor(tree, 501)
+	assert.True(t, ok)
+	assert.Equal(t, 500, k)
+	_, _, ok = btree.Floor(tree, -1)
+	assert.False(t, ok)
+}
+
+func TestRange(t *testing.T) {
+	tree := evens(1000)
+	assert.Equal(t, []int{14, 16, 18, 20}, seqKeys(btree.Range(tree, 13, 21)))
+	assert.Equal(t, []int{14, 16}, seqKeys(btree.Range(tree, 14, 18)))
+	assert.Empty(t, seqKeys(btree.Range(tree, 21, 13)))
+	assert.Len(t, seqKeys(btree.Range(tree, -5, 5000)), 500)
+
+	var got []int
+	for k := range btree.Range(tree, 0, 1000) {
+		if k == 6 {
+			break
+		}
+		got = append(got, k)
+	}
+	assert.Equal(t, []int{0, 2, 4}, got)
+}
+
+func TestRankAndKth(t *testing.T) {
+	tree := evens(1000)
+	assert.Equal(t, 0, btree.Rank(tree, 0))
+	assert.Equal(t, 5, btree.Rank(tree, 9))
+	assert.Equal(t, 5, btree.Rank(tree, 10))
+	assert.Equal(t, 500, btree.Rank(tree, 1000))
+
+	for i := range 500 {
+		k, _, ok := btree.Kth(tree, i)
+		require.True(t, ok)
+		assert.Equal(t, 2*i, k)
+	}
+	_, _, ok := btree.Kth(tree, 500)
+	assert.False(t, ok)
+	_, _, ok = btree.Kth(tree, -1)
+	assert.False(t, ok)
+}
+
+func TestRandomAgainstTree(t *testing.T) {
+	r := rand.New(rand.NewPCG(7, 8))
+	tree := btree.New[int, int]()
+	want := avlts.New[int, int]()
+	for i := range 50000 {
+		k := r.IntN(2000)
+		if r.IntN(2) == 0 {
+			require.Equal(t, avlts.Delete(want, k), btree.Delete(tree, k))
+		} else {
+			require.Equal(t, avlts.Insert(want, k, i), btree.Insert(tree, k, i))
+		}
+	}
+	assert.Equal(t, avlts.Len(want), btree.Len(tree))
+	assert.Equal(t, avlts.AppendKeys(want, nil), keys(tree))
+	assert.Equal(t, avlts.AppendValues(want, nil), values(tree))
+	for k := -1; k <= 2000; k++ {
+		assert.Equal(t, avlts.Rank(want, k), btree.Rank(tree, k))
+		var wantFloor, gotFloor int
+		if n, ok := avlts.Floor(want, k); ok {
+			wantFloor = n.Key()
+		}
+		gotFloor, _, _ = btree.Floor(tree, k)
+		assert.Equal(t, wantFloor, gotFloor)
+	}
+	for from := range 50 {
+		from *= 40
+		var wantRange []int
+		for n := range avlts.Range(want, from, from+77) {
+			wantRange = append(wantRange, n.Key())
+		}
+		assert.Equal(t, wantRange, seqKeys(btree.Range(tree, from, from+77)))
+	}
+}
+
+func ExampleInsert() {
+	tree := btree.New[string, int]()
+	btree.Insert(tree, "b", 2)
+	btree.Insert(tree, "a", 1)
+	btree.Insert(tree, "c", 3)
+	for k, v := range btree.All(tree) {
+		fmt.Println(k, v)
+	}
+	// Output:
+	// a 1
+	// b 2
+	// c 3
+}
+
+func ExampleKth() {
+	tree := btree.New[int, string]()
+	for i := 1; i <= 100; i++ {
+		btree.Insert(tree, i*10, "")
+	}
+	k, _, _ := btree.Kth(tree, 49)
+	fmt.Println(k, btree.Rank(tree, k))
+	// Output: 500 49
+}
+
+func BenchmarkSearch(b *testing.B) {
+	keys := rand.New(rand.NewPCG(1, 1)).Perm(100_000)
+	bt, at := btree.New[int, int](), avlts.New[int, int]()
+	for _, k := range keys {
+		btree.Insert(bt, k, k)
+		avlts.Insert(at, k, k)
+	}
+	b.Run("btree", func(b *testing.B) {
+		for i := range b.N {
+			btree.Get(bt, keys[i%len(keys)])
+		}
+	})
+	b.Run("avltrees", func(b *testing.B) {
+		for i := range b.N {
+			avlts.Get(at, keys[i%len(keys)])
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item = avlts.Item[int, string]
+
+func TestBTreeReplaceOrInsert(t *testing.T) {
+	bt := avlts.NewBTree[int, string]()
+
+	_, replaced := bt.ReplaceOrInsert(item{Key: 1, Value: "one"})
+	assert.False(t, replaced)
+
+	old, replaced := bt.ReplaceOrInsert(item{Key: 1, Value: "ONE"})
+	require.True(t, replaced)
+	assert.Equal(t, item{Key: 1, Value: "one"}, old)
+
+	got, ok := bt.Get(item{Key: 1})
+	require.True(t, ok)
+	assert.Equal(t, "ONE", got.Value)
+	assert.Equal(t, 1, bt.Len())
+}
+
+func TestBTreeGet(t *testing.T) {
+	bt := avlts.NewBTree[int, string]()
+	bt.ReplaceOrInsert(item{Key: 1, Value: "one"})
+
+	got, ok := bt.Get(item{Key: 1})
+	require.True(t, ok)
+	assert.Equal(t, item{Key: 1, Value: "one"}, got)
+	assert.True(t, bt.Has(item{Key: 1}))
+
+	_, ok = bt.Get(item{Key: 2})
+	assert.False(t, ok)
+	assert.False(t, bt.Has(item{Key: 2}))
+}
+
+func TestBTreeDelete(t *testing.T) {
+	bt := avlts.NewBTree[int, string]()
+	bt.ReplaceOrInsert(item{Key: 1, Value: "one"})
+	bt.ReplaceOrInsert(item{Key: 2, Value: "two"})
+
+	old, ok := bt.Delete(item{Key: 1})
+	require.True(t, ok)
+	assert.Equal(t, item{Key: 1, Value: "one"}, old)
+	assert.Equal(t, 1, bt.Len())
+
+	_, ok = bt.Delete(item{Key: 1})
+	assert.False(t, ok)
+}
+
+func TestBTreeMinMax(t *testing.T) {
+	bt := avlts.NewBTree[int, string]()
+	_, ok := bt.Min()
+	assert.False(t, ok)
+	_, ok = bt.Max()
+	assert.False(t, ok)
+
+	for _, k := range []int{20, 10, 30} {
+		bt.ReplaceOrInsert(item{Key: k})
+	}
+
+	lo, ok := bt.Min()
+	require.True(t, ok)
+	assert.Equal(t, 10, lo.Key)
+
+	hi, ok := bt.Max()
+	require.True(t, ok)
+	assert.Equal(t, 30, hi.Key)
+}
+
+func ExampleBTree() {
+	bt := avlts.NewBTree[string, int]()
+	bt.ReplaceOrInsert(avlts.Item[string, int]{Key: "a", Value: 1})
+	old, replaced := bt.ReplaceOrInsert(avlts.Item[string, int]{Key: "a", Value: 2})
+	fmt.Println(old.Value, replaced, bt.Len())
+	// Output: 1 true 1
+}
@@ -0,0 +1,254 @@
+package avltrees
+
+import (
+	"cmp"
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+// InsertBatch inserts all items into the AVL tree, overwriting existing keys.
+// When the same key appears more than once in items, the last one wins.
+// The batch is sorted and merged with the existing nodes, and the tree is
+// rebuilt once in O(n + m log m) instead of rebalancing after every key.
+// Returns the number of keys that were not already present.
+func InsertBatch[K cmp.Ordered, V any](t *Tree[K, V], items []Item[K, V]) int {
+	checkMutable(t)
+	if len(items) == 0 {
+		return 0
+	}
+	return insertOwnedBatch(t, slices.Clone(items))
+}
+
+// InsertAll inserts every entry of m into the AVL tree, overwriting
+// existing keys, with a single sort-and-merge rebuild as in InsertBatch.
+// Returns the number of keys that were not already present.
+func InsertAll[K cmp.Ordered, V any](t *Tree[K, V], m map[K]V) int {
+	checkMutable(t)
+	if len(m) == 0 {
+		return 0
+	}
+	batch := make([]Item[K, V], 0, len(m))
+	for k, v := range m {
+		batch = append(batch, Item[K, V]{k, v})
+	}
+	return insertOwnedBatch(t, batch)
+}
+
+// InsertSeq2 inserts every key-value pair produced by seq into the AVL tree,
+// overwriting existing keys, with a single sort-and-merge rebuild as in
+// InsertBatch. When seq yields a key more than once, the last pair wins.
+// Returns the number of keys that were not already present.
+func InsertSeq2[K cmp.Ordered, V any](t *Tree[K, V], seq iter.Seq2[K, V]) int {
+	checkMutable(t)
+	var batch []Item[K, V]
+	for k, v := range seq {
+		batch = append(batch, Item[K, V]{k, v})
+	}
+	if len(batch) == 0 {
+		return 0
+	}
+	return insertOwnedBatch(t, batch)
+}
+
+// insertOwnedBatch implements InsertBatch on a slice it may reorder.
+func insertOwnedBatch[K cmp.Ordered, V any](t *Tree[K, V], batch []Item[K, V]) int {
+	slices.SortStableFunc(batch, func(a, b Item[K, V]) int { return cmp.Compare(a.Key, b.Key) })
+
+	existing := appendNodes(make([]*Node[K, V], 0, Len(t)), t.Root)
+	merged := make([]*Node[K, V], 0, len(existing)+len(batch))
+	var added []*Node[K, V]
+	type update struct {
+		key      K
+		old, new V
+	}
+	var updated []update
+	inserted := 0
+	i := 0
+	for j := 0; j < len(batch); j++ {
+		if j+1 < len(batch) && batch[j+1].Key == batch[j].Key {
+			continue
+		}
+		it := batch[j]
+		for i < len(existing) && existing[i].key < it.Key {
+			merged = append(merged, existing[i])
+			i++
+		}
+		if i < len(existing) && existing[i].key == it.Key {
+			if t.hooks != nil {
+				updated = append(updated, update{it.Key, existing[i].value, it.Value})
+			}
+			existing[i].value = it.Value
+			merged = append(merged, existing[i])
+			i++
+			continue
+		}
+		n := allocNode(t)
+		n.key, n.value = it.Key, it.Value
+		merged = append(merged, n)
+		if t.hooks != nil {
+			added = append(added, n)
+		}
+		inserted++
+	}
+	merged = append(merged, existing[i:]...)
+
+	t.Root = buildFromNodes(t, merged, nil)
+	t.count = len(merged)
+	t.version++
+	recordInserts(t, inserted)
+	for _, u := range updated {
+		notifyUpdate(t, u.key, u.old, u.new)
+	}
+	for _, n := range added {
+		notifyInsert(t, n.key, n.value)
+	}
+	for {
+		if _, evicted := evictOverflow(t); !evicted {
+			break
+		}
+	}
+	return inserted
+}
+
+// DeleteAll removes every key produced by keys from the AVL tree and
+// returns the number of keys that were present. Small batches are deleted
+// one at a time; when the batch is large relative to the tree, the
+// surviving nodes are relinked into a balanced tree in a single O(n) pass
+// instead of rebalancing after every removal.
+func DeleteAll[K cmp.Ordered, V any](t *Tree[K, V], keys iter.Seq[K]) int {
+	checkMutable(t)
+	batch := slices.Sorted(keys)
+	batch = slices.Compact(batch)
+	n := Len(t)
+	if len(batch) == 0 || n == 0 {
+		return 0
+	}
+	if len(batch)*bits.Len(uint(n)) < n {
+		removed := 0
+		for _, key := range batch {
+			if Delete(t, key) {
+				removed++
+			}
+		}
+		return removed
+	}
+
+	i := 0
+	return retain(t, func(nd *Node[K, V]) bool {
+		for i < len(batch) && batch[i] < nd.key {
+			i++
+		}
+		return i == len(batch) || batch[i] != nd.key
+	})
+}
+
+// DeleteFunc removes every entry for which pred returns true and returns
+// the number of entries removed. pred is called once per entry, in key
+// order, before the tree is changed, and must not modify the tree. When
+// many entries match, the survivors are relinked into a balanced tree in a
+// single O(n) pass instead of rebalancing after every removal.
+func DeleteFunc[K cmp.Ordered, V any](t *Tree[K, V], pred func(key K, value V) bool) int {
+	checkMutable(t)
+	return retain(t, func(n *Node[K, V]) bool { return !pred(n.key, n.value) })
+}
+
+// RetainFunc removes every entry for which keep returns false, the
+// complement of DeleteFunc, and returns the number of entries removed.
+// keep is called once per entry, in key order, before the tree is
+// changed, and must not modify the tree. Unless only a few entries are
+// removed, the tree is rebuilt from the surviving nodes in O(n).
+func RetainFunc[K cmp.Ordered, V any](t *Tree[K, V], keep func(key K, value V) bool) int {
+	checkMutable(t)
+	return retain(t, func(n *Node[K, V]) bool { return keep(n.key, n.value) })
+}
+
+// Compact relinks the nodes of the AVL tree into a tree of minimum height,
+// ceil(log2(n+1)), in O(n). Insertions and deletions only keep the height
+// within about 1.44 log2(n), so compacting a tree that is mostly read
+// afterwards shortens every search. The nodes themselves are reused, so
+// pointers to them stay valid, and since no entry changes, Version does not
+// advance and no hooks are called.
+func Compact[K cmp.Ordered, V any](t *Tree[K, V]) {
+	checkMutable(t)
+	t.Root = buildFromNodes(t, appendNodes(make([]*Node[K, V], 0, t.count), t.Root), nil)
+}
+
+// retain removes the nodes for which keep returns false, calling keep on
+// every node in key order first. Few removals are made one at a time;
+// otherwise the kept nodes are relinked into a new balanced tree.
+func retain[K cmp.Ordered, V any](t *Tree[K, V], keep func(*Node[K, V]) bool) int {
+	n := Len(t)
+	if n == 0 {
+		return 0
+	}
+	nodes := appendNodes(make([]*Node[K, V], 0, n), t.Root)
+	kept := nodes[:0]
+	var dropped []*Node[K, V]
+	for _, nd := range nodes {
+		if keep(nd) {
+			kept = append(kept, nd)
+		} else {
+			dropped = append(dropped, nd)
+		}
+	}
+	if len(dropped)*bits.Len(uint(n)) < n {
+		// Delete may move keys between nodes, so take them all first.
+		keys := make([]K, len(dropped))
+		for i, nd := range dropped {
+			keys[i] = nd.key
+		}
+		for _, key := range keys {
+			Delete(t, key)
+		}
+		return len(keys)
+	}
+	t.Root = buildFromNodes(t, kept, nil)
+	t.count = len(kept)
+	t.version++
+	recordDeletes(t, len(dropped))
+	for _, nd := range dropped {
+		notifyDelete(t, nd.key, nd.value)
+	}
+	return len(dropped)
+}
+
+// appendNodes appends the nodes of the subtree rooted at n to dst in key order.
+func appendNodes[K cmp.Ordered, V any](dst []*Node[K, V], n *Node[K, V]) []*Node[K, V] {
+	stack := []*Node[K, V]{}
+	curr := n
+	for curr != nil || len(stack) > 0 {
+		for curr != nil {
+			stack = append(stack, curr)
+			curr = curr.left
+		}
+		curr = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		dst = append(dst, curr)
+		curr = curr.right
+	}
+	return dst
+}
+
+// buildFromNodes links sorted nodes into a balanced subtree and returns its root.
+func buildFromNodes[K cmp.Ordered, V any](t *Tree[K, V], nodes []*Node[K, V], parent *Node[K, V]) *Node[K, V] {
+	if len(nodes) == 0 {
+		return nil
+	}
+	mid := len(nodes) / 2
+	n := nodes[mid]
+	n.parent = parent
+	n.left = buildFromNodes(t, nodes[:mid], n)
+	n.right = buildFromNodes(t, nodes[mid+1:], n)
+	n.balance = medianBalance(len(nodes))
+	updateSize(t, n)
+	return n
+}
+
+// medianBalance returns the balance factor of the root of a subtree of n
+// nodes built by always taking the median as the root. Such a subtree of
+// k nodes has height bits.Len(k), and its left half is the larger one.
+func medianBalance(n int) int8 {
+	mid := n / 2
+	return int8(bits.Len(uint(mid)) - bits.Len(uint(n-mid-1)))
+}
@@ -0,0 +1,321 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"maps"
+	"math/bits"
+	"math/rand"
+	"slices"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertBatch(t *testing.T) {
+	tree := avlts.New[int, string]()
+	avlts.Insert(tree, 20, "old")
+	avlts.Insert(tree, 40, "forty")
+
+	inserted := avlts.InsertBatch(tree, []avlts.Item[int, string]{
+		{Key: 30, Value: "thirty"},
+		{Key: 10, Value: "first"},
+		{Key: 20, Value: "twenty"},
+		{Key: 10, Value: "ten"},
+	})
+	assert.Equal(t, 2, inserted)
+	assert.Equal(t, 4, avlts.Len(tree))
+
+	var keys []int
+	var values []string
+	for n := range avlts.InOrder(tree) {
+		keys = append(keys, n.Key())
+		values = append(values, n.Value())
+	}
+	assert.Equal(t, []int{10, 20, 30, 40}, keys)
+	assert.Equal(t, []string{"ten", "twenty", "thirty", "forty"}, values)
+
+	assert.Equal(t, 0, avlts.InsertBatch(tree, nil))
+}
+
+func TestInsertAll(t *testing.T) {
+	tree := avlts.New[string, int]()
+	avlts.Insert(tree, "b", 0)
+	inserted := avlts.InsertAll(tree, map[string]int{"a": 1, "b": 2, "c": 3})
+	assert.Equal(t, 2, inserted)
+	assert.Equal(t, 3, avlts.Len(tree))
+	v, _ := avlts.Get(tree, "b")
+	assert.Equal(t, 2, v)
+	assert.Equal(t, 0, avlts.InsertAll(tree, nil))
+	require.NoError(t, avlts.Validate(tree))
+}
+
+func TestInsertSeq2(t *testing.T) {
+	tree := avlts.New[int, string]()
+	seq := func(yield func(int, string) bool) {
+		for _, kv := range []struct {
+			k int
+			v string
+		}{{3, "c"}, {1, "a"}, {3, "C"}, {2, "b"}} {
+			if !yield(kv.k, kv.v) {
+				return
+			}
+		}
+	}
+	assert.Equal(t, 3, avlts.InsertSeq2(tree, seq))
+	v, _ := avlts.Get(tree, 3)
+	assert.Equal(t, "C", v, "last pair wins")
+
+	other := avlts.New[int, string]()
+	assert.Equal(t, 3, avlts.InsertSeq2(other, maps.All(map[int]string{1: "a", 2: "b", 3: "c"})))
+	assert.Equal(t, 0, avlts.InsertSeq2(other, maps.All(map[int]string{})))
+	require.NoError(t, avlts.Validate(other))
+}
+
+func TestDeleteAll(t *testing.T) {
+	for _, n := range []int{10, 1000} {
+		tree := avlts.New[int, int]()
+		for i := range n {
+			avlts.Insert(tree, i, i)
+		}
+		var deleted []int
+		avlts.OnDelete(tree, func(k, _ int) { deleted = append(deleted, k) })
+
+		few := avlts.DeleteAll(tree, slices.Values([]int{3, 5, 5, -1}))
+		assert.Equal(t, 2, few)
+		require.NoError(t, avlts.Validate(tree))
+
+		evens := func(yield func(int) bool) {
+			for i := 0; i < n; i += 2 {
+				if !yield(i) {
+					return
+				}
+			}
+		}
+		many := avlts.DeleteAll(tree, evens)
+		assert.Equal(t, n/2, many)
+		assert.Equal(t, n-n/2-2, avlts.Len(tree))
+		require.NoError(t, avlts.Validate(tree))
+		assert.Len(t, deleted, few+many)
+		assert.False(t, avlts.Contains(tree, 4))
+		assert.True(t, avlts.Contains(tree, 7))
+
+		assert.Equal(t, 0, avlts.DeleteAll(tree, slices.Values([]int{})))
+		assert.Equal(t, 0, avlts.DeleteAll(tree, evens))
+	}
+}
+
+func TestDeleteFunc(t *testing.T) {
+	for _, n := range []int{10, 1000} {
+		tree := avlts.New[int, int](avlts.WithPrefixSums[int]())
+		for i := range n {
+			avlts.Insert(tree, i, i)
+		}
+		var deleted []int
+		avlts.OnDelete(tree, func(k, _ int) { deleted = append(deleted, k) })
+
+		few := avlts.DeleteFunc(tree, func(k, _ int) bool { return k == 3 || k == 5 })
+		assert.Equal(t, 2, few)
+		require.NoError(t, avlts.Validate(tree))
+
+		var seen []int
+		many := avlts.DeleteFunc(tree, func(k, v int) bool {
+			seen = append(seen, k)
+			return v%2 == 0
+		})
+		assert.Equal(t, n/2, many)
+		assert.True(t, slices.IsSorted(seen), "pred sees keys in order")
+		assert.Len(t, seen, n-2)
+		assert.Equal(t, n-n/2-2, avlts.Len(tree))
+		require.NoError(t, avlts.Validate(tree))
+		assert.ElementsMatch(t, append([]int{3, 5}, evenInts(n)...), deleted)
+
+		assert.Equal(t, 0, avlts.DeleteFunc(tree, func(k, _ int) bool { return k < 0 }))
+	}
+}
+
+func TestRetainFunc(t *testing.T) {
+	tree := avlts.New[int, string]()
+	for i := range 100 {
+		avlts.Insert(tree, i, fmt.Sprint(i))
+	}
+	var deleted int
+	avlts.OnDelete(tree, func(int, string) { deleted++ })
+
+	removed := avlts.RetainFunc(tree, func(k int, _ string) bool { return k%10 == 0 })
+	assert.Equal(t, 90, removed)
+	assert.Equal(t, 90, deleted)
+	assert.Equal(t, []int{0, 10, 20, 30, 40, 50, 60, 70, 80, 90}, treeKeys(tree))
+	require.NoError(t, avlts.Validate(tree))
+
+	assert.Equal(t, 0, avlts.RetainFunc(tree, func(int, string) bool { return true }))
+	assert.Equal(t, 10, avlts.RetainFunc(tree, func(int, string) bool { return false }))
+	assert.Nil(t, tree.Root)
+	assert.Equal(t, 0, avlts.Len(tree))
+}
+
+func TestCompact(t *testing.T) {
+	tree := avlts.New[int, int](avlts.WithPrefixSums[int]())
+	avlts.Compact(tree)
+	assert.Nil(t, tree.Root)
+
+	for i := range 1000 {
+		avlts.Insert(tree, i, i)
+	}
+	// Sparse deletions leave a legal but taller than necessary tree.
+	for i := range 1000 {
+		if i%8 != 0 {
+			avlts.Delete(tree, i)
+		}
+	}
+	n := avlts.Len(tree)
+	require.Greater(t, avlts.Height(tree), bits.Len(uint(n)))
+	node, _ := avlts.Search(tree, 400)
+	version := avlts.Version(tree)
+	var updates int
+	avlts.OnUpdate(tree, func(int, int, int) { updates++ })
+
+	avlts.Compact(tree)
+	assert.Equal(t, bits.Len(uint(n)), avlts.Height(tree))
+	assert.Equal(t, n, avlts.Len(tree))
+	require.NoError(t, avlts.Validate(tree))
+	assert.Equal(t, version, avlts.Version(tree))
+	assert.Zero(t, updates)
+	assert.Equal(t, 50, avlts.Rank(tree, 400))
+	sum, _ := avlts.PrefixSum(tree, 400)
+	assert.Equal(t, 10200, sum)
+
+	again, _ := avlts.Search(tree, 400)
+	assert.Same(t, node, again, "nodes are reused")
+}
+
+func TestInsertBatchLarge(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tree := avlts.New[int, int]()
+	for range 500 {
+		k := r.Intn(2000)
+		avlts.Insert(tree, k, k)
+	}
+	items := make([]avlts.Item[int, int], 3000)
+	for i := range items {
+		k := r.Intn(4000)
+		items[i] = avlts.Item[int, int]{Key: k, Value: k}
+	}
+	avlts.InsertBatch(tree, items)
+
+	prev := -1
+	for n := range avlts.InOrder(tree) {
+		require.Less(t, prev, n.Key())
+		prev = n.Key()
+	}
+	node, _ := avlts.Max(tree)
+	count := 1
+	for p, ok := avlts.Predecessor(node); ok; p, ok = avlts.Predecessor(p) {
+		require.Less(t, p.Key(), node.Key())
+		node = p
+		count++
+	}
+	assert.Equal(t, avlts.Len(tree), count)
+	for i := range avlts.Len(tree) {
+		n, ok := avlts.Kth(tree, i)
+		require.True(t, ok)
+		assert.Equal(t, i, avlts.Rank(tree, n.Key()))
+	}
+	assert.LessOrEqual(t, avlts.Height(tree), 13)
+}
+
+func TestInsertBatchBounded(t *testing.T) {
+	tree := avlts.NewBounded[int, string](3, avlts.EvictMax)
+	avlts.InsertBatch(tree, []avlts.Item[int, string]{{Key: 5}, {Key: 1}, {Key: 4}, {Key: 2}, {Key: 3}})
+	assert.Equal(t, 3, avlts.Len(tree))
+	m, _ := avlts.Max(tree)
+	assert.Equal(t, 3, m.Key())
+}
+
+func ExampleInsertBatch() {
+	tree := avlts.New[int, string]()
+	avlts.InsertBatch(tree, []avlts.Item[int, string]{
+		{Key: 3, Value: "three"},
+		{Key: 1, Value: "one"},
+		{Key: 2, Value: "two"},
+	})
+	for n := range avlts.InOrder(tree) {
+		fmt.Print(n.Key(), ":", n.Value(), " ")
+	}
+	fmt.Println()
+	// Output: 1:one 2:two 3:three
+}
+
+func BenchmarkInsertBatch(b *testing.B) {
+	r := rand.New(rand.NewSource(42))
+	items := make([]avlts.Item[int, string], 100_000)
+	for i := range items {
+		items[i] = avlts.Item[int, string]{Key: r.Intn(1_000_000), Value: "value"}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree := avlts.New[int, string]()
+		avlts.InsertBatch(tree, items)
+	}
+}
+
+func ExampleInsertAll() {
+	tree := avlts.New[string, int]()
+	avlts.InsertAll(tree, map[string]int{"b": 2, "a": 1, "c": 3})
+	for n := range avlts.InOrder(tree) {
+		fmt.Print(n.Key(), n.Value(), " ")
+	}
+	fmt.Println()
+	// Output: a1 b2 c3
+}
+
+func ExampleInsertSeq2() {
+	tree := avlts.New[int, string]()
+	words := []string{"zero", "one", "two"}
+	avlts.InsertSeq2(tree, slices.All(words))
+	fmt.Println(avlts.Len(tree), avlts.GetOrDefault(tree, 2, ""))
+	// Output: 3 two
+}
+
+func ExampleDeleteFunc() {
+	tree := avlts.New[string, int]()
+	avlts.InsertAll(tree, map[string]int{"a": 1, "b": 2, "c": 3, "d": 4})
+	removed := avlts.DeleteFunc(tree, func(_ string, v int) bool { return v%2 == 0 })
+	fmt.Println(removed, avlts.AppendKeys(tree, nil))
+	// Output: 2 [a c]
+}
+
+func ExampleRetainFunc() {
+	tree := avlts.New[string, int]()
+	avlts.InsertAll(tree, map[string]int{"a": 1, "b": 2, "c": 3, "d": 4})
+	removed := avlts.RetainFunc(tree, func(_ string, v int) bool { return v > 2 })
+	fmt.Println(removed, avlts.AppendKeys(tree, nil))
+	// Output: 2 [c d]
+}
+
+func ExampleCompact() {
+	tree := avlts.New[int, struct{}]()
+	for i := range 1000 {
+		avlts.Insert(tree, i, struct{}{})
+	}
+	for i := range 1000 {
+		if i%8 != 0 {
+			avlts.Delete(tree, i)
+		}
+	}
+	fmt.Println(avlts.Len(tree), avlts.Height(tree))
+	avlts.Compact(tree)
+	fmt.Println(avlts.Len(tree), avlts.Height(tree))
+	// Output:
+	// 125 8
+	// 125 7
+}
+
+func ExampleDeleteAll() {
+	tree := avlts.New[string, int]()
+	avlts.InsertAll(tree, map[string]int{"a": 1, "b": 2, "c": 3, "d": 4})
+	removed := avlts.DeleteAll(tree, slices.Values([]string{"b", "d", "x"}))
+	fmt.Println(removed, avlts.Len(tree))
+	// Output: 2 2
+}
@@ -0,0 +1,59 @@
+package avltrees
+
+import "cmp"
+
+// Compare compares two AVL trees as sorted sequences of entries.
+// Entries are compared pairwise in key order, first by key and then by
+// value using cmpV; the first difference decides the result. If one tree
+// is a prefix of the other, the shorter tree is less.
+// The result is -1, 0, or +1 as with cmp.Compare.
+func Compare[K cmp.Ordered, V any](a, b *Tree[K, V], cmpV func(x, y V) int) int {
+	x, xok := Min(a)
+	y, yok := Min(b)
+	for xok && yok {
+		if c := cmp.Compare(x.key, y.key); c != 0 {
+			return c
+		}
+		if c := cmpV(x.value, y.value); c != 0 {
+			return sign(c)
+		}
+		x, xok = Successor(x)
+		y, yok = Successor(y)
+	}
+	switch {
+	case xok:
+		return 1
+	case yok:
+		return -1
+	}
+	return 0
+}
+
+// EqualFunc reports whether two AVL trees hold the same keys with values
+// that are equal according to eq. Trees of different lengths are unequal
+// without comparing any entries.
+func EqualFunc[K cmp.Ordered, V1, V2 any](a *Tree[K, V1], b *Tree[K, V2], eq func(x V1, y V2) bool) bool {
+	if Len(a) != Len(b) {
+		return false
+	}
+	x, xok := Min(a)
+	y, yok := Min(b)
+	for xok && yok {
+		if x.key != y.key || !eq(x.value, y.value) {
+			return false
+		}
+		x, xok = Successor(x)
+		y, yok = Successor(y)
+	}
+	return xok == yok
+}
+
+func sign(c int) int {
+	switch {
+	case c < 0:
+		return -1
+	case c > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+package avltrees_test
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *avlts.Tree[string, string]
+		want int
+	}{
+		{"both empty", avlts.New[string, string](), avlts.New[string, string](), 0},
+		{"equal", treeOf([]string{"a", "b"}, []string{"1", "2"}), treeOf([]string{"b", "a"}, []string{"2", "1"}), 0},
+		{"prefix is less", treeOf([]string{"a"}, []string{"1"}), treeOf([]string{"a", "b"}, []string{"1", "2"}), -1},
+		{"longer is greater", treeOf([]string{"a", "b"}, []string{"1", "2"}), treeOf([]string{"a"}, []string{"1"}), 1},
+		{"key decides", treeOf([]string{"a", "c"}, []string{"1", "0"}), treeOf([]string{"a", "b"}, []string{"1", "9"}), 1},
+		{"value decides", treeOf([]string{"a", "b"}, []string{"1", "2"}), treeOf([]string{"a", "b"}, []string{"1", "3"}), -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, avlts.Compare(tt.a, tt.b, strings.Compare))
+		})
+	}
+}
+
+func TestCompareNormalizesSign(t *testing.T) {
+	a, b := avlts.New[int, int](), avlts.New[int, int]()
+	avlts.Insert(a, 1, 100)
+	avlts.Insert(b, 1, 3)
+	assert.Equal(t, 1, avlts.Compare(a, b, func(x, y int) int { return x - y }))
+}
+
+func TestEqualFunc(t *testing.T) {
+	eq := func(x, y string) bool { return x == y }
+	assert.True(t, avlts.EqualFunc(avlts.New[string, string](), avlts.New[string, string](), eq))
+	assert.True(t, avlts.EqualFunc(treeOf([]string{"a", "b"}, []string{"1", "2"}), treeOf([]string{"b", "a"}, []string{"2", "1"}), eq))
+	assert.False(t, avlts.EqualFunc(treeOf([]string{"a"}, []string{"1"}), treeOf([]string{"a", "b"}, []string{"1", "2"}), eq))
+	assert.False(t, avlts.EqualFunc(treeOf([]string{"a", "c"}, []string{"1", "2"}), treeOf([]string{"a", "b"}, []string{"1", "2"}), eq))
+	assert.False(t, avlts.EqualFunc(treeOf([]string{"a"}, []string{"1"}), treeOf([]string{"a"}, []string{"2"}), eq))
+
+	calls := 0
+	avlts.EqualFunc(treeOf([]string{"a"}, []string{"1"}), treeOf([]string{"a", "b"}, []string{"1", "2"}), func(x, y string) bool {
+		calls++
+		return true
+	})
+	assert.Zero(t, calls, "lengths are checked first")
+}
+
+func TestEqualFuncMixedValueTypes(t *testing.T) {
+	a := avlts.New[string, []int]()
+	avlts.Insert(a, "x", []int{1, 2})
+	b := avlts.New[string, string]()
+	avlts.Insert(b, "x", "[1 2]")
+	assert.True(t, avlts.EqualFunc(a, b, func(x []int, y string) bool { return fmt.Sprint(x) == y }))
+}
+
+func ExampleEqualFunc() {
+	type config struct{ Tags []string }
+	a := avlts.New[string, config]()
+	avlts.Insert(a, "web", config{Tags: []string{"prod"}})
+	b := avlts.New[string, config]()
+	avlts.Insert(b, "web", config{Tags: []string{"prod"}})
+
+	fmt.Println(avlts.EqualFunc(a, b, func(x, y config) bool {
+		return slices.Equal(x.Tags, y.Tags)
+	}))
+	// Output: true
+}
+
+func ExampleCompare() {
+	v1 := avlts.New[string, int]()
+	avlts.Insert(v1, "replicas", 3)
+	v2 := avlts.New[string, int]()
+	avlts.Insert(v2, "replicas", 5)
+
+	fmt.Println(avlts.Compare(v1, v2, cmp.Compare[int]))
+	fmt.Println(avlts.Compare(v1, v1, cmp.Compare[int]))
+	// Output:
+	// -1
+	// 0
+}
@@ -0,0 +1,103 @@
+package avltrees
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Counter maps keys to positive counts, like Python's collections.Counter,
+// with access in key order and in count order.
+// Use NewCounter to create one; the zero value is not usable.
+type Counter[K cmp.Ordered] struct {
+	counts  Tree[K, int]
+	byCount Tree[int, *Set[K]] // count -> keys holding it
+}
+
+// NewCounter returns a new empty Counter.
+func NewCounter[K cmp.Ordered]() *Counter[K] {
+	return &Counter[K]{}
+}
+
+// Len returns the number of keys with a positive count.
+func (c *Counter[K]) Len() int {
+	return Len(&c.counts)
+}
+
+// Count returns the count of key, or 0 if key is not in the counter.
+func (c *Counter[K]) Count(key K) int {
+	return GetOrDefault(&c.counts, key, 0)
+}
+
+// Incr adds one to the count of key and returns the new count.
+func (c *Counter[K]) Incr(key K) int {
+	n, ok := Search(&c.counts, key)
+	if !ok {
+		Insert(&c.counts, key, 1)
+		c.link(key, 1)
+		return 1
+	}
+	c.unlink(key, n.value)
+	n.value++
+	c.link(key, n.value)
+	return n.value
+}
+
+// Decr subtracts one from the count of key and returns the new count.
+// A key whose count reaches 0 is removed. Decr of a key not in the counter
+// does nothing and returns 0.
+func (c *Counter[K]) Decr(key K) int {
+	n, ok := Search(&c.counts, key)
+	if !ok {
+		return 0
+	}
+	c.unlink(key, n.value)
+	if n.value == 1 {
+		Delete(&c.counts, key)
+		return 0
+	}
+	n.value--
+	c.link(key, n.value)
+	return n.value
+}
+
+// MostCommon returns the n keys with the highest counts, highest first.
+// Keys with equal counts are returned in key order.
+func (c *Counter[K]) MostCommon(n int) []Item[K, int] {
+	if n <= 0 {
+		return nil
+	}
+	out := make([]Item[K, int], 0, min(n, c.Len()))
+	for b, ok := Max(&c.byCount); ok; b, ok = Predecessor(b) {
+		for key := range b.value.All() {
+			if len(out) == n {
+				return out
+			}
+			out = append(out, Item[K, int]{key, b.key})
+		}
+	}
+	return out
+}
+
+// All returns an iterator over the keys and their counts in key order.
+func (c *Counter[K]) All() iter.Seq2[K, int] {
+	return func(yield func(K, int) bool) {
+		for n := range InOrder(&c.counts) {
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
+
+func (c *Counter[K]) link(key K, count int) {
+	b := Entry(&c.byCount, count).OrInsertWith(func() *Set[K] { return NewSet[K]() })
+	b.value.Add(key)
+}
+
+func (c *Counter[K]) unlink(key K, count int) {
+	b, _ := Search(&c.byCount, count)
+	b.value.Remove(key)
+	if b.value.Len() == 0 {
+		Delete(&c.byCount, count)
+	}
+}
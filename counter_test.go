@@ -0,0 +1,110 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounterIncr(t *testing.T) {
+	c := avlts.NewCounter[string]()
+	assert.Equal(t, 1, c.Incr("a"))
+	assert.Equal(t, 2, c.Incr("a"))
+	assert.Equal(t, 1, c.Incr("b"))
+	assert.Equal(t, 2, c.Len())
+	assert.Equal(t, 2, c.Count("a"))
+	assert.Equal(t, 0, c.Count("z"))
+}
+
+func TestCounterDecr(t *testing.T) {
+	c := avlts.NewCounter[string]()
+	for _, k := range []string{"a", "a", "b"} {
+		c.Incr(k)
+	}
+	assert.Equal(t, 1, c.Decr("a"))
+	assert.Equal(t, 0, c.Decr("b"))
+	assert.Equal(t, 0, c.Decr("b"))
+	assert.Equal(t, 0, c.Decr("z"))
+	assert.Equal(t, map[string]int{"a": 1}, maps.Collect(c.All()))
+	assert.Equal(t, []avlts.Item[string, int]{{Key: "a", Value: 1}}, c.MostCommon(5))
+}
+
+func TestCounterMostCommon(t *testing.T) {
+	c := avlts.NewCounter[string]()
+	for _, k := range []string{"x", "b", "a", "b", "c", "a", "b"} {
+		c.Incr(k)
+	}
+	assert.Equal(t, []avlts.Item[string, int]{
+		{Key: "b", Value: 3},
+		{Key: "a", Value: 2},
+		{Key: "c", Value: 1},
+	}, c.MostCommon(3))
+	assert.Len(t, c.MostCommon(10), 4)
+	assert.Nil(t, c.MostCommon(0))
+	assert.Empty(t, avlts.NewCounter[int]().MostCommon(3))
+}
+
+func TestCounterAll(t *testing.T) {
+	c := avlts.NewCounter[string]()
+	for _, k := range []string{"b", "a", "b"} {
+		c.Incr(k)
+	}
+	var keys []string
+	for k, n := range c.All() {
+		keys = append(keys, fmt.Sprint(k, n))
+	}
+	assert.Equal(t, []string{"a1", "b2"}, keys)
+}
+
+func TestCounterRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	c := avlts.NewCounter[int]()
+	want := map[int]int{}
+	for range 5000 {
+		k := r.Intn(50)
+		if r.Intn(3) == 0 {
+			c.Decr(k)
+			if want[k] > 0 {
+				want[k]--
+			}
+			if want[k] == 0 {
+				delete(want, k)
+			}
+		} else {
+			c.Incr(k)
+			want[k]++
+		}
+	}
+	assert.Equal(t, want, maps.Collect(c.All()))
+
+	var expected []avlts.Item[int, int]
+	for k, n := range want {
+		expected = append(expected, avlts.Item[int, int]{Key: k, Value: n})
+	}
+	slices.SortFunc(expected, func(a, b avlts.Item[int, int]) int {
+		if a.Value != b.Value {
+			return b.Value - a.Value
+		}
+		return a.Key - b.Key
+	})
+	assert.Equal(t, expected, c.MostCommon(len(want)))
+}
+
+func ExampleCounter() {
+	words := avlts.NewCounter[string]()
+	for _, w := range []string{"to", "be", "or", "not", "to", "be"} {
+		words.Incr(w)
+	}
+	for _, it := range words.MostCommon(3) {
+		fmt.Println(it.Key, it.Value)
+	}
+	// Output:
+	// be 2
+	// to 2
+	// not 1
+}
@@ -0,0 +1,37 @@
+package avltrees
+
+import (
+	"cmp"
+	"context"
+	"iter"
+)
+
+// InOrderCtx is like InOrder but stops yielding once ctx is done.
+// Callers can check ctx.Err() after the loop to tell a cancelled scan
+// from a complete one.
+func InOrderCtx[K cmp.Ordered, V any](ctx context.Context, t *Tree[K, V]) iter.Seq[Node[K, V]] {
+	return withContext(ctx, InOrder(t))
+}
+
+// RangeCtx is like Range but stops yielding once ctx is done.
+// Callers can check ctx.Err() after the loop to tell a cancelled scan
+// from a complete one.
+func RangeCtx[K cmp.Ordered, V any](ctx context.Context, t *Tree[K, V], from, to K) iter.Seq[Node[K, V]] {
+	return withContext(ctx, Range(t, from, to))
+}
+
+func withContext[T any](ctx context.Context, seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		done := ctx.Done()
+		for v := range seq {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package avltrees_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInOrderCtx(t *testing.T) {
+	tree := treeOf[int, struct{}]([]int{1, 2, 3, 4, 5}, nil)
+	var keys []int
+	for n := range avlts.InOrderCtx(context.Background(), tree) {
+		keys = append(keys, n.Key())
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, keys)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	keys = nil
+	for n := range avlts.InOrderCtx(ctx, tree) {
+		keys = append(keys, n.Key())
+		if n.Key() == 2 {
+			cancel()
+		}
+	}
+	assert.Equal(t, []int{1, 2}, keys)
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestRangeCtx(t *testing.T) {
+	tree := treeOf[int, struct{}]([]int{1, 2, 3, 4, 5}, nil)
+	var keys []int
+	for n := range avlts.RangeCtx(context.Background(), tree, 2, 5) {
+		keys = append(keys, n.Key())
+	}
+	assert.Equal(t, []int{2, 3, 4}, keys)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for range avlts.RangeCtx(ctx, tree, 0, 10) {
+		assert.Fail(t, "cancelled context yields nothing")
+	}
+
+	for n := range avlts.RangeCtx(context.Background(), tree, 1, 10) {
+		if n.Key() == 3 {
+			break
+		}
+	}
+}
+
+func ExampleRangeCtx() {
+	tree := treeOf[int, struct{}]([]int{10, 20, 30, 40}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for n := range avlts.RangeCtx(ctx, tree, 0, 100) {
+		fmt.Println(n.Key())
+		if n.Key() == 20 {
+			cancel() // e.g. the request deadline passed
+		}
+	}
+	fmt.Println(ctx.Err())
+	// Output:
+	// 10
+	// 20
+	// context canceled
+}
@@ -0,0 +1,108 @@
+package avltrees
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+)
+
+// ErrPatchConflict is returned when a patch does not match the tree it is
+// applied to.
+var ErrPatchConflict = errors.New("avltrees: patch does not apply")
+
+// Patch describes the differences between two AVL trees, as produced by
+// Diff. Each slice is sorted by key.
+type Patch[K cmp.Ordered, V any] struct {
+	// Added holds the entries present only in the new tree.
+	Added []Item[K, V]
+	// Removed holds the entries present only in the old tree.
+	Removed []Item[K, V]
+	// Changed holds the keys present in both trees with different values.
+	Changed []Change[K, V]
+}
+
+// Change is a key whose value differs between two trees.
+type Change[K cmp.Ordered, V any] struct {
+	Key K
+	Old V
+	New V
+}
+
+// Empty reports whether the patch contains no differences.
+func (p Patch[K, V]) Empty() bool {
+	return len(p.Added) == 0 && len(p.Removed) == 0 && len(p.Changed) == 0
+}
+
+// Diff returns the differences between old and new by walking both trees
+// in key order, in O(n + m).
+func Diff[K cmp.Ordered, V comparable](old, new *Tree[K, V]) Patch[K, V] {
+	return DiffFunc(old, new, func(a, b V) bool { return a == b })
+}
+
+// DiffFunc is like Diff but uses eq to decide whether two values are equal.
+func DiffFunc[K cmp.Ordered, V any](old, new *Tree[K, V], eq func(a, b V) bool) Patch[K, V] {
+	var p Patch[K, V]
+	x, xok := Min(old)
+	y, yok := Min(new)
+	for xok || yok {
+		switch {
+		case !yok || (xok && x.key < y.key):
+			p.Removed = append(p.Removed, Item[K, V]{x.key, x.value})
+			x, xok = Successor(x)
+		case !xok || y.key < x.key:
+			p.Added = append(p.Added, Item[K, V]{y.key, y.value})
+			y, yok = Successor(y)
+		default:
+			if !eq(x.value, y.value) {
+				p.Changed = append(p.Changed, Change[K, V]{x.key, x.value, y.value})
+			}
+			x, xok = Successor(x)
+			y, yok = Successor(y)
+		}
+	}
+	return p
+}
+
+// ApplyPatch applies p to the AVL tree. Every entry p removes or changes
+// must be present with its old value, and every key p adds must be absent.
+// The whole patch is checked before anything is modified, so on conflict
+// ApplyPatch returns an error wrapping ErrPatchConflict and leaves the
+// tree untouched.
+func ApplyPatch[K cmp.Ordered, V comparable](t *Tree[K, V], p Patch[K, V]) error {
+	return ApplyPatchFunc(t, p, func(a, b V) bool { return a == b })
+}
+
+// ApplyPatchFunc is like ApplyPatch but uses eq to compare the tree's
+// values with the old values recorded in the patch.
+func ApplyPatchFunc[K cmp.Ordered, V any](t *Tree[K, V], p Patch[K, V], eq func(a, b V) bool) error {
+	expect := func(key K, old V) error {
+		n, ok := Search(t, key)
+		if !ok {
+			return fmt.Errorf("%w: key %v is missing", ErrPatchConflict, key)
+		}
+		if !eq(n.value, old) {
+			return fmt.Errorf("%w: key %v has a different value", ErrPatchConflict, key)
+		}
+		return nil
+	}
+	x := Begin(t)
+	for _, it := range p.Removed {
+		if err := expect(it.Key, it.Value); err != nil {
+			return err
+		}
+		x.Delete(it.Key)
+	}
+	for _, c := range p.Changed {
+		if err := expect(c.Key, c.Old); err != nil {
+			return err
+		}
+		x.Insert(c.Key, c.New)
+	}
+	for _, it := range p.Added {
+		if _, ok := Search(t, it.Key); ok {
+			return fmt.Errorf("%w: key %v already exists", ErrPatchConflict, it.Key)
+		}
+		x.Insert(it.Key, it.Value)
+	}
+	return x.Commit()
+}
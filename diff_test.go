@@ -0,0 +1,158 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	strItem   = avlts.Item[string, string]
+	strChange = avlts.Change[string, string]
+)
+
+func TestDiff(t *testing.T) {
+	old := treeOf([]string{"a", "b", "c", "e"}, []string{"1", "2", "3", "5"})
+	new := treeOf([]string{"b", "c", "d", "e", "f"}, []string{"2", "30", "4", "5", "6"})
+
+	p := avlts.Diff(old, new)
+	assert.Equal(t, []strItem{{"d", "4"}, {"f", "6"}}, p.Added)
+	assert.Equal(t, []strItem{{"a", "1"}}, p.Removed)
+	assert.Equal(t, []strChange{{"c", "3", "30"}}, p.Changed)
+	assert.False(t, p.Empty())
+
+	assert.True(t, avlts.Diff(old, old).Empty())
+	assert.True(t, avlts.Diff(avlts.New[string, string](), avlts.New[string, string]()).Empty())
+
+	p = avlts.Diff(avlts.New[string, string](), old)
+	assert.Len(t, p.Added, 4)
+	assert.Empty(t, p.Removed)
+}
+
+func TestDiffFunc(t *testing.T) {
+	old := avlts.New[int, []int]()
+	new := avlts.New[int, []int]()
+	avlts.Insert(old, 1, []int{1, 2})
+	avlts.Insert(new, 1, []int{1, 2})
+	avlts.Insert(old, 2, []int{3})
+	avlts.Insert(new, 2, []int{4})
+
+	p := avlts.DiffFunc(old, new, slices.Equal[[]int])
+	assert.Empty(t, p.Added)
+	assert.Empty(t, p.Removed)
+	assert.Equal(t, []avlts.Change[int, []int]{{2, []int{3}, []int{4}}}, p.Changed)
+}
+
+func TestDiffRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	old, new := avlts.New[int, int](), avlts.New[int, int]()
+	want := map[string]int{}
+	for k := range 500 {
+		inOld, inNew := r.Intn(3) > 0, r.Intn(3) > 0
+		v, w := r.Intn(2), r.Intn(2)
+		if inOld {
+			avlts.Insert(old, k, v)
+		}
+		if inNew {
+			avlts.Insert(new, k, w)
+		}
+		switch {
+		case inOld && !inNew:
+			want["removed"]++
+		case !inOld && inNew:
+			want["added"]++
+		case inOld && inNew && v != w:
+			want["changed"]++
+		}
+	}
+	p := avlts.Diff(old, new)
+	assert.Equal(t, want["added"], len(p.Added))
+	assert.Equal(t, want["removed"], len(p.Removed))
+	assert.Equal(t, want["changed"], len(p.Changed))
+}
+
+func TestApplyPatch(t *testing.T) {
+	old := treeOf([]string{"a", "b", "c"}, []string{"1", "2", "3"})
+	new := treeOf([]string{"b", "c", "d"}, []string{"20", "3", "4"})
+	p := avlts.Diff(old, new)
+
+	target := treeOf([]string{"a", "b", "c"}, []string{"1", "2", "3"})
+	require.NoError(t, avlts.ApplyPatch(target, p))
+	assert.True(t, avlts.Diff(target, new).Empty())
+	assert.Equal(t, 3, avlts.Len(target))
+}
+
+func TestApplyPatchConflict(t *testing.T) {
+	p := avlts.Diff(treeOf([]string{"a", "b"}, []string{"1", "2"}), treeOf([]string{"b", "c"}, []string{"3", "4"}))
+	tests := []struct {
+		name   string
+		target *avlts.Tree[string, string]
+	}{
+		{"removed key missing", treeOf([]string{"b"}, []string{"2"})},
+		{"removed value differs", treeOf([]string{"a", "b"}, []string{"9", "2"})},
+		{"changed key missing", treeOf([]string{"a"}, []string{"1"})},
+		{"changed value differs", treeOf([]string{"a", "b"}, []string{"1", "9"})},
+		{"added key exists", treeOf([]string{"a", "b", "c"}, []string{"1", "2", "0"})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := avlts.Sprint(tt.target)
+			err := avlts.ApplyPatch(tt.target, p)
+			assert.ErrorIs(t, err, avlts.ErrPatchConflict)
+			assert.Equal(t, before, avlts.Sprint(tt.target), "tree is untouched")
+		})
+	}
+}
+
+func TestApplyPatchFunc(t *testing.T) {
+	old := avlts.New[int, []int]()
+	avlts.Insert(old, 1, []int{1})
+	new := avlts.New[int, []int]()
+	avlts.Insert(new, 1, []int{2})
+	p := avlts.DiffFunc(old, new, slices.Equal[[]int])
+
+	require.NoError(t, avlts.ApplyPatchFunc(old, p, slices.Equal[[]int]))
+	n, _ := avlts.Search(old, 1)
+	assert.Equal(t, []int{2}, n.Value())
+	assert.ErrorIs(t, avlts.ApplyPatchFunc(old, p, slices.Equal[[]int]), avlts.ErrPatchConflict)
+}
+
+func ExampleDiff() {
+	desired := treeOf([]string{"replicas", "image"}, []string{"3", "v2"})
+	current := treeOf([]string{"replicas", "image", "debug"}, []string{"3", "v1", "true"})
+
+	p := avlts.Diff(current, desired)
+	for _, c := range p.Changed {
+		fmt.Printf("change %s: %s -> %s\n", c.Key, c.Old, c.New)
+	}
+	for _, it := range p.Removed {
+		fmt.Println("remove", it.Key)
+	}
+	// Output:
+	// change image: v1 -> v2
+	// remove debug
+}
+
+func ExampleApplyPatch() {
+	v1 := treeOf([]string{"host", "port"}, []string{"a.example", "80"})
+	v2 := treeOf([]string{"host", "port", "tls"}, []string{"a.example", "443", "on"})
+	replica := treeOf([]string{"host", "port"}, []string{"a.example", "80"})
+
+	if err := avlts.ApplyPatch(replica, avlts.Diff(v1, v2)); err != nil {
+		fmt.Println(err)
+	}
+	for n := range avlts.InOrder(replica) {
+		fmt.Println(n.Key(), n.Value())
+	}
+	fmt.Println(avlts.ApplyPatch(replica, avlts.Diff(v1, v2)))
+	// Output:
+	// host a.example
+	// port 443
+	// tls on
+	// avltrees: patch does not apply: key port has a different value
+}
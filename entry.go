@@ -0,0 +1,128 @@
+package avltrees
+
+import "cmp"
+
+// EntryRef is a handle to the position of a key in a tree, obtained by Entry.
+// It lets read-modify-write sequences run with a single descent.
+// An EntryRef is invalidated by any change to the tree not made through it.
+type EntryRef[K cmp.Ordered, V any] struct {
+	t      *Tree[K, V]
+	key    K
+	node   *Node[K, V]
+	parent *Node[K, V]
+}
+
+// Entry descends the AVL tree once and returns a handle to the position of key,
+// whether or not the key is present.
+func Entry[K cmp.Ordered, V any](t *Tree[K, V], key K) *EntryRef[K, V] {
+	e := &EntryRef[K, V]{t: t, key: key}
+	e.seek()
+	return e
+}
+
+// seek descends from the root to the position of the entry's key.
+func (e *EntryRef[K, V]) seek() {
+	e.node, e.parent = nil, nil
+	curr := e.t.Root
+	for curr != nil {
+		if e.key < curr.key {
+			e.parent, curr = curr, curr.left
+		} else if e.key > curr.key {
+			e.parent, curr = curr, curr.right
+		} else {
+			e.node = curr
+			return
+		}
+	}
+}
+
+// Key returns the key of the entry.
+func (e *EntryRef[K, V]) Key() K {
+	return e.key
+}
+
+// Node returns the node holding the entry's key, if present.
+func (e *EntryRef[K, V]) Node() (*Node[K, V], bool) {
+	return e.node, e.node != nil
+}
+
+// OrInsert inserts value if the key is absent and returns the node holding the key.
+// If the tree is bounded and full, the insertion may evict another entry or the
+// new entry itself; in the latter case the returned node is detached.
+func (e *EntryRef[K, V]) OrInsert(value V) *Node[K, V] {
+	if e.node == nil {
+		e.attach(value)
+	}
+	return e.node
+}
+
+// OrInsertWith inserts the result of f if the key is absent and returns the node
+// holding the key. f is only called when an insertion happens.
+func (e *EntryRef[K, V]) OrInsertWith(f func() V) *Node[K, V] {
+	if e.node == nil {
+		e.attach(f())
+	}
+	return e.node
+}
+
+// AndModify replaces the value with the result of f if the key is present.
+// It returns the entry to allow chaining with OrInsert.
+func (e *EntryRef[K, V]) AndModify(f func(value V) V) *EntryRef[K, V] {
+	checkMutable(e.t)
+	if e.node != nil {
+		old := e.node.value
+		e.node.value = f(old)
+		refreshUp(e.t, e.node)
+		notifyUpdate(e.t, e.key, old, e.node.value)
+	}
+	return e
+}
+
+// Delete removes the entry from the tree.
+// Returns true if the key was present and was deleted. The entry stays
+// usable, so a later OrInsert puts the key back.
+func (e *EntryRef[K, V]) Delete() bool {
+	if e.node == nil {
+		return false
+	}
+	deleted := Delete(e.t, e.key)
+	// Rebalancing may have moved the key's former parent, so find the
+	// insertion point again.
+	e.seek()
+	return deleted
+}
+
+func (e *EntryRef[K, V]) attach(value V) {
+	checkMutable(e.t)
+	n := newNode(e.t, e.key, value, e.parent)
+	if e.parent == nil {
+		e.t.Root = n
+	} else if e.key < e.parent.key {
+		e.parent.left = n
+	} else {
+		e.parent.right = n
+	}
+	retrace(e.t, n)
+	e.node, e.parent = n, nil
+	e.t.count++
+	recordInserts(e.t, 1)
+	notifyInsert(e.t, e.key, value)
+	evictOverflow(e.t)
+}
+
+// retrace rebalances each ancestor of n, a leaf just linked into the tree.
+func retrace[K cmp.Ordered, V any](t *Tree[K, V], n *Node[K, V]) {
+	grown := 1
+	for p := n.parent; p != nil; p = n.parent {
+		grand := p.parent
+		left := p.left == n
+		n, grown = rebalanceAfter(t, p, left, grown)
+		if grand == nil {
+			t.Root = n
+		} else if grand.left == p {
+			grand.left = n
+		} else {
+			grand.right = n
+		}
+	}
+}
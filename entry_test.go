@@ -0,0 +1,133 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntry(t *testing.T) {
+	tree := avlts.New[string, int]()
+	e := avlts.Entry(tree, "a")
+	assert.Equal(t, "a", e.Key())
+	_, ok := e.Node()
+	assert.False(t, ok)
+
+	avlts.Insert(tree, "a", 1)
+	n, ok := avlts.Entry(tree, "a").Node()
+	require.True(t, ok)
+	assert.Equal(t, 1, n.Value())
+}
+
+func TestEntryOrInsert(t *testing.T) {
+	tree := avlts.New[string, int]()
+	n := avlts.Entry(tree, "a").OrInsert(1)
+	assert.Equal(t, 1, n.Value())
+	n = avlts.Entry(tree, "a").OrInsert(2)
+	assert.Equal(t, 1, n.Value())
+	assert.Equal(t, 1, avlts.Len(tree))
+
+	calls := 0
+	f := func() int { calls++; return 3 }
+	avlts.Entry(tree, "a").OrInsertWith(f)
+	assert.Equal(t, 0, calls)
+	n = avlts.Entry(tree, "b").OrInsertWith(f)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 3, n.Value())
+	assert.Equal(t, 2, avlts.Len(tree))
+}
+
+func TestEntryOrInsertBalance(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	tree := avlts.New[int, int]()
+	for i := range 2000 {
+		avlts.Entry(tree, r.Intn(5000)).OrInsert(i)
+	}
+	assert.LessOrEqual(t, avlts.Height(tree), 16)
+
+	prev := -1
+	count := 0
+	for n := range avlts.InOrder(tree) {
+		require.Less(t, prev, n.Key())
+		prev = n.Key()
+		count++
+	}
+	assert.Equal(t, count, avlts.Len(tree))
+	for i := range count {
+		n, ok := avlts.Kth(tree, i)
+		require.True(t, ok)
+		require.Equal(t, i, avlts.Rank(tree, n.Key()))
+	}
+}
+
+func TestEntryAndModify(t *testing.T) {
+	tree := avlts.New[string, int]()
+	incr := func(v int) int { return v + 1 }
+
+	for range 3 {
+		avlts.Entry(tree, "hits").AndModify(incr).OrInsert(1)
+	}
+	n, _ := avlts.Search(tree, "hits")
+	assert.Equal(t, 3, n.Value())
+
+	var updates []int
+	avlts.OnUpdate(tree, func(_ string, old, new int) { updates = append(updates, old, new) })
+	avlts.Entry(tree, "hits").AndModify(incr)
+	avlts.Entry(tree, "misses").AndModify(incr)
+	assert.Equal(t, []int{3, 4}, updates)
+	assert.Equal(t, 1, avlts.Len(tree))
+}
+
+func TestEntryDelete(t *testing.T) {
+	tree := avlts.New[string, int]()
+	avlts.Insert(tree, "a", 1)
+
+	e := avlts.Entry(tree, "a")
+	assert.True(t, e.Delete())
+	assert.False(t, e.Delete())
+	assert.Equal(t, 0, avlts.Len(tree))
+	assert.False(t, avlts.Entry(tree, "b").Delete())
+}
+
+func TestEntryDeleteThenOrInsert(t *testing.T) {
+	tree := treeOf[int, int]([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, nil)
+	e := avlts.Entry(tree, 5)
+	require.True(t, e.Delete())
+	n := e.OrInsert(55)
+
+	assert.Equal(t, 5, n.Key())
+	assert.Equal(t, 55, n.Value())
+	assert.Equal(t, 10, avlts.Len(tree))
+	assert.NoError(t, avlts.Validate(tree))
+}
+
+func TestEntryBounded(t *testing.T) {
+	tree := avlts.NewBounded[int, string](2, avlts.EvictMin)
+	var inserted []int
+	avlts.OnInsert(tree, func(k int, _ string) { inserted = append(inserted, k) })
+	for i := range 4 {
+		avlts.Entry(tree, i).OrInsert("")
+	}
+	assert.Equal(t, 2, avlts.Len(tree))
+	assert.Equal(t, []int{0, 1, 2, 3}, inserted)
+	m, _ := avlts.Min(tree)
+	assert.Equal(t, 2, m.Key())
+}
+
+func ExampleEntry() {
+	counts := avlts.New[string, int]()
+	for _, w := range []string{"b", "a", "b", "c", "b"} {
+		avlts.Entry(counts, w).AndModify(func(v int) int { return v + 1 }).OrInsert(1)
+	}
+	for n := range avlts.InOrder(counts) {
+		fmt.Println(n.Key(), n.Value())
+	}
+	// Output:
+	// a 1
+	// b 3
+	// c 1
+}
@@ -0,0 +1,80 @@
+package avltrees
+
+import "cmp"
+
+// SearchFrom finds the node with the given key, starting from hint, a node
+// of the tree, instead of the root. It climbs from hint to the lowest
+// ancestor whose subtree spans key and descends from there, so lookups
+// near the hint touch few nodes. Returns nil and false if hint is nil or
+// the key is not in the tree.
+func SearchFrom[K cmp.Ordered, V any](hint *Node[K, V], key K) (*Node[K, V], bool) {
+	curr := fingerStart(hint, key)
+	for curr != nil {
+		if key < curr.key {
+			curr = curr.left
+		} else if key > curr.key {
+			curr = curr.right
+		} else {
+			return curr, true
+		}
+	}
+	return nil, false
+}
+
+// InsertNear inserts a key-value pair like Insert, but locates the position
+// of key by searching from hint, a node of the AVL tree, as SearchFrom
+// does. When keys arrive mostly in order, passing the previously inserted
+// node as the hint avoids most key comparisons. A nil hint searches from
+// the root. Returns the node holding key, to be used as the next hint, and
+// true if the key was newly inserted. As with EntryRef.OrInsert, the node
+// is detached if a bounded tree evicted it right away.
+func InsertNear[K cmp.Ordered, V any](t *Tree[K, V], hint *Node[K, V], key K, value V) (*Node[K, V], bool) {
+	checkMutable(t)
+	e := &EntryRef[K, V]{t: t, key: key}
+	curr := t.Root
+	if hint != nil {
+		curr = fingerStart(hint, key)
+		e.parent = curr.parent
+	}
+	for curr != nil {
+		if key < curr.key {
+			e.parent, curr = curr, curr.left
+		} else if key > curr.key {
+			e.parent, curr = curr, curr.right
+		} else {
+			e.node = curr
+			break
+		}
+	}
+	if e.node != nil {
+		e.AndModify(func(V) V { return value })
+		return e.node, false
+	}
+	e.attach(value)
+	return e.node, true
+}
+
+// fingerStart returns the lowest ancestor of hint, or hint itself, whose
+// subtree would contain key. All keys in the subtree of a node lie between
+// the nearest ancestors it descends from on the left and on the right;
+// the climb stops at the first such bound on the far side of key.
+func fingerStart[K cmp.Ordered, V any](hint *Node[K, V], key K) *Node[K, V] {
+	n := hint
+	if n == nil || key == n.key {
+		return n
+	}
+	for n.parent != nil {
+		p := n.parent
+		if key == p.key {
+			return p
+		}
+		if key > hint.key && p.left == n && key < p.key {
+			break
+		}
+		if key < hint.key && p.right == n && key > p.key {
+			break
+		}
+		n = p
+	}
+	return n
+}
@@ -0,0 +1,116 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchFrom(t *testing.T) {
+	tree := avlts.New[int, int]()
+	for i := 0; i < 200; i += 2 {
+		avlts.Insert(tree, i, i)
+	}
+	nodes := make([]*avlts.Node[int, int], 0, 100)
+	for i := 0; i < 200; i += 2 {
+		n, _ := avlts.Search(tree, i)
+		nodes = append(nodes, n)
+	}
+	for _, hint := range nodes {
+		for key := -1; key <= 200; key++ {
+			n, ok := avlts.SearchFrom(hint, key)
+			require.Equal(t, key%2 == 0 && key >= 0 && key < 200, ok, "hint %d key %d", hint.Key(), key)
+			if ok {
+				assert.Equal(t, key, n.Key())
+			}
+		}
+	}
+	_, ok := avlts.SearchFrom[int, int](nil, 4)
+	assert.False(t, ok)
+}
+
+func TestInsertNear(t *testing.T) {
+	tree := avlts.New[int, string]()
+	var hint *avlts.Node[int, string]
+	for _, k := range []int{1, 2, 3, 5, 4, 6, 8, 7} {
+		var inserted bool
+		hint, inserted = avlts.InsertNear(tree, hint, k, fmt.Sprint(k))
+		assert.True(t, inserted)
+		assert.Equal(t, k, hint.Key())
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8}, treeKeys(tree))
+	require.NoError(t, avlts.Validate(tree))
+
+	n, inserted := avlts.InsertNear(tree, hint, 2, "two")
+	assert.False(t, inserted)
+	assert.Equal(t, "two", n.Value())
+	assert.Equal(t, 8, avlts.Len(tree))
+}
+
+func TestInsertNearRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(4, 2))
+	tree := avlts.New[int, int](avlts.WithPrefixSums[int]())
+	want := avlts.New[int, int]()
+	var updates int
+	avlts.OnUpdate(tree, func(int, int, int) { updates++ })
+
+	var hint *avlts.Node[int, int]
+	for i := range 5000 {
+		k := i + r.IntN(50) // mostly ascending
+		if r.IntN(10) == 0 {
+			k = r.IntN(5000)
+		}
+		var ok bool
+		hint, ok = avlts.InsertNear(tree, hint, k, i)
+		assert.Equal(t, avlts.Insert(want, k, i), ok)
+	}
+	require.NoError(t, avlts.Validate(tree))
+	assert.Zero(t, avlts.Compare(want, tree, func(a, b int) int { return a - b }))
+	assert.Equal(t, 5000-avlts.Len(tree), updates)
+}
+
+func ExampleInsertNear() {
+	tree := avlts.New[int, string]()
+	var hint *avlts.Node[int, string]
+	for _, k := range []int{10, 11, 13, 12, 14} {
+		hint, _ = avlts.InsertNear(tree, hint, k, "")
+	}
+	fmt.Println(avlts.AppendKeys(tree, nil))
+	// Output: [10 11 12 13 14]
+}
+
+func ExampleSearchFrom() {
+	tree := avlts.New[int, string]()
+	for i := 1; i <= 100; i++ {
+		avlts.Insert(tree, i, fmt.Sprint("v", i))
+	}
+	hint, _ := avlts.Search(tree, 50)
+	n, ok := avlts.SearchFrom(hint, 52)
+	fmt.Println(n.Value(), ok)
+	// Output: v52 true
+}
+
+func BenchmarkInsertNear(b *testing.B) {
+	const n = 100_000
+	b.Run("Insert", func(b *testing.B) {
+		for range b.N {
+			tree := avlts.New[int, int]()
+			for k := range n {
+				avlts.Insert(tree, k, k)
+			}
+		}
+	})
+	b.Run("InsertNear", func(b *testing.B) {
+		for range b.N {
+			tree := avlts.New[int, int]()
+			var hint *avlts.Node[int, int]
+			for k := range n {
+				hint, _ = avlts.InsertNear(tree, hint, k, k)
+			}
+		}
+	})
+}
@@ -0,0 +1,28 @@
+package avltrees
+
+import (
+	"cmp"
+	"errors"
+)
+
+// ErrFrozen is the value passed to panic when a frozen tree is modified.
+var ErrFrozen = errors.New("avltrees: modification of a frozen tree")
+
+// Freeze makes the AVL tree read-only. Every later call that would modify
+// it, such as Insert, Delete, Clear or InsertBatch, panics with ErrFrozen
+// instead. A frozen tree is safe for concurrent reads without locking.
+// Freezing cannot be undone.
+func Freeze[K cmp.Ordered, V any](t *Tree[K, V]) {
+	t.frozen = true
+}
+
+// Frozen reports whether Freeze has been called on the AVL tree.
+func Frozen[K cmp.Ordered, V any](t *Tree[K, V]) bool {
+	return t.frozen
+}
+
+func checkMutable[K cmp.Ordered, V any](t *Tree[K, V]) {
+	if t.frozen {
+		panic(ErrFrozen)
+	}
+}
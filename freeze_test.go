@@ -0,0 +1,64 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeze(t *testing.T) {
+	tree := avlts.New[int, string]()
+	avlts.Insert(tree, 1, "a")
+	avlts.Insert(tree, 2, "b")
+	assert.False(t, avlts.Frozen(tree))
+
+	avlts.Freeze(tree)
+	assert.True(t, avlts.Frozen(tree))
+
+	mutations := map[string]func(){
+		"Insert":         func() { avlts.Insert(tree, 3, "c") },
+		"InsertIfAbsent": func() { avlts.InsertIfAbsent(tree, 1, "z") },
+		"InsertEvict":    func() { avlts.InsertEvict(tree, 3, "c") },
+		"Delete":         func() { avlts.Delete(tree, 1) },
+		"ReplaceKey":     func() { avlts.ReplaceKey(tree, 1, 5) },
+		"Clear":          func() { avlts.Clear(tree) },
+		"SetValue":       func() { avlts.SetValue(tree, tree.Root, "z") },
+		"InsertBatch":    func() { avlts.InsertBatch(tree, []avlts.Item[int, string]{{Key: 3}}) },
+		"InsertAll":      func() { avlts.InsertAll(tree, map[int]string{3: "c"}) },
+		"InsertSeq2":     func() { avlts.InsertSeq2(tree, maps.All(map[int]string{3: "c"})) },
+		"DeleteAll":      func() { avlts.DeleteAll(tree, slices.Values([]int{1})) },
+		"DeleteFunc":     func() { avlts.DeleteFunc(tree, func(int, string) bool { return true }) },
+		"RetainFunc":     func() { avlts.RetainFunc(tree, func(int, string) bool { return false }) },
+		"DeleteBefore":   func() { avlts.DeleteBefore(tree, 2) },
+		"DeleteAfter":    func() { avlts.DeleteAfter(tree, 1) },
+		"Compact":        func() { avlts.Compact(tree) },
+		"OrInsert":       func() { avlts.Entry(tree, 3).OrInsert("c") },
+		"AndModify":      func() { avlts.Entry(tree, 1).AndModify(func(v string) string { return v + "!" }) },
+	}
+	for name, mutate := range mutations {
+		assert.PanicsWithValue(t, avlts.ErrFrozen, mutate, name)
+	}
+
+	// Reads still work and the tree is unchanged.
+	assert.Equal(t, 2, avlts.Len(tree))
+	v, ok := avlts.Get(tree, 1)
+	assert.True(t, ok)
+	assert.Equal(t, "a", v)
+	assert.Equal(t, "a", avlts.Entry(tree, 1).OrInsert("x").Value(), "OrInsert on a present key does not modify")
+}
+
+func ExampleFreeze() {
+	tree := avlts.New[string, int]()
+	avlts.Insert(tree, "max_connections", 100)
+	avlts.Freeze(tree)
+
+	defer func() {
+		fmt.Println(recover())
+	}()
+	avlts.Insert(tree, "max_connections", 200)
+	// Output: avltrees: modification of a frozen tree
+}
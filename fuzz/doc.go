@@ -0,0 +1,11 @@
+// Package fuzz holds a differential fuzzing harness for avltrees.
+//
+// FuzzOps decodes its input into a sequence of tree operations, applies
+// them to an AVL tree and to a sorted-slice oracle, and fails on the first
+// result that differs or on any violation reported by avltrees.Validate.
+// Run it with
+//
+//	go test -fuzz FuzzOps ./fuzz
+//
+// Without -fuzz, the seed corpus runs as a regular test.
+package fuzz
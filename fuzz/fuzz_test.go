@@ -0,0 +1,120 @@
+package fuzz
+
+import (
+	"slices"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+)
+
+const (
+	opInsert = iota
+	opDelete
+	opSearch
+	opRank
+	opKth
+	opCeiling
+	opDeleteBefore
+	opDeleteAfter
+	opCount
+)
+
+// oracle is a sorted slice of keys with their values.
+type oracle struct {
+	keys   []int
+	values []int
+}
+
+func (o *oracle) find(key int) (int, bool) {
+	return slices.BinarySearch(o.keys, key)
+}
+
+func (o *oracle) insert(key, value int) bool {
+	i, found := o.find(key)
+	if found {
+		o.values[i] = value
+		return false
+	}
+	o.keys = slices.Insert(o.keys, i, key)
+	o.values = slices.Insert(o.values, i, value)
+	return true
+}
+
+func (o *oracle) delete(key int) bool {
+	i, found := o.find(key)
+	if found {
+		o.keys = slices.Delete(o.keys, i, i+1)
+		o.values = slices.Delete(o.values, i, i+1)
+	}
+	return found
+}
+
+func FuzzOps(f *testing.F) {
+	f.Add([]byte{0, 5, 1, 0, 3, 2, 0, 7, 9, 1, 5, 0, 3, 3, 4, 1})
+	f.Add([]byte{0, 1, 0, 0, 2, 0, 0, 3, 0, 0, 4, 0, 0, 5, 0, 6, 3, 0, 7, 4, 0})
+	f.Add([]byte{0, 200, 1, 0, 100, 2, 0, 50, 3, 0, 150, 4, 4, 1, 0, 1, 100, 0, 2, 50, 0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		tree := avlts.New[int, int]()
+		var o oracle
+		for i := 0; i+2 < len(data); i += 3 {
+			op, key, value := int(data[i])%opCount, int(data[i+1]), int(data[i+2])
+			switch op {
+			case opInsert:
+				if got, want := avlts.Insert(tree, key, value), o.insert(key, value); got != want {
+					t.Fatalf("Insert(%d) = %v, want %v", key, got, want)
+				}
+			case opDelete:
+				if got, want := avlts.Delete(tree, key), o.delete(key); got != want {
+					t.Fatalf("Delete(%d) = %v, want %v", key, got, want)
+				}
+			case opSearch:
+				n, ok := avlts.Search(tree, key)
+				j, found := o.find(key)
+				if ok != found || (ok && n.Value() != o.values[j]) {
+					t.Fatalf("Search(%d) disagrees with oracle", key)
+				}
+			case opRank:
+				j, _ := o.find(key)
+				if got := avlts.Rank(tree, key); got != j {
+					t.Fatalf("Rank(%d) = %d, want %d", key, got, j)
+				}
+			case opKth:
+				n, ok := avlts.Kth(tree, key)
+				if ok != (key < len(o.keys)) || (ok && n.Key() != o.keys[key]) {
+					t.Fatalf("Kth(%d) disagrees with oracle", key)
+				}
+			case opCeiling:
+				n, ok := avlts.Ceiling(tree, key)
+				j, _ := o.find(key)
+				if ok != (j < len(o.keys)) || (ok && n.Key() != o.keys[j]) {
+					t.Fatalf("Ceiling(%d) disagrees with oracle", key)
+				}
+			case opDeleteBefore:
+				j, _ := o.find(key)
+				if got := avlts.DeleteBefore(tree, key); got != j {
+					t.Fatalf("DeleteBefore(%d) = %d, want %d", key, got, j)
+				}
+				o.keys, o.values = o.keys[j:], o.values[j:]
+			case opDeleteAfter:
+				j, found := o.find(key)
+				if found {
+					j++
+				}
+				if got := avlts.DeleteAfter(tree, key); got != len(o.keys)-j {
+					t.Fatalf("DeleteAfter(%d) = %d, want %d", key, got, len(o.keys)-j)
+				}
+				o.keys, o.values = o.keys[:j], o.values[:j]
+			}
+			if err := avlts.Validate(tree); err != nil {
+				t.Fatalf("after op %d on key %d: %v", op, key, err)
+			}
+		}
+		var keys []int
+		for n := range avlts.InOrder(tree) {
+			keys = append(keys, n.Key())
+		}
+		if !slices.Equal(keys, o.keys) {
+			t.Fatalf("InOrder = %v, want %v", keys, o.keys)
+		}
+	})
+}
@@ -0,0 +1,201 @@
+package avltrees
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+)
+
+// TreeMap adapts an AVL tree to the map and iterator interfaces of
+// github.com/emirpasic/gods (v2), so code built on that ecosystem can use
+// this tree without changes at its call sites.
+type TreeMap[K cmp.Ordered, V any] struct {
+	tree Tree[K, V]
+}
+
+// NewTreeMap returns a new empty TreeMap.
+func NewTreeMap[K cmp.Ordered, V any]() *TreeMap[K, V] {
+	return &TreeMap[K, V]{}
+}
+
+// Put inserts or replaces the value stored under key.
+func (m *TreeMap[K, V]) Put(key K, value V) {
+	Insert(&m.tree, key, value)
+}
+
+// Get returns the value stored under key and whether it was found.
+func (m *TreeMap[K, V]) Get(key K) (value V, found bool) {
+	n, found := Search(&m.tree, key)
+	if !found {
+		return value, false
+	}
+	return n.value, true
+}
+
+// Remove deletes the entry stored under key, if any.
+func (m *TreeMap[K, V]) Remove(key K) {
+	Delete(&m.tree, key)
+}
+
+// Empty reports whether the map has no entries.
+func (m *TreeMap[K, V]) Empty() bool {
+	return m.tree.Root == nil
+}
+
+// Size returns the number of entries in the map.
+func (m *TreeMap[K, V]) Size() int {
+	return Len(&m.tree)
+}
+
+// Clear removes all entries from the map.
+func (m *TreeMap[K, V]) Clear() {
+	Clear(&m.tree)
+}
+
+// Keys returns all keys in ascending order.
+func (m *TreeMap[K, V]) Keys() []K {
+	keys := make([]K, 0, Len(&m.tree))
+	for n := range InOrder(&m.tree) {
+		keys = append(keys, n.key)
+	}
+	return keys
+}
+
+// Values returns all values in ascending key order.
+func (m *TreeMap[K, V]) Values() []V {
+	values := make([]V, 0, Len(&m.tree))
+	for n := range InOrder(&m.tree) {
+		values = append(values, n.value)
+	}
+	return values
+}
+
+// Min returns the entry with the smallest key, if any.
+func (m *TreeMap[K, V]) Min() (key K, value V, ok bool) {
+	return entryOf(Min(&m.tree))
+}
+
+// Max returns the entry with the largest key, if any.
+func (m *TreeMap[K, V]) Max() (key K, value V, ok bool) {
+	return entryOf(Max(&m.tree))
+}
+
+// Floor returns the entry with the largest key less than or equal to key, if any.
+func (m *TreeMap[K, V]) Floor(key K) (foundKey K, foundValue V, ok bool) {
+	return entryOf(Floor(&m.tree, key))
+}
+
+// Ceiling returns the entry with the smallest key greater than or equal to key, if any.
+func (m *TreeMap[K, V]) Ceiling(key K) (foundKey K, foundValue V, ok bool) {
+	return entryOf(Ceiling(&m.tree, key))
+}
+
+// String returns a string representation of the map's entries.
+func (m *TreeMap[K, V]) String() string {
+	var sb strings.Builder
+	sb.WriteString("TreeMap\nmap[")
+	first := true
+	for n := range InOrder(&m.tree) {
+		if !first {
+			sb.WriteByte(' ')
+		}
+		first = false
+		fmt.Fprintf(&sb, "%v:%v", n.key, n.value)
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
+
+// Iterator returns a stateful iterator positioned before the first entry.
+func (m *TreeMap[K, V]) Iterator() *TreeMapIterator[K, V] {
+	return &TreeMapIterator[K, V]{m: m, pos: posBegin}
+}
+
+type iterPosition int
+
+const (
+	posBegin iterPosition = iota
+	posBetween
+	posEnd
+)
+
+// TreeMapIterator is a stateful bidirectional iterator over a TreeMap,
+// following the gods ReverseIteratorWithKey contract.
+type TreeMapIterator[K cmp.Ordered, V any] struct {
+	m    *TreeMap[K, V]
+	node *Node[K, V]
+	pos  iterPosition
+}
+
+// Next moves the iterator to the next entry and reports whether one exists.
+func (it *TreeMapIterator[K, V]) Next() bool {
+	var ok bool
+	switch it.pos {
+	case posBegin:
+		it.node, ok = Min(&it.m.tree)
+	case posBetween:
+		it.node, ok = Successor(it.node)
+	}
+	if ok {
+		it.pos = posBetween
+	} else {
+		it.End()
+	}
+	return ok
+}
+
+// Prev moves the iterator to the previous entry and reports whether one exists.
+func (it *TreeMapIterator[K, V]) Prev() bool {
+	var ok bool
+	switch it.pos {
+	case posEnd:
+		it.node, ok = Max(&it.m.tree)
+	case posBetween:
+		it.node, ok = Predecessor(it.node)
+	}
+	if ok {
+		it.pos = posBetween
+	} else {
+		it.Begin()
+	}
+	return ok
+}
+
+// Key returns the key of the current entry.
+func (it *TreeMapIterator[K, V]) Key() K {
+	return it.node.key
+}
+
+// Value returns the value of the current entry.
+func (it *TreeMapIterator[K, V]) Value() V {
+	return it.node.value
+}
+
+// Begin resets the iterator to its initial state, before the first entry.
+func (it *TreeMapIterator[K, V]) Begin() {
+	it.node, it.pos = nil, posBegin
+}
+
+// End moves the iterator past the last entry.
+func (it *TreeMapIterator[K, V]) End() {
+	it.node, it.pos = nil, posEnd
+}
+
+// First moves the iterator to the first entry and reports whether one exists.
+func (it *TreeMapIterator[K, V]) First() bool {
+	it.Begin()
+	return it.Next()
+}
+
+// Last moves the iterator to the last entry and reports whether one exists.
+func (it *TreeMapIterator[K, V]) Last() bool {
+	it.End()
+	return it.Prev()
+}
+
+func entryOf[K cmp.Ordered, V any](n *Node[K, V], ok bool) (key K, value V, found bool) {
+	if !ok {
+		return key, value, false
+	}
+	return n.key, n.value, true
+}
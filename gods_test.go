@@ -0,0 +1,115 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTreeMapPutGetRemove(t *testing.T) {
+	m := avlts.NewTreeMap[int, string]()
+	assert.True(t, m.Empty())
+
+	m.Put(2, "b")
+	m.Put(1, "a")
+	m.Put(2, "B")
+	assert.Equal(t, 2, m.Size())
+	assert.False(t, m.Empty())
+
+	v, found := m.Get(2)
+	require.True(t, found)
+	assert.Equal(t, "B", v)
+
+	m.Remove(2)
+	_, found = m.Get(2)
+	assert.False(t, found)
+
+	m.Clear()
+	assert.True(t, m.Empty())
+}
+
+func TestTreeMapKeysValues(t *testing.T) {
+	m := avlts.NewTreeMap[int, string]()
+	m.Put(3, "c")
+	m.Put(1, "a")
+	m.Put(2, "b")
+
+	assert.Equal(t, []int{1, 2, 3}, m.Keys())
+	assert.Equal(t, []string{"a", "b", "c"}, m.Values())
+	assert.Equal(t, "TreeMap\nmap[1:a 2:b 3:c]", m.String())
+}
+
+func TestTreeMapNavigation(t *testing.T) {
+	m := avlts.NewTreeMap[int, string]()
+	_, _, ok := m.Min()
+	assert.False(t, ok)
+
+	m.Put(10, "ten")
+	m.Put(20, "twenty")
+
+	k, v, ok := m.Min()
+	require.True(t, ok)
+	assert.Equal(t, 10, k)
+	assert.Equal(t, "ten", v)
+
+	k, _, ok = m.Max()
+	require.True(t, ok)
+	assert.Equal(t, 20, k)
+
+	k, _, ok = m.Floor(15)
+	require.True(t, ok)
+	assert.Equal(t, 10, k)
+
+	k, _, ok = m.Ceiling(15)
+	require.True(t, ok)
+	assert.Equal(t, 20, k)
+
+	_, _, ok = m.Ceiling(25)
+	assert.False(t, ok)
+}
+
+func TestTreeMapIterator(t *testing.T) {
+	m := avlts.NewTreeMap[int, string]()
+	it := m.Iterator()
+	assert.False(t, it.Next())
+	assert.False(t, it.First())
+
+	for _, k := range []int{2, 1, 3} {
+		m.Put(k, "")
+	}
+
+	it = m.Iterator()
+	var keys []int
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	assert.Equal(t, []int{1, 2, 3}, keys)
+
+	keys = nil
+	for it.Prev() {
+		keys = append(keys, it.Key())
+	}
+	assert.Equal(t, []int{3, 2, 1}, keys)
+
+	require.True(t, it.Last())
+	assert.Equal(t, 3, it.Key())
+	require.True(t, it.First())
+	assert.Equal(t, 1, it.Key())
+	assert.Equal(t, "", it.Value())
+}
+
+func ExampleTreeMap() {
+	m := avlts.NewTreeMap[string, int]()
+	m.Put("b", 2)
+	m.Put("a", 1)
+	it := m.Iterator()
+	for it.Next() {
+		fmt.Println(it.Key(), it.Value())
+	}
+	// Output:
+	// a 1
+	// b 2
+}
@@ -0,0 +1,76 @@
+package avltrees
+
+import "cmp"
+
+// WithMultisetHash makes the tree maintain a multiset hash of the entries
+// in every subtree, so MultisetHash and RangeMultisetHash answer in O(1)
+// and O(log n). hash must map each key/value pair to a well-distributed
+// 64-bit value; it is mixed further before use. The type parameters must
+// match the tree's key and value types; New panics otherwise.
+//
+// This is not a Merkle hash: a subtree's hash is the sum of its entries'
+// hashes, not a hash of its children's hashes. Two trees holding the same
+// entries therefore have the same hash however their nodes are arranged,
+// and the hash of any key range can be derived by subtraction, but
+// subtrees of two trees cannot be compared node by node.
+func WithMultisetHash[K cmp.Ordered, V any](hash func(key K, value V) uint64) Option {
+	return func(o *options) {
+		o.hash = hash
+	}
+}
+
+// MultisetHash returns the multiset hash of the entries of the AVL tree,
+// or 0 if it is empty. Trees with equal entries have equal hashes; trees
+// with different entries have different hashes with high probability.
+// Always returns 0 if the tree was not created WithMultisetHash.
+func MultisetHash[K cmp.Ordered, V any](t *Tree[K, V]) uint64 {
+	if t.aug == nil || t.aug.hash == nil {
+		return 0
+	}
+	return hashSum(t.Root)
+}
+
+// RangeMultisetHash returns the multiset hash of the entries with keys in
+// the range [from, to), computed in O(log n). Comparing range hashes lets
+// two replicas narrow down where they diverge, by bisecting the key space,
+// without exchanging their contents.
+// Always returns 0 if the tree was not created WithMultisetHash.
+func RangeMultisetHash[K cmp.Ordered, V any](t *Tree[K, V], from, to K) uint64 {
+	if t.aug == nil || t.aug.hash == nil || from >= to {
+		return 0
+	}
+	return hashBefore(t, to) - hashBefore(t, from)
+}
+
+// hashBefore returns the hash of the entries with keys less than key.
+func hashBefore[K cmp.Ordered, V any](t *Tree[K, V], key K) uint64 {
+	var h uint64
+	curr := t.Root
+	for curr != nil {
+		if key <= curr.key {
+			curr = curr.left
+		} else {
+			h += ext(curr).hash - hashSum(curr.right)
+			curr = curr.right
+		}
+	}
+	return h
+}
+
+func hashSum[K cmp.Ordered, V any](n *Node[K, V]) uint64 {
+	if n == nil {
+		return 0
+	}
+	return ext(n).hash
+}
+
+// mix64 is the splitmix64 finalizer. It keeps sums of weak entry hashes,
+// such as identity hashes of small integers, from colliding trivially.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
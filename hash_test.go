@@ -0,0 +1,120 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func entryHash(k, v int) uint64 {
+	return uint64(k)<<32 ^ uint64(v)
+}
+
+func TestMultisetHash(t *testing.T) {
+	a := avlts.New[int, int](avlts.WithMultisetHash(entryHash))
+	b := avlts.New[int, int](avlts.WithMultisetHash(entryHash))
+	assert.Zero(t, avlts.MultisetHash(a))
+
+	for i := range 100 {
+		avlts.Insert(a, i, i*i)
+		avlts.Insert(b, 99-i, (99-i)*(99-i))
+	}
+	assert.NotEqual(t, avlts.Sprint(a), avlts.Sprint(b), "shapes differ")
+	assert.Equal(t, avlts.MultisetHash(a), avlts.MultisetHash(b))
+
+	avlts.Insert(b, 50, 0)
+	assert.NotEqual(t, avlts.MultisetHash(a), avlts.MultisetHash(b))
+	avlts.Insert(b, 50, 2500)
+	assert.Equal(t, avlts.MultisetHash(a), avlts.MultisetHash(b))
+
+	avlts.Delete(a, 10)
+	assert.NotEqual(t, avlts.MultisetHash(a), avlts.MultisetHash(b))
+
+	assert.Zero(t, avlts.MultisetHash(avlts.New[int, int]()))
+	assert.Panics(t, func() { avlts.New[string, int](avlts.WithMultisetHash(entryHash)) })
+}
+
+func TestMultisetHashRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	tree := avlts.New[int, int](avlts.WithMultisetHash(entryHash))
+	for range 2000 {
+		k := r.Intn(300)
+		if r.Intn(3) == 0 {
+			avlts.Delete(tree, k)
+		} else {
+			avlts.Insert(tree, k, r.Intn(10))
+		}
+	}
+	rebuilt := avlts.New[int, int](avlts.WithMultisetHash(entryHash))
+	for n := range avlts.InOrder(tree) {
+		avlts.Insert(rebuilt, n.Key(), n.Value())
+	}
+	assert.Equal(t, avlts.MultisetHash(rebuilt), avlts.MultisetHash(tree))
+}
+
+func TestRangeMultisetHash(t *testing.T) {
+	tree := avlts.New[int, int](avlts.WithMultisetHash(entryHash))
+	part := avlts.New[int, int](avlts.WithMultisetHash(entryHash))
+	for i := range 100 {
+		avlts.Insert(tree, i, i)
+		if i >= 20 && i < 60 {
+			avlts.Insert(part, i, i)
+		}
+	}
+	assert.Equal(t, avlts.MultisetHash(part), avlts.RangeMultisetHash(tree, 20, 60))
+	assert.Equal(t, avlts.MultisetHash(tree), avlts.RangeMultisetHash(tree, -1, 100))
+	assert.Zero(t, avlts.RangeMultisetHash(tree, 60, 20))
+	assert.Zero(t, avlts.RangeMultisetHash(tree, 200, 300))
+
+	avlts.Insert(tree, 70, -1)
+	assert.Equal(t, avlts.MultisetHash(part), avlts.RangeMultisetHash(tree, 20, 60))
+}
+
+func TestRangeMultisetHashFindsDivergence(t *testing.T) {
+	a := avlts.New[int, int](avlts.WithMultisetHash(entryHash))
+	b := avlts.New[int, int](avlts.WithMultisetHash(entryHash))
+	for i := range 1000 {
+		avlts.Insert(a, i, i)
+		avlts.Insert(b, i, i)
+	}
+	avlts.Insert(b, 637, 0)
+
+	lo, hi := 0, 1000
+	for hi-lo > 1 {
+		mid := (lo + hi) / 2
+		if avlts.RangeMultisetHash(a, lo, mid) != avlts.RangeMultisetHash(b, lo, mid) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	require.Equal(t, 637, lo)
+}
+
+func ExampleMultisetHash() {
+	h := func(k, v string) uint64 {
+		f := fnv.New64a()
+		f.Write([]byte(k))
+		f.Write([]byte{0})
+		f.Write([]byte(v))
+		return f.Sum64()
+	}
+	primary := avlts.New[string, string](avlts.WithMultisetHash(h))
+	replica := avlts.New[string, string](avlts.WithMultisetHash(h))
+	avlts.Insert(primary, "a", "1")
+	avlts.Insert(primary, "b", "2")
+	avlts.Insert(replica, "b", "2")
+	avlts.Insert(replica, "a", "1")
+
+	fmt.Println(avlts.MultisetHash(primary) == avlts.MultisetHash(replica))
+	avlts.Insert(replica, "a", "3")
+	fmt.Println(avlts.MultisetHash(primary) == avlts.MultisetHash(replica))
+	// Output:
+	// true
+	// false
+}
@@ -0,0 +1,61 @@
+package avltrees_test
+
+import (
+	"cmp"
+	"math"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+)
+
+// treeOf returns a tree configured by opts that maps keys[i] to values[i],
+// inserting the keys one at a time in order. values may be shorter than
+// keys, or nil; the remaining keys get the zero value.
+func treeOf[K cmp.Ordered, V any](keys []K, values []V, opts ...avlts.Option) *avlts.Tree[K, V] {
+	tree := avlts.New[K, V](opts...)
+	for i, k := range keys {
+		var v V
+		if i < len(values) {
+			v = values[i]
+		}
+		avlts.Insert(tree, k, v)
+	}
+	return tree
+}
+
+// assertBalanced checks the AVL balance and key order of tree.
+func assertBalanced[K cmp.Ordered, V any](t *testing.T, tree *avlts.Tree[K, V]) {
+	t.Helper()
+	s := avlts.Stats(tree)
+	assert.Equal(t, s.Count, s.LeftHeavy+s.Balanced+s.RightHeavy, "balance factors out of range")
+	assert.Equal(t, avlts.Len(tree), s.Count)
+	if s.Count > 0 {
+		assert.LessOrEqual(t, float64(s.Height), 1.45*math.Log2(float64(s.Count+2)))
+	}
+	prev, first := *new(K), true
+	for n := range avlts.InOrder(tree) {
+		if !first {
+			assert.Less(t, prev, n.Key())
+		}
+		prev, first = n.Key(), false
+	}
+}
+
+// treeKeys returns the keys of tree in order.
+func treeKeys[V any](tree *avlts.Tree[int, V]) []int {
+	var keys []int
+	for n := range avlts.InOrder(tree) {
+		keys = append(keys, n.Key())
+	}
+	return keys
+}
+
+// evenInts returns the even numbers in [0, n).
+func evenInts(n int) []int {
+	var out []int
+	for i := 0; i < n; i += 2 {
+		out = append(out, i)
+	}
+	return out
+}
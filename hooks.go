@@ -0,0 +1,97 @@
+package avltrees
+
+import "cmp"
+
+type hook[F any] struct {
+	id int
+	fn F
+}
+
+type hooks[K cmp.Ordered, V any] struct {
+	nextID   int
+	onInsert []hook[func(key K, value V)]
+	onUpdate []hook[func(key K, old, new V)]
+	onDelete []hook[func(key K, value V)]
+}
+
+// OnInsert registers f to be called after a new key is added to the AVL tree.
+// Hooks run synchronously in registration order and must not modify the tree.
+// Calling the returned function unregisters f.
+func OnInsert[K cmp.Ordered, V any](t *Tree[K, V], f func(key K, value V)) (remove func()) {
+	h := ensureHooks(t)
+	id := h.nextID
+	h.nextID++
+	h.onInsert = append(h.onInsert, hook[func(K, V)]{id, f})
+	return func() { h.onInsert = removeHook(h.onInsert, id) }
+}
+
+// OnUpdate registers f to be called after the value of an existing key is overwritten.
+// Hooks run synchronously in registration order and must not modify the tree.
+// Calling the returned function unregisters f.
+func OnUpdate[K cmp.Ordered, V any](t *Tree[K, V], f func(key K, old, new V)) (remove func()) {
+	h := ensureHooks(t)
+	id := h.nextID
+	h.nextID++
+	h.onUpdate = append(h.onUpdate, hook[func(K, V, V)]{id, f})
+	return func() { h.onUpdate = removeHook(h.onUpdate, id) }
+}
+
+// OnDelete registers f to be called after a key is removed from the AVL tree,
+// including removals by Clear and eviction from bounded trees.
+// Hooks run synchronously in registration order and must not modify the tree.
+// Calling the returned function unregisters f.
+func OnDelete[K cmp.Ordered, V any](t *Tree[K, V], f func(key K, value V)) (remove func()) {
+	h := ensureHooks(t)
+	id := h.nextID
+	h.nextID++
+	h.onDelete = append(h.onDelete, hook[func(K, V)]{id, f})
+	return func() { h.onDelete = removeHook(h.onDelete, id) }
+}
+
+func ensureHooks[K cmp.Ordered, V any](t *Tree[K, V]) *hooks[K, V] {
+	if t.hooks == nil {
+		t.hooks = &hooks[K, V]{}
+	}
+	return t.hooks
+}
+
+func removeHook[F any](hs []hook[F], id int) []hook[F] {
+	for i, h := range hs {
+		if h.id == id {
+			return append(hs[:i:i], hs[i+1:]...)
+		}
+	}
+	return hs
+}
+
+// The notify functions are called after every mutation of the tree, so
+// they also advance its version.
+func notifyInsert[K cmp.Ordered, V any](t *Tree[K, V], key K, value V) {
+	t.version++
+	if t.hooks == nil {
+		return
+	}
+	for _, h := range t.hooks.onInsert {
+		h.fn(key, value)
+	}
+}
+
+func notifyUpdate[K cmp.Ordered, V any](t *Tree[K, V], key K, old, new V) {
+	t.version++
+	if t.hooks == nil {
+		return
+	}
+	for _, h := range t.hooks.onUpdate {
+		h.fn(key, old, new)
+	}
+}
+
+func notifyDelete[K cmp.Ordered, V any](t *Tree[K, V], key K, value V) {
+	t.version++
+	if t.hooks == nil {
+		return
+	}
+	for _, h := range t.hooks.onDelete {
+		h.fn(key, value)
+	}
+}
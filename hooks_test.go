@@ -0,0 +1,114 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnInsert(t *testing.T) {
+	tree := avlts.New[int, string]()
+	var got []string
+	remove := avlts.OnInsert(tree, func(k int, v string) {
+		got = append(got, fmt.Sprint(k, v))
+	})
+
+	avlts.Insert(tree, 1, "a")
+	avlts.Insert(tree, 1, "b")
+	avlts.InsertBatch(tree, []avlts.Item[int, string]{{Key: 2, Value: "c"}, {Key: 1, Value: "d"}})
+	assert.Equal(t, []string{"1a", "2c"}, got)
+
+	remove()
+	avlts.Insert(tree, 3, "e")
+	assert.Equal(t, []string{"1a", "2c"}, got)
+}
+
+func TestOnUpdate(t *testing.T) {
+	tree := avlts.New[int, string]()
+	var got []string
+	avlts.OnUpdate(tree, func(k int, old, new string) {
+		got = append(got, fmt.Sprint(k, old, new))
+	})
+
+	avlts.Insert(tree, 1, "a")
+	avlts.Insert(tree, 1, "b")
+	avlts.InsertBatch(tree, []avlts.Item[int, string]{{Key: 1, Value: "c"}, {Key: 2, Value: "d"}})
+	assert.Equal(t, []string{"1ab", "1bc"}, got)
+}
+
+func TestOnDelete(t *testing.T) {
+	tree := avlts.NewBounded[int, string](3, avlts.EvictMin)
+	var got []int
+	avlts.OnDelete(tree, func(k int, v string) {
+		got = append(got, k)
+	})
+
+	for i := range 4 {
+		avlts.Insert(tree, i, "")
+	}
+	assert.Equal(t, []int{0}, got, "eviction should fire OnDelete")
+
+	avlts.Delete(tree, 2)
+	avlts.Delete(tree, 42)
+	assert.Equal(t, []int{0, 2}, got)
+
+	avlts.Clear(tree)
+	assert.Equal(t, []int{0, 2, 1, 3}, got, "Clear should fire OnDelete for every entry")
+}
+
+func TestOnDeleteValue(t *testing.T) {
+	tree := avlts.New[int, string]()
+	for i := range 7 {
+		avlts.Insert(tree, i, fmt.Sprint("v", i))
+	}
+	deleted := map[int]string{}
+	avlts.OnDelete(tree, func(k int, v string) { deleted[k] = v })
+
+	avlts.Delete(tree, 3)
+	assert.Equal(t, map[int]string{3: "v3"}, deleted)
+}
+
+func TestHooksRemoveOne(t *testing.T) {
+	tree := avlts.New[int, string]()
+	var calls []string
+	removeA := avlts.OnInsert(tree, func(int, string) { calls = append(calls, "a") })
+	avlts.OnInsert(tree, func(int, string) { calls = append(calls, "b") })
+
+	avlts.Insert(tree, 1, "")
+	removeA()
+	removeA()
+	avlts.Insert(tree, 2, "")
+	assert.Equal(t, []string{"a", "b", "b"}, calls)
+}
+
+func ExampleOnInsert() {
+	tree := avlts.New[string, int]()
+	avlts.OnInsert(tree, func(k string, v int) {
+		fmt.Println("inserted", k, v)
+	})
+	avlts.Insert(tree, "a", 1)
+	avlts.Insert(tree, "a", 2)
+	// Output: inserted a 1
+}
+
+func ExampleOnUpdate() {
+	tree := avlts.New[string, int]()
+	avlts.OnUpdate(tree, func(k string, old, new int) {
+		fmt.Println("updated", k, old, "->", new)
+	})
+	avlts.Insert(tree, "a", 1)
+	avlts.Insert(tree, "a", 2)
+	// Output: updated a 1 -> 2
+}
+
+func ExampleOnDelete() {
+	tree := avlts.New[string, int]()
+	avlts.OnDelete(tree, func(k string, v int) {
+		fmt.Println("deleted", k, v)
+	})
+	avlts.Insert(tree, "a", 1)
+	avlts.Delete(tree, "a")
+	// Output: deleted a 1
+}
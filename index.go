@@ -0,0 +1,111 @@
+package avltrees
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Index is a secondary index that orders the entries of a tree by an
+// attribute derived from their values. It is kept up to date through the
+// tree's hooks, so every Insert, Delete, and overwrite is reflected
+// immediately. Entries with equal attributes are ordered by key.
+type Index[K cmp.Ordered, V any, S cmp.Ordered] struct {
+	extract func(V) S
+	tree    Tree[S, *Tree[K, V]]
+	count   int
+	detach  []func()
+}
+
+// NewIndex builds a secondary index over the entries of t ordered by
+// extract(value) and keeps it in sync with later mutations of t.
+// extract must be deterministic.
+func NewIndex[K cmp.Ordered, V any, S cmp.Ordered](t *Tree[K, V], extract func(V) S) *Index[K, V, S] {
+	x := &Index[K, V, S]{extract: extract}
+	for n := range InOrder(t) {
+		x.add(n.key, n.value)
+	}
+	x.detach = []func(){
+		OnInsert(t, x.add),
+		OnUpdate(t, func(key K, old, new V) {
+			x.remove(key, old)
+			x.add(key, new)
+		}),
+		OnDelete(t, x.remove),
+	}
+	return x
+}
+
+// Len returns the number of entries in the index.
+func (x *Index[K, V, S]) Len() int {
+	return x.count
+}
+
+// Min returns the entry with the smallest attribute.
+func (x *Index[K, V, S]) Min() (*Node[K, V], bool) {
+	n, ok := Min(&x.tree)
+	if !ok {
+		return nil, false
+	}
+	return Min(n.value)
+}
+
+// Max returns the entry with the largest attribute.
+func (x *Index[K, V, S]) Max() (*Node[K, V], bool) {
+	n, ok := Max(&x.tree)
+	if !ok {
+		return nil, false
+	}
+	return Max(n.value)
+}
+
+// InOrder returns an iterator over the entries in ascending attribute order.
+func (x *Index[K, V, S]) InOrder() iter.Seq[Node[K, V]] {
+	return x.each(InOrder(&x.tree))
+}
+
+// Range returns an iterator over the entries whose attribute is in the
+// range [from, to), in ascending attribute order.
+func (x *Index[K, V, S]) Range(from, to S) iter.Seq[Node[K, V]] {
+	return x.each(Range(&x.tree, from, to))
+}
+
+// Detach stops maintaining the index. The index keeps its current contents.
+func (x *Index[K, V, S]) Detach() {
+	for _, remove := range x.detach {
+		remove()
+	}
+	x.detach = nil
+}
+
+func (x *Index[K, V, S]) each(groups iter.Seq[Node[S, *Tree[K, V]]]) iter.Seq[Node[K, V]] {
+	return func(yield func(Node[K, V]) bool) {
+		for g := range groups {
+			for n := range InOrder(g.value) {
+				if !yield(n) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (x *Index[K, V, S]) add(key K, value V) {
+	g := Entry(&x.tree, x.extract(value)).OrInsertWith(func() *Tree[K, V] { return New[K, V]() })
+	if Insert(g.value, key, value) {
+		x.count++
+	}
+}
+
+func (x *Index[K, V, S]) remove(key K, value V) {
+	s := x.extract(value)
+	g, ok := Search(&x.tree, s)
+	if !ok {
+		return
+	}
+	if Delete(g.value, key) {
+		x.count--
+	}
+	if g.value.Root == nil {
+		Delete(&x.tree, s)
+	}
+}
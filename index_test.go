@@ -0,0 +1,124 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"iter"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type user struct {
+	name       string
+	lastActive int
+}
+
+func byLastActive(u user) int { return u.lastActive }
+
+func indexKeys(seq iter.Seq[avlts.Node[int, user]]) []int {
+	var keys []int
+	for n := range seq {
+		keys = append(keys, n.Key())
+	}
+	return keys
+}
+
+func TestNewIndex(t *testing.T) {
+	tree := treeOf([]int{1, 2, 3}, []user{{"ann", 30}, {"bob", 10}, {"cat", 20}})
+	idx := avlts.NewIndex(tree, byLastActive)
+	assert.Equal(t, 3, idx.Len())
+	assert.Equal(t, []int{2, 3, 1}, indexKeys(idx.InOrder()))
+}
+
+func TestIndexTracksMutations(t *testing.T) {
+	tree := treeOf([]int{1, 2, 3}, []user{{"ann", 30}, {"bob", 10}, {"cat", 20}})
+	idx := avlts.NewIndex(tree, byLastActive)
+
+	avlts.Insert(tree, 4, user{"dan", 5})
+	avlts.Insert(tree, 2, user{"bob", 40})
+	avlts.Delete(tree, 3)
+	assert.Equal(t, []int{4, 1, 2}, indexKeys(idx.InOrder()))
+	assert.Equal(t, 3, idx.Len())
+
+	avlts.Clear(tree)
+	assert.Equal(t, 0, idx.Len())
+	assert.Empty(t, indexKeys(idx.InOrder()))
+}
+
+func TestIndexTies(t *testing.T) {
+	tree := avlts.New[int, user]()
+	idx := avlts.NewIndex(tree, byLastActive)
+	for _, k := range []int{5, 3, 4} {
+		avlts.Insert(tree, k, user{lastActive: 1})
+	}
+	avlts.Insert(tree, 1, user{lastActive: 2})
+	assert.Equal(t, []int{3, 4, 5, 1}, indexKeys(idx.InOrder()))
+
+	avlts.Delete(tree, 4)
+	assert.Equal(t, []int{3, 5, 1}, indexKeys(idx.InOrder()))
+}
+
+func TestIndexMinMax(t *testing.T) {
+	idx := avlts.NewIndex(avlts.New[int, user](), byLastActive)
+	_, ok := idx.Min()
+	assert.False(t, ok)
+	_, ok = idx.Max()
+	assert.False(t, ok)
+
+	idx = avlts.NewIndex(treeOf([]int{1, 2, 3}, []user{{"ann", 30}, {"bob", 10}, {"cat", 20}}), byLastActive)
+	n, ok := idx.Min()
+	require.True(t, ok)
+	assert.Equal(t, "bob", n.Value().name)
+	n, ok = idx.Max()
+	require.True(t, ok)
+	assert.Equal(t, "ann", n.Value().name)
+}
+
+func TestIndexRange(t *testing.T) {
+	idx := avlts.NewIndex(treeOf([]int{1, 2, 3}, []user{{"ann", 30}, {"bob", 10}, {"cat", 20}}), byLastActive)
+	assert.Equal(t, []int{2, 3}, indexKeys(idx.Range(10, 30)))
+	assert.Empty(t, indexKeys(idx.Range(31, 50)))
+}
+
+func TestIndexDetach(t *testing.T) {
+	tree := treeOf([]int{1, 2, 3}, []user{{"ann", 30}, {"bob", 10}, {"cat", 20}})
+	idx := avlts.NewIndex(tree, byLastActive)
+	idx.Detach()
+	avlts.Insert(tree, 4, user{"dan", 5})
+	assert.Equal(t, 3, idx.Len())
+}
+
+func ExampleNewIndex() {
+	users := avlts.New[string, int]() // name -> last active
+	recent := avlts.NewIndex(users, func(lastActive int) int { return -lastActive })
+
+	avlts.Insert(users, "ann", 100)
+	avlts.Insert(users, "bob", 300)
+	avlts.Insert(users, "cat", 200)
+	avlts.Insert(users, "ann", 400)
+
+	for n := range recent.InOrder() {
+		fmt.Println(n.Key(), n.Value())
+	}
+	// Output:
+	// ann 400
+	// bob 300
+	// cat 200
+}
+
+func ExampleIndex_Range() {
+	prices := avlts.New[string, int]()
+	avlts.Insert(prices, "apple", 3)
+	avlts.Insert(prices, "melon", 8)
+	avlts.Insert(prices, "pear", 4)
+	byPrice := avlts.NewIndex(prices, func(p int) int { return p })
+
+	for n := range byPrice.Range(3, 5) {
+		fmt.Println(n.Key())
+	}
+	// Output:
+	// apple
+	// pear
+}
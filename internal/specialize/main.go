@@ -0,0 +1,205 @@
+// Command specialize generates a copy of the slab tree with the key type
+// fixed to a concrete type, for callers whose profiles show overhead from
+// the generic key comparisons.
+//
+// Usage:
+//
+//	specialize -src slab.go -pkg int64tree -key int64 -out int64tree.go
+//
+// The key type parameter K is removed from every type and function in src
+// and each use of K is replaced with the given key type. The value type
+// parameter V is kept.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	src := flag.String("src", "", "source file with a generic tree over K and V")
+	pkg := flag.String("pkg", "", "package name of the generated file")
+	key := flag.String("key", "", "concrete key type, such as int64 or string")
+	out := flag.String("out", "", "output file")
+	flag.Parse()
+	if *src == "" || *pkg == "" || *key == "" || *out == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	code, err := generate(*src, *pkg, *key)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the formatted source of src specialized to key.
+func generate(src, pkg, key string) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, src, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	f.Name.Name = pkg
+	// The package comment describes src; the generated package documents
+	// itself in a separate file.
+	for i, c := range f.Comments {
+		if c == f.Doc {
+			f.Comments = append(f.Comments[:i], f.Comments[i+1:]...)
+			break
+		}
+	}
+	f.Doc = nil
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			n.Type.TypeParams = dropKeyParam(n.Type.TypeParams)
+			if n.Recv != nil {
+				for _, field := range n.Recv.List {
+					dropKeyIndex(&field.Type)
+				}
+			}
+		case *ast.TypeSpec:
+			n.TypeParams = dropKeyParam(n.TypeParams)
+		}
+		return true
+	})
+	// Instantiations such as Tree[K, V] lose their K argument, and every
+	// remaining K becomes the key type.
+	replaceExprs(f, func(e *ast.Expr) {
+		dropKeyIndex(e)
+		if id, ok := (*e).(*ast.Ident); ok && id.Name == "K" {
+			id.Name = key
+		}
+	})
+	dropUnusedImport(f, "cmp")
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by specialize from %s with key type %s; DO NOT EDIT.\n\n", filepath.ToSlash(src), key)
+	if err := format.Node(&buf, fset, f); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// dropKeyParam removes K from a type parameter list.
+func dropKeyParam(params *ast.FieldList) *ast.FieldList {
+	if params == nil {
+		return nil
+	}
+	var kept []*ast.Field
+	for _, field := range params.List {
+		var names []*ast.Ident
+		for _, name := range field.Names {
+			if name.Name != "K" {
+				names = append(names, name)
+			}
+		}
+		if len(names) > 0 {
+			field.Names = names
+			kept = append(kept, field)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	params.List = kept
+	return params
+}
+
+// dropKeyIndex rewrites X[K, V] to X[V] in place.
+func dropKeyIndex(e *ast.Expr) {
+	idx, ok := (*e).(*ast.IndexListExpr)
+	if !ok || len(idx.Indices) != 2 {
+		return
+	}
+	if id, ok := idx.Indices[0].(*ast.Ident); ok && id.Name == "K" {
+		*e = &ast.IndexExpr{X: idx.X, Lbrack: idx.Lbrack, Index: idx.Indices[1], Rbrack: idx.Rbrack}
+	}
+}
+
+// replaceExprs calls f with a pointer to every expression in the file that
+// appears in a type or value position, children before parents.
+func replaceExprs(root ast.Node, f func(*ast.Expr)) {
+	ast.Inspect(root, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Field:
+			f(&n.Type)
+		case *ast.CompositeLit:
+			if n.Type != nil {
+				f(&n.Type)
+			}
+		case *ast.ArrayType:
+			f(&n.Elt)
+		case *ast.StarExpr:
+			f(&n.X)
+		case *ast.MapType:
+			f(&n.Key)
+			f(&n.Value)
+		case *ast.IndexExpr:
+			f(&n.Index)
+		case *ast.IndexListExpr:
+			for i := range n.Indices {
+				f(&n.Indices[i])
+			}
+		case *ast.CallExpr:
+			f(&n.Fun)
+			for i := range n.Args {
+				f(&n.Args[i])
+			}
+		case *ast.ValueSpec:
+			if n.Type != nil {
+				f(&n.Type)
+			}
+		case *ast.TypeSpec:
+			f(&n.Type)
+		}
+		return true
+	})
+}
+
+// dropUnusedImport removes the import of path if nothing refers to it.
+func dropUnusedImport(f *ast.File, path string) {
+	used := false
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok && id.Name == path {
+				used = true
+			}
+		}
+		return !used
+	})
+	if used {
+		return
+	}
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		for i, spec := range gen.Specs {
+			if spec.(*ast.ImportSpec).Path.Value == fmt.Sprintf("%q", path) {
+				gen.Specs = append(gen.Specs[:i], gen.Specs[i+1:]...)
+				break
+			}
+		}
+	}
+	for i, imp := range f.Imports {
+		if imp.Path.Value == fmt.Sprintf("%q", path) {
+			f.Imports = append(f.Imports[:i], f.Imports[i+1:]...)
+			break
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedUpToDate(t *testing.T) {
+	for _, c := range []struct{ pkg, key string }{
+		{"int64tree", "int64"},
+		{"stringtree", "string"},
+	} {
+		// The generated packages sit as deep as this one, so the source
+		// path is the same as in their go:generate directives.
+		got, err := generate("../../slab/slab.go", c.pkg, c.key)
+		require.NoError(t, err)
+		want, err := os.ReadFile(filepath.Join("../../specialized", c.pkg, c.pkg+".go"))
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got), "run go generate in specialized/%s", c.pkg)
+	}
+}
@@ -0,0 +1,315 @@
+package avltrees
+
+import "iter"
+
+// Hook holds the links of a value in an IntrusiveTree. Embed it in the
+// struct to be linked:
+//
+//	type Job struct {
+//		avlts.Hook[Job]
+//		Deadline time.Time
+//	}
+//
+// The zero value is an unlinked hook. A value can be linked into at most one
+// tree at a time through a given Hook.
+type Hook[T any] struct {
+	left, right, parent *T
+	height              int
+	owner               *intrusiveRoot[T]
+}
+
+func (h *Hook[T]) avlHook() *Hook[T] {
+	return h
+}
+
+// HookPtr is satisfied by *T when T embeds Hook[T].
+type HookPtr[T any] interface {
+	*T
+	avlHook() *Hook[T]
+}
+
+type intrusiveRoot[T any] struct {
+	root  *T
+	count int
+}
+
+// IntrusiveTree is an AVL tree of caller-owned values, in the spirit of
+// container/list. The links live in a Hook embedded in each value, so
+// inserting and removing never allocate and a value can be found and
+// removed through a pointer to it. Values are ordered by the compare
+// function given to NewIntrusive and must not change their order while
+// linked.
+type IntrusiveTree[T any, P HookPtr[T]] struct {
+	intrusiveRoot[T]
+	compare func(a, b *T) int
+}
+
+// NewIntrusive returns a new empty IntrusiveTree ordered by compare.
+func NewIntrusive[T any, P HookPtr[T]](compare func(a, b *T) int) *IntrusiveTree[T, P] {
+	return &IntrusiveTree[T, P]{compare: compare}
+}
+
+// Len returns the number of values linked into the tree.
+func (t *IntrusiveTree[T, P]) Len() int {
+	return t.count
+}
+
+// Insert links x into the tree.
+// Returns false if x or a value comparing equal to it is already in the
+// tree. It panics if x is linked into another tree.
+func (t *IntrusiveTree[T, P]) Insert(x *T) bool {
+	hx := P(x).avlHook()
+	switch hx.owner {
+	case nil:
+	case &t.intrusiveRoot:
+		return false
+	default:
+		panic("avltrees: Insert of a value linked into another IntrusiveTree")
+	}
+	var parent *T
+	link := &t.root
+	for *link != nil {
+		parent = *link
+		c := t.compare(x, parent)
+		switch {
+		case c < 0:
+			link = &P(parent).avlHook().left
+		case c > 0:
+			link = &P(parent).avlHook().right
+		default:
+			return false
+		}
+	}
+	*hx = Hook[T]{parent: parent, height: 1, owner: &t.intrusiveRoot}
+	*link = x
+	t.count++
+	t.retrace(parent)
+	return true
+}
+
+// Remove unlinks x from the tree.
+// Returns false if x is not linked into this tree.
+func (t *IntrusiveTree[T, P]) Remove(x *T) bool {
+	hx := P(x).avlHook()
+	if hx.owner != &t.intrusiveRoot {
+		return false
+	}
+	var start *T
+	switch {
+	case hx.left == nil || hx.right == nil:
+		child := hx.left
+		if child == nil {
+			child = hx.right
+		}
+		t.replaceChild(hx.parent, x, child)
+		if child != nil {
+			P(child).avlHook().parent = hx.parent
+		}
+		start = hx.parent
+	default:
+		// Move the successor s into x's position rather than swapping
+		// contents, since callers hold pointers to both.
+		s := hx.right
+		for P(s).avlHook().left != nil {
+			s = P(s).avlHook().left
+		}
+		hs := P(s).avlHook()
+		if hs.parent == x {
+			start = s
+		} else {
+			start = hs.parent
+			P(start).avlHook().left = hs.right
+			if hs.right != nil {
+				P(hs.right).avlHook().parent = start
+			}
+			hs.right = hx.right
+			P(hs.right).avlHook().parent = s
+		}
+		hs.left = hx.left
+		P(hs.left).avlHook().parent = s
+		hs.parent = hx.parent
+		hs.height = hx.height
+		t.replaceChild(hx.parent, x, s)
+	}
+	*hx = Hook[T]{}
+	t.count--
+	t.retrace(start)
+	return true
+}
+
+// Contains reports whether x is linked into this tree.
+func (t *IntrusiveTree[T, P]) Contains(x *T) bool {
+	return P(x).avlHook().owner == &t.intrusiveRoot
+}
+
+// Search returns the value comparing equal to probe.
+// probe is only passed to the compare function and need not be linked.
+func (t *IntrusiveTree[T, P]) Search(probe *T) (*T, bool) {
+	n := t.root
+	for n != nil {
+		c := t.compare(probe, n)
+		switch {
+		case c < 0:
+			n = P(n).avlHook().left
+		case c > 0:
+			n = P(n).avlHook().right
+		default:
+			return n, true
+		}
+	}
+	return nil, false
+}
+
+// Min returns the smallest value in the tree.
+func (t *IntrusiveTree[T, P]) Min() (*T, bool) {
+	if t.root == nil {
+		return nil, false
+	}
+	return t.leftmost(t.root), true
+}
+
+// Max returns the largest value in the tree.
+func (t *IntrusiveTree[T, P]) Max() (*T, bool) {
+	n := t.root
+	if n == nil {
+		return nil, false
+	}
+	for P(n).avlHook().right != nil {
+		n = P(n).avlHook().right
+	}
+	return n, true
+}
+
+// Next returns the value following x in the tree.
+func (t *IntrusiveTree[T, P]) Next(x *T) (*T, bool) {
+	h := P(x).avlHook()
+	if h.right != nil {
+		return t.leftmost(h.right), true
+	}
+	for p := h.parent; p != nil; x, p = p, P(p).avlHook().parent {
+		if P(p).avlHook().left == x {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// Prev returns the value preceding x in the tree.
+func (t *IntrusiveTree[T, P]) Prev(x *T) (*T, bool) {
+	h := P(x).avlHook()
+	if h.left != nil {
+		n := h.left
+		for P(n).avlHook().right != nil {
+			n = P(n).avlHook().right
+		}
+		return n, true
+	}
+	for p := h.parent; p != nil; x, p = p, P(p).avlHook().parent {
+		if P(p).avlHook().right == x {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// All returns an iterator over the values in ascending order.
+// The value just yielded may be removed during iteration.
+func (t *IntrusiveTree[T, P]) All() iter.Seq[*T] {
+	return func(yield func(*T) bool) {
+		n, ok := t.Min()
+		for ok {
+			next, more := t.Next(n)
+			if !yield(n) {
+				return
+			}
+			n, ok = next, more
+		}
+	}
+}
+
+func (t *IntrusiveTree[T, P]) leftmost(n *T) *T {
+	for P(n).avlHook().left != nil {
+		n = P(n).avlHook().left
+	}
+	return n
+}
+
+func (t *IntrusiveTree[T, P]) height(n *T) int {
+	if n == nil {
+		return 0
+	}
+	return P(n).avlHook().height
+}
+
+func (t *IntrusiveTree[T, P]) replaceChild(parent, old, new *T) {
+	switch {
+	case parent == nil:
+		t.root = new
+	case P(parent).avlHook().left == old:
+		P(parent).avlHook().left = new
+	default:
+		P(parent).avlHook().right = new
+	}
+}
+
+// retrace restores heights and balance from n up to the root.
+func (t *IntrusiveTree[T, P]) retrace(n *T) {
+	for n != nil {
+		n = t.rebalance(n)
+		n = P(n).avlHook().parent
+	}
+}
+
+func (t *IntrusiveTree[T, P]) rebalance(n *T) *T {
+	h := P(n).avlHook()
+	switch bf := t.height(h.left) - t.height(h.right); {
+	case bf > 1:
+		l := P(h.left).avlHook()
+		if t.height(l.left) < t.height(l.right) {
+			t.rotateLeft(h.left)
+		}
+		return t.rotateRight(n)
+	case bf < -1:
+		r := P(h.right).avlHook()
+		if t.height(r.right) < t.height(r.left) {
+			t.rotateRight(h.right)
+		}
+		return t.rotateLeft(n)
+	}
+	h.height = 1 + max(t.height(h.left), t.height(h.right))
+	return n
+}
+
+func (t *IntrusiveTree[T, P]) rotateLeft(z *T) *T {
+	hz := P(z).avlHook()
+	y := hz.right
+	hy := P(y).avlHook()
+	hz.right = hy.left
+	if hy.left != nil {
+		P(hy.left).avlHook().parent = z
+	}
+	hy.parent = hz.parent
+	t.replaceChild(hz.parent, z, y)
+	hy.left = z
+	hz.parent = y
+	hz.height = 1 + max(t.height(hz.left), t.height(hz.right))
+	hy.height = 1 + max(t.height(hy.left), t.height(hy.right))
+	return y
+}
+
+func (t *IntrusiveTree[T, P]) rotateRight(z *T) *T {
+	hz := P(z).avlHook()
+	y := hz.left
+	hy := P(y).avlHook()
+	hz.left = hy.right
+	if hy.right != nil {
+		P(hy.right).avlHook().parent = z
+	}
+	hy.parent = hz.parent
+	t.replaceChild(hz.parent, z, y)
+	hy.right = z
+	hz.parent = y
+	hz.height = 1 + max(t.height(hz.left), t.height(hz.right))
+	hy.height = 1 + max(t.height(hy.left), t.height(hy.right))
+	return y
+}
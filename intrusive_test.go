@@ -0,0 +1,187 @@
+package avltrees_test
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type job struct {
+	avlts.Hook[job]
+	deadline int
+	name     string
+}
+
+func byDeadline(a, b *job) int {
+	return cmp.Compare(a.deadline, b.deadline)
+}
+
+func intrusiveDeadlines(t *avlts.IntrusiveTree[job, *job]) []int {
+	var out []int
+	for j := range t.All() {
+		out = append(out, j.deadline)
+	}
+	return out
+}
+
+func TestIntrusiveTree(t *testing.T) {
+	tree := avlts.NewIntrusive[job](byDeadline)
+	_, ok := tree.Min()
+	assert.False(t, ok)
+	_, ok = tree.Max()
+	assert.False(t, ok)
+
+	jobs := make([]job, 10)
+	for i := range jobs {
+		jobs[i].deadline = (i * 7) % 10
+		require.True(t, tree.Insert(&jobs[i]))
+	}
+	assert.Equal(t, 10, tree.Len())
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, intrusiveDeadlines(tree))
+
+	dup := &job{deadline: 3}
+	assert.False(t, tree.Insert(dup), "equal values are rejected")
+	assert.False(t, tree.Contains(dup))
+	assert.False(t, tree.Insert(&jobs[0]), "already linked")
+
+	got, ok := tree.Search(&job{deadline: 7})
+	require.True(t, ok)
+	assert.Same(t, &jobs[1], got)
+	_, ok = tree.Search(&job{deadline: 42})
+	assert.False(t, ok)
+
+	assert.True(t, tree.Remove(&jobs[1]))
+	assert.False(t, tree.Remove(&jobs[1]), "already unlinked")
+	assert.False(t, tree.Contains(&jobs[1]))
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 8, 9}, intrusiveDeadlines(tree))
+
+	// An unlinked value can be linked again.
+	jobs[1].deadline = 11
+	assert.True(t, tree.Insert(&jobs[1]))
+	maxJob, _ := tree.Max()
+	assert.Same(t, &jobs[1], maxJob)
+
+	other := avlts.NewIntrusive[job](byDeadline)
+	assert.False(t, other.Remove(&jobs[0]), "linked into another tree")
+	assert.Panics(t, func() { other.Insert(&jobs[0]) })
+}
+
+func TestIntrusiveTreeNavigation(t *testing.T) {
+	tree := avlts.NewIntrusive[job](byDeadline)
+	jobs := []job{{deadline: 20}, {deadline: 10}, {deadline: 30}}
+	for i := range jobs {
+		tree.Insert(&jobs[i])
+	}
+
+	first, _ := tree.Min()
+	assert.Equal(t, 10, first.deadline)
+	_, ok := tree.Prev(first)
+	assert.False(t, ok)
+
+	second, ok := tree.Next(first)
+	require.True(t, ok)
+	assert.Equal(t, 20, second.deadline)
+	third, _ := tree.Next(second)
+	assert.Equal(t, 30, third.deadline)
+	_, ok = tree.Next(third)
+	assert.False(t, ok)
+
+	back, _ := tree.Prev(third)
+	assert.Same(t, second, back)
+}
+
+func TestIntrusiveTreeRemoveWhileIterating(t *testing.T) {
+	tree := avlts.NewIntrusive[job](byDeadline)
+	jobs := make([]job, 20)
+	for i := range jobs {
+		jobs[i].deadline = i
+		tree.Insert(&jobs[i])
+	}
+	for j := range tree.All() {
+		if j.deadline%2 == 1 {
+			tree.Remove(j)
+		}
+	}
+	assert.Equal(t, []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}, intrusiveDeadlines(tree))
+}
+
+func TestIntrusiveTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	tree := avlts.NewIntrusive[job](byDeadline)
+	pool := make([]job, 200)
+	for i := range pool {
+		pool[i].deadline = i
+	}
+	want := map[int]bool{}
+	for range 5000 {
+		j := &pool[r.IntN(len(pool))]
+		if r.IntN(2) == 0 {
+			assert.Equal(t, !want[j.deadline], tree.Insert(j))
+			want[j.deadline] = true
+		} else {
+			assert.Equal(t, want[j.deadline], tree.Remove(j))
+			delete(want, j.deadline)
+		}
+	}
+	var keys []int
+	for k := range want {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	assert.Equal(t, len(keys), tree.Len())
+	assert.Equal(t, keys, intrusiveDeadlines(tree))
+
+	var rev []int
+	for j, ok := tree.Max(); ok; j, ok = tree.Prev(j) {
+		rev = append(rev, j.deadline)
+	}
+	slices.Reverse(rev)
+	assert.Equal(t, keys, rev)
+}
+
+func TestIntrusiveTreeAllocs(t *testing.T) {
+	tree := avlts.NewIntrusive[job](byDeadline)
+	jobs := make([]job, 64)
+	for i := range jobs {
+		jobs[i].deadline = i
+	}
+	allocs := testing.AllocsPerRun(10, func() {
+		for i := range jobs {
+			tree.Insert(&jobs[i])
+		}
+		for i := range jobs {
+			tree.Remove(&jobs[i])
+		}
+	})
+	assert.Zero(t, allocs)
+}
+
+func ExampleNewIntrusive() {
+	type Job struct {
+		avlts.Hook[Job]
+		Deadline int
+		Name     string
+	}
+	queue := avlts.NewIntrusive[Job](func(a, b *Job) int {
+		return cmp.Compare(a.Deadline, b.Deadline)
+	})
+
+	jobs := []Job{{Deadline: 30, Name: "report"}, {Deadline: 10, Name: "backup"}, {Deadline: 20, Name: "email"}}
+	for i := range jobs {
+		queue.Insert(&jobs[i])
+	}
+	queue.Remove(&jobs[2])
+
+	for j := range queue.All() {
+		fmt.Println(j.Deadline, j.Name)
+	}
+	// Output:
+	// 10 backup
+	// 30 report
+}
@@ -0,0 +1,138 @@
+package avltrees
+
+import "cmp"
+
+// Leaderboard ranks ids by score, highest first.
+// Ids with equal scores are ranked in the order they reached that score.
+// Every operation takes O(log n), however many ids share a score.
+// Use NewLeaderboard to create one; the zero value is not usable.
+type Leaderboard[ID comparable, Score cmp.Ordered] struct {
+	placed map[ID]placing[Score]
+	tree   Tree[Score, *Tree[uint64, ID]] // score -> ids holding it, by arrival
+	seq    uint64
+}
+
+// placing locates an id in the tree: its score, and the sequence number
+// it got on reaching that score, which orders ties.
+type placing[Score cmp.Ordered] struct {
+	score Score
+	seq   uint64
+}
+
+// Standing is an id together with its score and 0-based rank.
+type Standing[ID comparable, Score cmp.Ordered] struct {
+	ID    ID
+	Score Score
+	Rank  int
+}
+
+// NewLeaderboard returns a new empty Leaderboard.
+func NewLeaderboard[ID comparable, Score cmp.Ordered]() *Leaderboard[ID, Score] {
+	lb := &Leaderboard[ID, Score]{placed: map[ID]placing[Score]{}}
+	lb.tree.aug = &augment[Score, *Tree[uint64, ID]]{weight: func(_ Score, ids *Tree[uint64, ID]) int64 { return int64(Len(ids)) }}
+	return lb
+}
+
+// Len returns the number of ids on the leaderboard.
+func (lb *Leaderboard[ID, Score]) Len() int {
+	return len(lb.placed)
+}
+
+// SetScore sets the score of id, adding it to the leaderboard if needed.
+// Setting an id to the score it already holds keeps its position among ties.
+func (lb *Leaderboard[ID, Score]) SetScore(id ID, score Score) {
+	if old, ok := lb.placed[id]; ok {
+		if old.score == score {
+			return
+		}
+		lb.unlink(old)
+	}
+	lb.seq++
+	lb.placed[id] = placing[Score]{score, lb.seq}
+	if n, ok := Search(&lb.tree, score); ok {
+		Insert(n.value, lb.seq, id)
+		refreshUp(&lb.tree, n)
+	} else {
+		ids := New[uint64, ID]()
+		Insert(ids, lb.seq, id)
+		Insert(&lb.tree, score, ids)
+	}
+}
+
+// Score returns the score of id.
+func (lb *Leaderboard[ID, Score]) Score(id ID) (Score, bool) {
+	p, ok := lb.placed[id]
+	return p.score, ok
+}
+
+// Remove removes id from the leaderboard.
+// Returns false if id is not on the leaderboard.
+func (lb *Leaderboard[ID, Score]) Remove(id ID) bool {
+	p, ok := lb.placed[id]
+	if !ok {
+		return false
+	}
+	delete(lb.placed, id)
+	lb.unlink(p)
+	return true
+}
+
+// RankOf returns the 0-based rank of id, where rank 0 has the highest score.
+// Returns false if id is not on the leaderboard.
+func (lb *Leaderboard[ID, Score]) RankOf(id ID) (int, bool) {
+	p, ok := lb.placed[id]
+	if !ok {
+		return 0, false
+	}
+	n, _ := Search(&lb.tree, p.score)
+	above := weightSum(lb.tree.Root) - weightBefore(&lb.tree, p.score) - int64(Len(n.value))
+	return int(above) + Rank(n.value, p.seq), true
+}
+
+// Top returns the standings of the n highest-ranked ids.
+func (lb *Leaderboard[ID, Score]) Top(n int) []Standing[ID, Score] {
+	return lb.fromRank(0, n)
+}
+
+// Around returns the standings of id and up to n ids ranked directly above
+// and below it. Returns false if id is not on the leaderboard.
+func (lb *Leaderboard[ID, Score]) Around(id ID, n int) ([]Standing[ID, Score], bool) {
+	rank, ok := lb.RankOf(id)
+	if !ok {
+		return nil, false
+	}
+	first := max(rank-n, 0)
+	return lb.fromRank(first, rank+n+1-first), true
+}
+
+// fromRank returns up to count standings starting at the given rank.
+func (lb *Leaderboard[ID, Score]) fromRank(rank, count int) []Standing[ID, Score] {
+	total := weightSum(lb.tree.Root)
+	n, offset := seekWeight(&lb.tree, total-1-int64(rank))
+	if n == nil || count <= 0 {
+		return nil
+	}
+	out := make([]Standing[ID, Score], 0, min(count, len(lb.placed)-rank))
+	id, ok := Kth(n.value, Len(n.value)-1-int(offset))
+	for len(out) < count {
+		for ; ok && len(out) < count; id, ok = Successor(id) {
+			out = append(out, Standing[ID, Score]{id.value, n.key, rank})
+			rank++
+		}
+		if n, ok = Predecessor(n); !ok {
+			break
+		}
+		id, ok = Min(n.value)
+	}
+	return out
+}
+
+func (lb *Leaderboard[ID, Score]) unlink(p placing[Score]) {
+	n, _ := Search(&lb.tree, p.score)
+	Delete(n.value, p.seq)
+	if Len(n.value) == 0 {
+		Delete(&lb.tree, p.score)
+	} else {
+		refreshUp(&lb.tree, n)
+	}
+}
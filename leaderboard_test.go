@@ -0,0 +1,176 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type standing = avlts.Standing[string, int]
+
+func TestLeaderboardSetScore(t *testing.T) {
+	lb := avlts.NewLeaderboard[string, int]()
+	lb.SetScore("ann", 50)
+	lb.SetScore("bob", 80)
+	lb.SetScore("cat", 50)
+	lb.SetScore("dan", 90)
+	lb.SetScore("eve", 10)
+	assert.Equal(t, 5, lb.Len())
+
+	lb.SetScore("eve", 100)
+	score, ok := lb.Score("eve")
+	require.True(t, ok)
+	assert.Equal(t, 100, score)
+	assert.Equal(t, 5, lb.Len())
+	rank, _ := lb.RankOf("eve")
+	assert.Equal(t, 0, rank)
+
+	lb.SetScore("ann", 50)
+	rank, _ = lb.RankOf("ann")
+	assert.Equal(t, 3, rank, "resetting the same score keeps the tie order")
+}
+
+func TestLeaderboardRemove(t *testing.T) {
+	lb := avlts.NewLeaderboard[string, int]()
+	lb.SetScore("ann", 50)
+	lb.SetScore("bob", 80)
+	lb.SetScore("cat", 50)
+	lb.SetScore("dan", 90)
+	lb.SetScore("eve", 10)
+	assert.True(t, lb.Remove("ann"))
+	assert.False(t, lb.Remove("ann"))
+	_, ok := lb.Score("ann")
+	assert.False(t, ok)
+	rank, _ := lb.RankOf("cat")
+	assert.Equal(t, 2, rank)
+}
+
+func TestLeaderboardRankOf(t *testing.T) {
+	lb := avlts.NewLeaderboard[string, int]()
+	lb.SetScore("ann", 50)
+	lb.SetScore("bob", 80)
+	lb.SetScore("cat", 50)
+	lb.SetScore("dan", 90)
+	lb.SetScore("eve", 10)
+	for id, want := range map[string]int{"dan": 0, "bob": 1, "ann": 2, "cat": 3, "eve": 4} {
+		rank, ok := lb.RankOf(id)
+		require.True(t, ok)
+		assert.Equal(t, want, rank, id)
+	}
+	_, ok := lb.RankOf("zed")
+	assert.False(t, ok)
+}
+
+func TestLeaderboardTop(t *testing.T) {
+	lb := avlts.NewLeaderboard[string, int]()
+	lb.SetScore("ann", 50)
+	lb.SetScore("bob", 80)
+	lb.SetScore("cat", 50)
+	lb.SetScore("dan", 90)
+	lb.SetScore("eve", 10)
+	assert.Equal(t, []standing{{"dan", 90, 0}, {"bob", 80, 1}, {"ann", 50, 2}}, lb.Top(3))
+	assert.Len(t, lb.Top(10), 5)
+	assert.Empty(t, lb.Top(0))
+	assert.Empty(t, avlts.NewLeaderboard[string, int]().Top(3))
+}
+
+func TestLeaderboardAround(t *testing.T) {
+	lb := avlts.NewLeaderboard[string, int]()
+	lb.SetScore("ann", 50)
+	lb.SetScore("bob", 80)
+	lb.SetScore("cat", 50)
+	lb.SetScore("dan", 90)
+	lb.SetScore("eve", 10)
+	got, ok := lb.Around("ann", 1)
+	require.True(t, ok)
+	assert.Equal(t, []standing{{"bob", 80, 1}, {"ann", 50, 2}, {"cat", 50, 3}}, got)
+
+	got, _ = lb.Around("dan", 2)
+	assert.Equal(t, []standing{{"dan", 90, 0}, {"bob", 80, 1}, {"ann", 50, 2}}, got)
+
+	got, _ = lb.Around("eve", 1)
+	assert.Equal(t, []standing{{"cat", 50, 3}, {"eve", 10, 4}}, got)
+
+	_, ok = lb.Around("zed", 1)
+	assert.False(t, ok)
+}
+
+func TestLeaderboardTies(t *testing.T) {
+	lb := avlts.NewLeaderboard[int, int]()
+	for id := range 1000 {
+		lb.SetScore(id, 7)
+	}
+	lb.SetScore(1000, 8)
+	for id := 0; id < 1000; id += 2 {
+		require.True(t, lb.Remove(id))
+	}
+	rank, ok := lb.RankOf(501)
+	require.True(t, ok)
+	assert.Equal(t, 251, rank)
+
+	got, _ := lb.Around(501, 1)
+	assert.Equal(t, []avlts.Standing[int, int]{{ID: 499, Score: 7, Rank: 250}, {ID: 501, Score: 7, Rank: 251}, {ID: 503, Score: 7, Rank: 252}}, got)
+	assert.Equal(t, []avlts.Standing[int, int]{{ID: 1000, Score: 8, Rank: 0}, {ID: 1, Score: 7, Rank: 1}}, lb.Top(2))
+}
+
+func TestLeaderboardRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	lb := avlts.NewLeaderboard[int, int]()
+	scores := map[int]int{}
+	for range 1000 {
+		id := r.Intn(200)
+		if r.Intn(4) == 0 {
+			lb.Remove(id)
+			delete(scores, id)
+			continue
+		}
+		s := r.Intn(30)
+		lb.SetScore(id, s)
+		scores[id] = s
+	}
+	top := lb.Top(lb.Len())
+	require.Len(t, top, len(scores))
+	assert.True(t, slices.IsSortedFunc(top, func(a, b avlts.Standing[int, int]) int { return b.Score - a.Score }))
+	for i, s := range top {
+		assert.Equal(t, scores[s.ID], s.Score)
+		rank, _ := lb.RankOf(s.ID)
+		assert.Equal(t, i, rank)
+		assert.Equal(t, i, s.Rank)
+	}
+}
+
+func ExampleLeaderboard() {
+	lb := avlts.NewLeaderboard[string, int]()
+	lb.SetScore("alice", 120)
+	lb.SetScore("bob", 95)
+	lb.SetScore("carol", 130)
+	lb.SetScore("bob", 140)
+
+	for _, s := range lb.Top(3) {
+		fmt.Println(s.Rank, s.ID, s.Score)
+	}
+	// Output:
+	// 0 bob 140
+	// 1 carol 130
+	// 2 alice 120
+}
+
+func ExampleLeaderboard_Around() {
+	lb := avlts.NewLeaderboard[string, int]()
+	for i, id := range []string{"a", "b", "c", "d", "e"} {
+		lb.SetScore(id, i*10)
+	}
+	around, _ := lb.Around("c", 1)
+	for _, s := range around {
+		fmt.Println(s.Rank, s.ID)
+	}
+	// Output:
+	// 1 d
+	// 2 c
+	// 3 b
+}
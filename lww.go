@@ -0,0 +1,153 @@
+package avltrees
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Timestamp orders writes to an LWWMap. Writes are ordered by Time, and
+// writes with equal Time by Actor, so every replica picks the same winner.
+// An actor must not issue two different writes with the same Time.
+type Timestamp struct {
+	Time  int64
+	Actor string
+}
+
+// Compare returns -1, 0, or +1 depending on whether ts is ordered before,
+// equal to, or after other.
+func (ts Timestamp) Compare(other Timestamp) int {
+	if c := cmp.Compare(ts.Time, other.Time); c != 0 {
+		return c
+	}
+	return cmp.Compare(ts.Actor, other.Actor)
+}
+
+// LWWMap is a last-writer-wins map, a state-based CRDT built on an AVL tree.
+// Every entry remembers the timestamp of the write that produced it, and
+// deletes leave tombstones, so replicas that exchange state with MergeCRDT
+// converge to the same contents regardless of the order in which they see
+// each other's writes. The zero value is an empty map ready to use.
+type LWWMap[K cmp.Ordered, V any] struct {
+	tree Tree[K, lwwEntry[V]]
+	live int
+}
+
+type lwwEntry[V any] struct {
+	value   V
+	stamp   Timestamp
+	deleted bool
+}
+
+// wins reports whether e takes precedence over other.
+// On equal timestamps a tombstone wins, so the outcome does not depend on
+// which replica merges first.
+func (e lwwEntry[V]) wins(other lwwEntry[V]) bool {
+	if c := e.stamp.Compare(other.stamp); c != 0 {
+		return c > 0
+	}
+	return e.deleted && !other.deleted
+}
+
+// Set stores value under key if the write at stamp is newer than the one
+// currently recorded for key. Returns true if the write was applied.
+func (m *LWWMap[K, V]) Set(key K, value V, stamp Timestamp) bool {
+	return m.apply(key, lwwEntry[V]{value: value, stamp: stamp})
+}
+
+// Delete records the deletion of key at stamp if it is newer than the write
+// currently recorded for key. Returns true if the delete was applied.
+func (m *LWWMap[K, V]) Delete(key K, stamp Timestamp) bool {
+	return m.apply(key, lwwEntry[V]{stamp: stamp, deleted: true})
+}
+
+// Get returns the value of key, if it is present and not deleted.
+func (m *LWWMap[K, V]) Get(key K) (V, bool) {
+	n, ok := Search(&m.tree, key)
+	if !ok || n.value.deleted {
+		var zero V
+		return zero, false
+	}
+	return n.value.value, true
+}
+
+// Stamp returns the timestamp of the last write or delete recorded for key.
+func (m *LWWMap[K, V]) Stamp(key K) (Timestamp, bool) {
+	n, ok := Search(&m.tree, key)
+	if !ok {
+		return Timestamp{}, false
+	}
+	return n.value.stamp, true
+}
+
+// Len returns the number of keys that are present and not deleted.
+func (m *LWWMap[K, V]) Len() int {
+	return m.live
+}
+
+// All returns an iterator over the present keys and their values in key order.
+func (m *LWWMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := range InOrder(&m.tree) {
+			if !n.value.deleted && !yield(n.key, n.value.value) {
+				return
+			}
+		}
+	}
+}
+
+// PurgeTombstones drops the tombstones of deletes made before the given
+// time and returns how many were dropped. It is only safe once every
+// replica has seen those deletes; otherwise a merge may bring the deleted
+// entries back.
+func (m *LWWMap[K, V]) PurgeTombstones(before int64) int {
+	var keys []K
+	for n := range InOrder(&m.tree) {
+		if n.value.deleted && n.value.stamp.Time < before {
+			keys = append(keys, n.key)
+		}
+	}
+	for _, key := range keys {
+		Delete(&m.tree, key)
+	}
+	return len(keys)
+}
+
+func (m *LWWMap[K, V]) apply(key K, e lwwEntry[V]) bool {
+	n, ok := Search(&m.tree, key)
+	if ok && !e.wins(n.value) {
+		return false
+	}
+	if ok && !n.value.deleted {
+		m.live--
+	}
+	if !e.deleted {
+		m.live++
+	}
+	Insert(&m.tree, key, e)
+	return true
+}
+
+// MergeCRDT returns the join of two replicas: for every key, the write with
+// the newest timestamp on either side, tombstones included. The result does
+// not depend on the order of the arguments, and merging is idempotent.
+// a and b are left unchanged.
+func MergeCRDT[K cmp.Ordered, V any](a, b *LWWMap[K, V]) *LWWMap[K, V] {
+	m := &LWWMap[K, V]{}
+	var nodes []*Node[K, lwwEntry[V]]
+	x, y := cursorAt(&a.tree), cursorAt(&b.tree)
+	for x.n != nil || y.n != nil {
+		key := minCursorKey(x, y)
+		e, inA := x.take(key)
+		f, inB := y.take(key)
+		if !inA || (inB && f.wins(e)) {
+			e = f
+		}
+		if !e.deleted {
+			m.live++
+		}
+		nodes = append(nodes, &Node[K, lwwEntry[V]]{key: key, value: e})
+	}
+	m.tree.Root = buildFromNodes(&m.tree, nodes, nil)
+	m.tree.count = len(nodes)
+	return m
+}
@@ -0,0 +1,124 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"maps"
+	"math/rand"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ts(time int64, actor string) avlts.Timestamp {
+	return avlts.Timestamp{Time: time, Actor: actor}
+}
+
+func TestTimestampCompare(t *testing.T) {
+	assert.Equal(t, -1, ts(1, "b").Compare(ts(2, "a")))
+	assert.Equal(t, 1, ts(2, "b").Compare(ts(2, "a")))
+	assert.Equal(t, 0, ts(2, "a").Compare(ts(2, "a")))
+}
+
+func TestLWWMapSet(t *testing.T) {
+	var m avlts.LWWMap[string, int]
+	assert.True(t, m.Set("k", 1, ts(10, "a")))
+	assert.False(t, m.Set("k", 2, ts(5, "b")), "older writes lose")
+	assert.True(t, m.Set("k", 3, ts(10, "b")), "actor breaks ties")
+
+	v, ok := m.Get("k")
+	require.True(t, ok)
+	assert.Equal(t, 3, v)
+	stamp, _ := m.Stamp("k")
+	assert.Equal(t, ts(10, "b"), stamp)
+	assert.Equal(t, 1, m.Len())
+}
+
+func TestLWWMapDelete(t *testing.T) {
+	var m avlts.LWWMap[string, int]
+	m.Set("k", 1, ts(10, "a"))
+	assert.False(t, m.Delete("k", ts(9, "a")))
+	assert.True(t, m.Delete("k", ts(11, "a")))
+	_, ok := m.Get("k")
+	assert.False(t, ok)
+	assert.Equal(t, 0, m.Len())
+
+	assert.False(t, m.Set("k", 2, ts(11, "a")), "tombstones win ties")
+	assert.True(t, m.Set("k", 2, ts(12, "a")))
+	assert.Equal(t, 1, m.Len())
+
+	assert.True(t, m.Delete("never-set", ts(1, "a")))
+	assert.Equal(t, 1, m.Len())
+	_, ok = m.Stamp("never-set")
+	assert.True(t, ok)
+}
+
+func TestLWWMapPurgeTombstones(t *testing.T) {
+	var m avlts.LWWMap[int, int]
+	m.Set(1, 1, ts(1, "a"))
+	m.Delete(2, ts(5, "a"))
+	m.Delete(3, ts(20, "a"))
+	assert.Equal(t, 1, m.PurgeTombstones(10))
+	_, ok := m.Stamp(2)
+	assert.False(t, ok)
+	_, ok = m.Stamp(3)
+	assert.True(t, ok)
+	assert.Equal(t, 1, m.Len())
+}
+
+func TestMergeCRDT(t *testing.T) {
+	var a, b avlts.LWWMap[string, string]
+	a.Set("x", "a1", ts(1, "a"))
+	a.Set("y", "a2", ts(5, "a"))
+	b.Set("x", "b1", ts(2, "b"))
+	b.Set("y", "b2", ts(3, "b"))
+	b.Set("z", "b3", ts(4, "b"))
+	a.Delete("z", ts(6, "a"))
+
+	ab := avlts.MergeCRDT(&a, &b)
+	ba := avlts.MergeCRDT(&b, &a)
+	assert.Equal(t, map[string]string{"x": "b1", "y": "a2"}, maps.Collect(ab.All()))
+	assert.Equal(t, maps.Collect(ab.All()), maps.Collect(ba.All()))
+	assert.Equal(t, 2, ab.Len())
+
+	again := avlts.MergeCRDT(ab, &b)
+	assert.Equal(t, maps.Collect(ab.All()), maps.Collect(again.All()), "merging is idempotent")
+	assert.Equal(t, 2, a.Len(), "inputs are unchanged")
+}
+
+func TestMergeCRDTConverges(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	replicas := make([]*avlts.LWWMap[int, int], 3)
+	for i := range replicas {
+		replicas[i] = &avlts.LWWMap[int, int]{}
+	}
+	for clock := range int64(500) {
+		i := r.Intn(len(replicas))
+		actor := fmt.Sprint(i)
+		if r.Intn(4) == 0 {
+			replicas[i].Delete(r.Intn(50), ts(clock, actor))
+		} else {
+			replicas[i].Set(r.Intn(50), int(clock), ts(clock, actor))
+		}
+	}
+	x := avlts.MergeCRDT(avlts.MergeCRDT(replicas[0], replicas[1]), replicas[2])
+	y := avlts.MergeCRDT(replicas[2], avlts.MergeCRDT(replicas[1], replicas[0]))
+	assert.Equal(t, maps.Collect(x.All()), maps.Collect(y.All()))
+	assert.Equal(t, x.Len(), len(maps.Collect(x.All())))
+}
+
+func ExampleMergeCRDT() {
+	var laptop, phone avlts.LWWMap[string, string]
+	laptop.Set("theme", "dark", avlts.Timestamp{Time: 100, Actor: "laptop"})
+	phone.Set("theme", "light", avlts.Timestamp{Time: 120, Actor: "phone"})
+	phone.Set("font", "serif", avlts.Timestamp{Time: 130, Actor: "phone"})
+	laptop.Delete("font", avlts.Timestamp{Time: 140, Actor: "laptop"})
+
+	merged := avlts.MergeCRDT(&laptop, &phone)
+	for k, v := range merged.All() {
+		fmt.Println(k, v)
+	}
+	// Output:
+	// theme light
+}
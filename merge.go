@@ -0,0 +1,146 @@
+package avltrees
+
+import (
+	"cmp"
+	"container/heap"
+	"iter"
+)
+
+// Merge3 reconciles two trees that diverged from a common base and returns
+// the merged result as a new tree, leaving the inputs unchanged.
+//
+// For each key, a change made on only one side since base is taken as is,
+// and identical changes on both sides are taken once. When both sides
+// changed a key differently, resolve decides: it returns the merged value
+// and true to keep the key, or false to delete it. A side that does not
+// hold the key passes the zero value; use Search on the trees when that
+// needs to be told apart from a stored zero value.
+//
+// The result has the order-statistics setting and aggregates of ours but
+// no capacity bound, hooks or metrics.
+func Merge3[K cmp.Ordered, V comparable](base, ours, theirs *Tree[K, V], resolve func(key K, base, ours, theirs V) (V, bool)) *Tree[K, V] {
+	return Merge3Func(base, ours, theirs, func(a, b V) bool { return a == b }, resolve)
+}
+
+// Merge3Func is like Merge3 but uses eq to decide whether two values are
+// equal, so it also merges trees of slices, maps, or other values that are
+// not comparable.
+func Merge3Func[K cmp.Ordered, V any](base, ours, theirs *Tree[K, V], eq func(a, b V) bool, resolve func(key K, base, ours, theirs V) (V, bool)) *Tree[K, V] {
+	t := newTreeLike(ours)
+	var nodes []*Node[K, V]
+	b, o, h := cursorAt(base), cursorAt(ours), cursorAt(theirs)
+	for b.n != nil || o.n != nil || h.n != nil {
+		key := minCursorKey(b, o, h)
+		bv, bin := b.take(key)
+		ov, oin := o.take(key)
+		hv, hin := h.take(key)
+
+		var v V
+		var keep bool
+		switch {
+		case oin == hin && (!oin || eq(ov, hv)):
+			v, keep = ov, oin
+		case oin == bin && (!oin || eq(ov, bv)):
+			v, keep = hv, hin
+		case hin == bin && (!hin || eq(hv, bv)):
+			v, keep = ov, oin
+		default:
+			v, keep = resolve(key, bv, ov, hv)
+		}
+		if keep {
+			n := allocNode(t)
+			n.key, n.value = key, v
+			nodes = append(nodes, n)
+		}
+	}
+	t.Root = buildFromNodes(t, nodes, nil)
+	t.count = len(nodes)
+	return t
+}
+
+// MergedIter returns an iterator over the entries of all the trees in
+// ascending key order, merging their in-order streams. A key held by
+// several trees is yielded once for each, in the order the trees are
+// given. Each step costs O(log k) for k trees. The trees must not be
+// modified during iteration.
+func MergedIter[K cmp.Ordered, V any](trees ...*Tree[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		h := make(mergeHeap[K, V], 0, len(trees))
+		for i, t := range trees {
+			if n, ok := Min(t); ok {
+				h = append(h, mergeHead[K, V]{n, i})
+			}
+		}
+		heap.Init(&h)
+		for len(h) > 0 {
+			top := &h[0]
+			if !yield(top.n.key, top.n.value) {
+				return
+			}
+			if next, ok := Successor(top.n); ok {
+				top.n = next
+				heap.Fix(&h, 0)
+			} else {
+				heap.Pop(&h)
+			}
+		}
+	}
+}
+
+// mergeHead is the next node of one tree in a MergedIter.
+type mergeHead[K cmp.Ordered, V any] struct {
+	n    *Node[K, V]
+	tree int
+}
+
+// mergeHeap orders heads by key, then by tree position.
+type mergeHeap[K cmp.Ordered, V any] []mergeHead[K, V]
+
+func (h mergeHeap[K, V]) Len() int { return len(h) }
+func (h mergeHeap[K, V]) Less(i, j int) bool {
+	if h[i].n.key != h[j].n.key {
+		return h[i].n.key < h[j].n.key
+	}
+	return h[i].tree < h[j].tree
+}
+func (h mergeHeap[K, V]) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *mergeHeap[K, V]) Push(x any)   { *h = append(*h, x.(mergeHead[K, V])) }
+func (h *mergeHeap[K, V]) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// cursor walks the nodes of a tree in key order; n is nil once exhausted.
+type cursor[K cmp.Ordered, V any] struct {
+	n *Node[K, V]
+}
+
+func cursorAt[K cmp.Ordered, V any](t *Tree[K, V]) *cursor[K, V] {
+	n, _ := Min(t)
+	return &cursor[K, V]{n}
+}
+
+// take returns the value under the cursor and advances past it if the
+// cursor is positioned at key.
+func (c *cursor[K, V]) take(key K) (V, bool) {
+	var v V
+	if c.n == nil || c.n.key != key {
+		return v, false
+	}
+	v = c.n.value
+	c.n, _ = Successor(c.n)
+	return v, true
+}
+
+func minCursorKey[K cmp.Ordered, V any](cs ...*cursor[K, V]) K {
+	var key K
+	first := true
+	for _, c := range cs {
+		if c.n != nil && (first || c.n.key < key) {
+			key, first = c.n.key, false
+		}
+	}
+	return key
+}
@@ -0,0 +1,172 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func treeItems(tree *avlts.Tree[string, string]) []strItem {
+	var items []strItem
+	for n := range avlts.InOrder(tree) {
+		items = append(items, strItem{n.Key(), n.Value()})
+	}
+	return items
+}
+
+func TestMerge3(t *testing.T) {
+	base := treeOf([]string{"a", "b", "c", "d", "e"}, []string{"1", "2", "3", "4", "5"})
+	ours := treeOf([]string{"a", "b", "c", "e", "x"}, []string{"1", "20", "3", "50", "ours"})
+	theirs := treeOf([]string{"a", "b", "c", "e", "x", "y"}, []string{"10", "2", "3", "55", "theirs", "9"})
+
+	var conflicts []string
+	merged := avlts.Merge3(base, ours, theirs, func(k, b, o, h string) (string, bool) {
+		conflicts = append(conflicts, k)
+		return o + "+" + h, true
+	})
+	assert.Equal(t, []strItem{
+		{"a", "10"},
+		{"b", "20"},
+		{"c", "3"},
+		{"e", "50+55"},
+		{"x", "ours+theirs"},
+		{"y", "9"},
+	}, treeItems(merged))
+	assert.Equal(t, []string{"e", "x"}, conflicts)
+	assert.Equal(t, 6, avlts.Len(merged))
+	assertBalanced(t, merged)
+	assert.Equal(t, 5, avlts.Len(base), "inputs are unchanged")
+}
+
+func TestMerge3DeleteConflict(t *testing.T) {
+	base := treeOf([]string{"k"}, []string{"1"})
+	ours := avlts.New[string, string]()
+	theirs := treeOf([]string{"k"}, []string{"2"})
+
+	merged := avlts.Merge3(base, ours, theirs, func(k, b, o, h string) (string, bool) {
+		assert.Equal(t, "", o)
+		return "", false
+	})
+	assert.Empty(t, treeItems(merged))
+
+	merged = avlts.Merge3(base, ours, avlts.New[string, string](), nil)
+	assert.Empty(t, treeItems(merged), "deleted on both sides")
+}
+
+func ExampleMerge3() {
+	base := treeOf([]string{"title", "body"}, []string{"Draft", "Hello"})
+	alice := treeOf([]string{"title", "body"}, []string{"Final", "Hello"})
+	bob := treeOf([]string{"title", "body", "tags"}, []string{"Draft", "Hello, world", "go"})
+
+	merged := avlts.Merge3(base, alice, bob, func(k, b, o, t string) (string, bool) {
+		return o, true
+	})
+	for n := range avlts.InOrder(merged) {
+		fmt.Println(n.Key(), "=", n.Value())
+	}
+	// Output:
+	// body = Hello, world
+	// tags = go
+	// title = Final
+}
+
+func TestMerge3Func(t *testing.T) {
+	tags := func(kv ...any) *avlts.Tree[string, []string] {
+		tree := avlts.New[string, []string]()
+		for i := 0; i < len(kv); i += 2 {
+			avlts.Insert(tree, kv[i].(string), kv[i+1].([]string))
+		}
+		return tree
+	}
+	base := tags("a", []string{"x"}, "b", []string{"y"})
+	ours := tags("a", []string{"x", "z"}, "b", []string{"y"})
+	theirs := tags("a", []string{"x"}, "b", []string{"w"}, "c", []string{"v"})
+
+	merged := avlts.Merge3Func(base, ours, theirs, slices.Equal[[]string], func(k string, b, o, h []string) ([]string, bool) {
+		t.Errorf("unexpected conflict on %q", k)
+		return nil, false
+	})
+	var got []string
+	for n := range avlts.InOrder(merged) {
+		got = append(got, fmt.Sprint(n.Key(), n.Value()))
+	}
+	assert.Equal(t, []string{"a[x z]", "b[w]", "c[v]"}, got)
+}
+
+func TestMerge3KeepsAggregates(t *testing.T) {
+	base := avlts.New[int, int]()
+	ours := avlts.New[int, int](avlts.WithPrefixSums[int]())
+	theirs := avlts.New[int, int]()
+	for i := range 5 {
+		avlts.Insert(ours, i, i)
+		avlts.Insert(theirs, i+5, i+5)
+	}
+	merged := avlts.Merge3(base, ours, theirs, nil)
+	require.NoError(t, avlts.Validate(merged))
+	sum, ok := avlts.PrefixSum(merged, 100)
+	assert.True(t, ok)
+	assert.Equal(t, 45, sum)
+}
+
+func ExampleMerge3Func() {
+	base := avlts.New[string, []string]()
+	avlts.Insert(base, "go", []string{"lang"})
+	ours := avlts.CloneWith(base, slices.Clone)
+	theirs := avlts.CloneWith(base, slices.Clone)
+	avlts.Insert(ours, "go", []string{"lang", "fast"})
+	avlts.Insert(theirs, "go", []string{"lang", "simple"})
+
+	merged := avlts.Merge3Func(base, ours, theirs, slices.Equal[[]string], func(k string, b, o, t []string) ([]string, bool) {
+		return append(o, t[len(b):]...), true
+	})
+	v, _ := avlts.Get(merged, "go")
+	fmt.Println(v)
+	// Output:
+	// [lang fast simple]
+}
+
+func TestMergedIter(t *testing.T) {
+	a := treeOf([]string{"a", "d", "g"}, []string{"a1", "a4", "a7"})
+	b := treeOf([]string{"b", "d"}, []string{"b2", "b4"})
+	c := treeOf([]string{"c", "z"}, []string{"c3", "c26"})
+	empty := avlts.New[string, string]()
+
+	var got []strItem
+	for k, v := range avlts.MergedIter(a, empty, b, c) {
+		got = append(got, strItem{k, v})
+	}
+	assert.Equal(t, []strItem{
+		{"a", "a1"}, {"b", "b2"}, {"c", "c3"}, {"d", "a4"}, {"d", "b4"}, {"g", "a7"}, {"z", "c26"},
+	}, got, "duplicates follow the order of the trees")
+
+	var first []string
+	for k := range avlts.MergedIter(c, b, a) {
+		first = append(first, k)
+		if len(first) == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, first)
+
+	for range avlts.MergedIter[string, string]() {
+		t.Fatal("no trees yields nothing")
+	}
+}
+
+func ExampleMergedIter() {
+	shardA := treeOf([]string{"apple", "cherry"}, []string{"A", "A"})
+	shardB := treeOf([]string{"banana", "date"}, []string{"B", "B"})
+
+	for k, shard := range avlts.MergedIter(shardA, shardB) {
+		fmt.Println(k, shard)
+	}
+	// Output:
+	// apple A
+	// banana B
+	// cherry A
+	// date B
+}
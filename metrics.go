@@ -0,0 +1,116 @@
+package avltrees
+
+import (
+	"cmp"
+	"encoding/json"
+	"expvar"
+	"sync/atomic"
+)
+
+// Metrics counts the operations performed on the AVL trees it is attached
+// to with WithMetrics. The counters are updated atomically, so a Metrics may
+// be shared by several trees and read from any goroutine while they change.
+//
+// Metrics implements expvar.Var and can be published directly:
+//
+//	var m avltrees.Metrics
+//	expvar.Publish("orders_index", &m)
+//
+// Other monitoring systems can poll Snapshot instead.
+type Metrics struct {
+	inserts   atomic.Int64
+	deletes   atomic.Int64
+	searches  atomic.Int64
+	rotations atomic.Int64
+	maxHeight atomic.Int64
+}
+
+var _ expvar.Var = (*Metrics)(nil)
+
+// MetricsSnapshot holds the values of a Metrics at one point in time.
+type MetricsSnapshot struct {
+	// Inserts is the number of keys added, including by batch inserts and
+	// BuildParallel. Overwriting the value of an existing key is not
+	// counted.
+	Inserts int64
+	// Deletes is the number of keys removed, including by Clear,
+	// DeleteBefore, and eviction from bounded trees.
+	Deletes int64
+	// Searches is the number of lookups by key through Search, Get, and
+	// Contains.
+	Searches int64
+	// Rotations is the number of single rotations made to rebalance the
+	// trees; a double rotation counts as two.
+	Rotations int64
+	// MaxHeight is the greatest height any of the trees has reached after
+	// an insertion.
+	MaxHeight int64
+}
+
+// WithMetrics makes the tree count its operations in m. Trees derived
+// from it, such as by CloneWith or Union, do not inherit the option.
+func WithMetrics(m *Metrics) Option {
+	return func(o *options) {
+		o.metrics = m
+	}
+}
+
+// Snapshot returns the current values of the counters. Each counter is
+// read atomically, but the snapshot as a whole is not.
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	return MetricsSnapshot{
+		Inserts:   m.inserts.Load(),
+		Deletes:   m.deletes.Load(),
+		Searches:  m.searches.Load(),
+		Rotations: m.rotations.Load(),
+		MaxHeight: m.maxHeight.Load(),
+	}
+}
+
+// Reset sets all counters to zero.
+func (m *Metrics) Reset() {
+	m.inserts.Store(0)
+	m.deletes.Store(0)
+	m.searches.Store(0)
+	m.rotations.Store(0)
+	m.maxHeight.Store(0)
+}
+
+// String returns the counters as a JSON object, as expvar.Var requires.
+func (m *Metrics) String() string {
+	b, _ := json.Marshal(m.Snapshot())
+	return string(b)
+}
+
+func recordInserts[K cmp.Ordered, V any](t *Tree[K, V], n int) {
+	m := t.metrics
+	if m == nil || n == 0 {
+		return
+	}
+	m.inserts.Add(int64(n))
+	h := int64(height(t.Root))
+	for {
+		old := m.maxHeight.Load()
+		if h <= old || m.maxHeight.CompareAndSwap(old, h) {
+			return
+		}
+	}
+}
+
+func recordDeletes[K cmp.Ordered, V any](t *Tree[K, V], n int) {
+	if t.metrics != nil && n > 0 {
+		t.metrics.deletes.Add(int64(n))
+	}
+}
+
+func recordSearch[K cmp.Ordered, V any](t *Tree[K, V]) {
+	if t.metrics != nil {
+		t.metrics.searches.Add(1)
+	}
+}
+
+func recordRotation[K cmp.Ordered, V any](t *Tree[K, V]) {
+	if t.metrics != nil {
+		t.metrics.rotations.Add(1)
+	}
+}
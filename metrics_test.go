@@ -0,0 +1,124 @@
+package avltrees_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMetrics(t *testing.T) {
+	var m avlts.Metrics
+	tree := avlts.NewBounded[int, string](10, avlts.EvictMin, avlts.WithMetrics(&m))
+
+	for i := range 7 {
+		avlts.Insert(tree, i, "")
+	}
+	avlts.Insert(tree, 3, "x")
+	got := m.Snapshot()
+	assert.Equal(t, int64(7), got.Inserts)
+	assert.Equal(t, int64(0), got.Deletes)
+	assert.Equal(t, int64(4), got.Rotations)
+	assert.Equal(t, int64(3), got.MaxHeight)
+
+	avlts.Get(tree, 3)
+	avlts.Contains(tree, 42)
+	avlts.Search(tree, 5)
+	assert.Equal(t, int64(3), m.Snapshot().Searches)
+
+	avlts.InsertBatch(tree, []avlts.Item[int, string]{{Key: 6, Value: ""}, {Key: 7, Value: ""}, {Key: 8, Value: ""}})
+	avlts.Insert(tree, 9, "")
+	avlts.Insert(tree, 10, "")
+	assert.Equal(t, int64(11), m.Snapshot().Inserts)
+	assert.Equal(t, int64(1), m.Snapshot().Deletes, "eviction")
+
+	avlts.Delete(tree, 5)
+	avlts.DeleteBefore(tree, 3)
+	assert.Equal(t, int64(4), m.Snapshot().Deletes)
+	avlts.DeleteFunc(tree, func(k int, _ string) bool { return k%2 == 0 })
+	assert.Equal(t, int64(8), m.Snapshot().Deletes)
+	avlts.Clear(tree)
+	assert.Equal(t, int64(11), m.Snapshot().Deletes)
+	assert.Equal(t, int64(4), m.Snapshot().MaxHeight)
+
+	m.Reset()
+	assert.Equal(t, avlts.MetricsSnapshot{}, m.Snapshot())
+}
+
+func TestWithMetricsShared(t *testing.T) {
+	var m avlts.Metrics
+	a := avlts.New[int, int](avlts.WithMetrics(&m))
+	b := avlts.New[string, int](avlts.WithMetrics(&m))
+	avlts.Insert(a, 1, 1)
+	avlts.Insert(b, "x", 1)
+	avlts.Insert(b, "y", 1)
+
+	got := m.Snapshot()
+	assert.Equal(t, int64(3), got.Inserts)
+	assert.Equal(t, int64(2), got.MaxHeight)
+
+	avlts.Insert(avlts.CloneWith(a, nil), 2, 2)
+	assert.Equal(t, int64(3), m.Snapshot().Inserts, "clones do not inherit metrics")
+}
+
+func TestWithMetricsBuildParallel(t *testing.T) {
+	var m avlts.Metrics
+	items := make([]avlts.Item[int, int], 100)
+	for i := range items {
+		items[i] = avlts.Item[int, int]{Key: i, Value: i}
+	}
+	tree := avlts.BuildParallel(items, 2, avlts.WithMetrics(&m))
+
+	got := m.Snapshot()
+	assert.Equal(t, int64(100), got.Inserts)
+	assert.Equal(t, int64(avlts.Height(tree)), got.MaxHeight)
+}
+
+func TestWithMetricsReplaceKey(t *testing.T) {
+	var m avlts.Metrics
+	tree := avlts.New[int, string](avlts.WithMetrics(&m))
+	avlts.Insert(tree, 1, "a")
+	avlts.Insert(tree, 2, "b")
+	m.Reset()
+
+	require.True(t, avlts.ReplaceKey(tree, 1, 3))
+	assert.False(t, avlts.ReplaceKey(tree, 2, 3))
+	got := m.Snapshot()
+	assert.Equal(t, int64(0), got.Searches)
+	assert.Equal(t, int64(1), got.Deletes)
+	assert.Equal(t, int64(1), got.Inserts)
+}
+
+func TestMetricsString(t *testing.T) {
+	var m avlts.Metrics
+	tree := avlts.New[int, int](avlts.WithMetrics(&m))
+	avlts.Insert(tree, 1, 1)
+	avlts.Get(tree, 1)
+
+	var got avlts.MetricsSnapshot
+	require.NoError(t, json.Unmarshal([]byte(m.String()), &got))
+	assert.Equal(t, m.Snapshot(), got)
+
+	expvar.Publish("avltrees_test_metrics", &m)
+	assert.Equal(t, m.String(), expvar.Get("avltrees_test_metrics").String())
+}
+
+func ExampleWithMetrics() {
+	var m avlts.Metrics
+	tree := avlts.New[int, string](avlts.WithMetrics(&m))
+	for i := range 100 {
+		avlts.Insert(tree, i, "")
+	}
+	avlts.Get(tree, 42)
+	avlts.Delete(tree, 42)
+
+	// In a service, publish the counters once at startup:
+	// expvar.Publish("orders_index", &m)
+	fmt.Println(m.String())
+	// Output:
+	// {"Inserts":100,"Deletes":1,"Searches":1,"Rotations":93,"MaxHeight":7}
+}
@@ -0,0 +1,355 @@
+// Package mvcc provides a multi-version AVL tree. Every change creates a
+// new version of the tree by copying only the path from the root to the
+// changed entry, so earlier versions stay intact and can be read with
+// AsOf while the tree keeps changing.
+//
+// Nodes are immutable once published and have no parent links, which is
+// what lets versions share all unchanged subtrees. The avltrees.Tree type
+// keeps parent links for O(1) successor steps and cannot share nodes this
+// way.
+//
+// A Tree must not be modified and read from different goroutines at the
+// same time. A Snapshot is immutable and may be read from any goroutine,
+// including while its tree is being modified.
+package mvcc
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+type node[K cmp.Ordered, V any] struct {
+	key    K
+	value  V
+	left   *node[K, V]
+	right  *node[K, V]
+	height int8
+}
+
+// Snapshot is a read-only view of one version of a Tree.
+type Snapshot[K cmp.Ordered, V any] struct {
+	root    *node[K, V]
+	count   int
+	version uint64
+}
+
+// Tree is a multi-version AVL tree. Use New to create one.
+type Tree[K cmp.Ordered, V any] struct {
+	versions []*Snapshot[K, V] // retained versions, oldest first; the last is current
+	retain   int
+}
+
+// Option configures a Tree at construction time.
+type Option func(*options)
+
+type options struct {
+	retain int
+}
+
+// WithRetention keeps only the n most recent versions, including the
+// current one; older versions are released as new ones are created.
+// By default every version is kept until Prune is called.
+// It panics if n is less than 1.
+func WithRetention(n int) Option {
+	if n < 1 {
+		panic("mvcc: WithRetention needs at least one version")
+	}
+	return func(o *options) { o.retain = n }
+}
+
+// New creates a new empty tree at version 0.
+func New[K cmp.Ordered, V any](opts ...Option) *Tree[K, V] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Tree[K, V]{versions: []*Snapshot[K, V]{{}}, retain: o.retain}
+}
+
+// Version returns the current version of the tree. It starts at 0 and
+// advances by one with every Insert and every Delete that removes a key.
+func Version[K cmp.Ordered, V any](t *Tree[K, V]) uint64 {
+	return current(t).version
+}
+
+// Current returns a snapshot of the current version of the tree.
+func Current[K cmp.Ordered, V any](t *Tree[K, V]) *Snapshot[K, V] {
+	return current(t)
+}
+
+// AsOf returns a snapshot of the given version of the tree.
+// Returns false if the version does not exist yet or has been released.
+func AsOf[K cmp.Ordered, V any](t *Tree[K, V], version uint64) (*Snapshot[K, V], bool) {
+	i, ok := slices.BinarySearchFunc(t.versions, version, func(s *Snapshot[K, V], v uint64) int {
+		return cmp.Compare(s.version, v)
+	})
+	if !ok {
+		return nil, false
+	}
+	return t.versions[i], true
+}
+
+// Prune releases every version older than before, except the current one,
+// and returns the number of versions released. Snapshots already obtained
+// remain readable.
+func Prune[K cmp.Ordered, V any](t *Tree[K, V], before uint64) int {
+	n := 0
+	for n < len(t.versions)-1 && t.versions[n].version < before {
+		n++
+	}
+	release(t, n)
+	return n
+}
+
+// Insert adds a key-value pair to the tree as a new version, overwriting
+// the value if the key already exists.
+// Returns true if the key was newly inserted, or false if it was updated.
+func Insert[K cmp.Ordered, V any](t *Tree[K, V], key K, value V) bool {
+	cur := current(t)
+	root, inserted := insert(cur.root, key, value)
+	count := cur.count
+	if inserted {
+		count++
+	}
+	publish(t, root, count)
+	return inserted
+}
+
+// Delete removes the key from the tree as a new version.
+// Returns false, without creating a version, if the key does not exist.
+func Delete[K cmp.Ordered, V any](t *Tree[K, V], key K) bool {
+	cur := current(t)
+	root, deleted := remove(cur.root, key)
+	if deleted {
+		publish(t, root, cur.count-1)
+	}
+	return deleted
+}
+
+func current[K cmp.Ordered, V any](t *Tree[K, V]) *Snapshot[K, V] {
+	return t.versions[len(t.versions)-1]
+}
+
+func publish[K cmp.Ordered, V any](t *Tree[K, V], root *node[K, V], count int) {
+	t.versions = append(t.versions, &Snapshot[K, V]{root, count, current(t).version + 1})
+	if t.retain > 0 && len(t.versions) > t.retain {
+		release(t, len(t.versions)-t.retain)
+	}
+}
+
+// release drops the n oldest versions.
+func release[K cmp.Ordered, V any](t *Tree[K, V], n int) {
+	// Clear the dropped slots so their trees can be collected before the
+	// backing array is next reallocated.
+	clear(t.versions[:n])
+	t.versions = t.versions[n:]
+}
+
+// Version returns the version of the tree the snapshot shows.
+func (s *Snapshot[K, V]) Version() uint64 {
+	return s.version
+}
+
+// Len returns the number of entries in the snapshot.
+func (s *Snapshot[K, V]) Len() int {
+	return s.count
+}
+
+// Get returns the value stored under key.
+// Returns the zero value and false if the key does not exist.
+func (s *Snapshot[K, V]) Get(key K) (V, bool) {
+	n := s.root
+	for n != nil {
+		switch {
+		case key < n.key:
+			n = n.left
+		case key > n.key:
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains reports whether the key exists in the snapshot.
+func (s *Snapshot[K, V]) Contains(key K) bool {
+	_, ok := s.Get(key)
+	return ok
+}
+
+// Min returns the entry with the smallest key.
+func (s *Snapshot[K, V]) Min() (key K, value V, ok bool) {
+	n := s.root
+	if n == nil {
+		return key, value, false
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.key, n.value, true
+}
+
+// Max returns the entry with the largest key.
+func (s *Snapshot[K, V]) Max() (key K, value V, ok bool) {
+	n := s.root
+	if n == nil {
+		return key, value, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, n.value, true
+}
+
+// All returns an iterator over the entries of the snapshot in key order.
+func (s *Snapshot[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var stack []*node[K, V]
+		walk(s.root, stack, func(n *node[K, V]) bool { return yield(n.key, n.value) })
+	}
+}
+
+// Range returns an iterator over the entries with keys in [from, to).
+func (s *Snapshot[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		// Start with the ancestors of from that an in-order walk would
+		// still have pending on reaching it.
+		var stack []*node[K, V]
+		for n := s.root; n != nil; {
+			if n.key < from {
+				n = n.right
+			} else {
+				stack = append(stack, n)
+				n = n.left
+			}
+		}
+		walk(nil, stack, func(n *node[K, V]) bool { return n.key < to && yield(n.key, n.value) })
+	}
+}
+
+// walk continues an in-order traversal with pending ancestors stack,
+// starting at the subtree rooted at n.
+func walk[K cmp.Ordered, V any](n *node[K, V], stack []*node[K, V], visit func(*node[K, V]) bool) {
+	for n != nil || len(stack) > 0 {
+		for n != nil {
+			stack = append(stack, n)
+			n = n.left
+		}
+		n = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !visit(n) {
+			return
+		}
+		n = n.right
+	}
+}
+
+// insert returns the root of a copy of the subtree rooted at n with key
+// set to value. Only the nodes on the path to key are copied.
+func insert[K cmp.Ordered, V any](n *node[K, V], key K, value V) (*node[K, V], bool) {
+	if n == nil {
+		return &node[K, V]{key: key, value: value, height: 1}, true
+	}
+	c := *n
+	var inserted bool
+	switch {
+	case key < n.key:
+		c.left, inserted = insert(n.left, key, value)
+	case key > n.key:
+		c.right, inserted = insert(n.right, key, value)
+	default:
+		c.value = value
+		return &c, false
+	}
+	return rebalance(&c), inserted
+}
+
+func remove[K cmp.Ordered, V any](n *node[K, V], key K) (*node[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	c := *n
+	switch {
+	case key < n.key:
+		left, deleted := remove(n.left, key)
+		if !deleted {
+			return n, false
+		}
+		c.left = left
+	case key > n.key:
+		right, deleted := remove(n.right, key)
+		if !deleted {
+			return n, false
+		}
+		c.right = right
+	case n.left == nil:
+		return n.right, true
+	case n.right == nil:
+		return n.left, true
+	default:
+		var m *node[K, V]
+		c.right, m = removeMin(n.right)
+		c.key, c.value = m.key, m.value
+	}
+	return rebalance(&c), true
+}
+
+// removeMin returns a copy of the subtree rooted at n without its smallest
+// node, and that node.
+func removeMin[K cmp.Ordered, V any](n *node[K, V]) (*node[K, V], *node[K, V]) {
+	if n.left == nil {
+		return n.right, n
+	}
+	c := *n
+	var m *node[K, V]
+	c.left, m = removeMin(n.left)
+	return rebalance(&c), m
+}
+
+func height[K cmp.Ordered, V any](n *node[K, V]) int8 {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// rebalance restores the balance of n, which must be a fresh copy.
+// Rotations copy any other node they change, since it may be shared with
+// older versions.
+func rebalance[K cmp.Ordered, V any](n *node[K, V]) *node[K, V] {
+	n.height = max(height(n.left), height(n.right)) + 1
+	switch bf := height(n.left) - height(n.right); {
+	case bf > 1:
+		if height(n.left.left) < height(n.left.right) {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	case bf < -1:
+		if height(n.right.right) < height(n.right.left) {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+	return n
+}
+
+func rotateLeft[K cmp.Ordered, V any](z *node[K, V]) *node[K, V] {
+	zc, y := *z, *z.right
+	zc.right = y.left
+	zc.height = max(height(zc.left), height(zc.right)) + 1
+	y.left = &zc
+	y.height = max(height(y.left), height(y.right)) + 1
+	return &y
+}
+
+func rotateRight[K cmp.Ordered, V any](z *node[K, V]) *node[K, V] {
+	zc, y := *z, *z.left
+	zc.left = y.right
+	zc.height = max(height(zc.left), height(zc.right)) + 1
+	y.right = &zc
+	y.height = max(height(y.left), height(y.right)) + 1
+	return &y
+}
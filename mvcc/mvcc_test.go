@@ -0,0 +1,192 @@
+package mvcc_test
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/byExist/avltrees/mvcc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func entries(s *mvcc.Snapshot[int, int]) map[int]int {
+	m := map[int]int{}
+	for k, v := range s.All() {
+		m[k] = v
+	}
+	return m
+}
+
+func TestInsertAndDelete(t *testing.T) {
+	tree := mvcc.New[string, int]()
+	assert.Equal(t, uint64(0), mvcc.Version(tree))
+	assert.True(t, mvcc.Insert(tree, "a", 1))
+	assert.True(t, mvcc.Insert(tree, "b", 2))
+	assert.False(t, mvcc.Insert(tree, "a", 10))
+	assert.Equal(t, uint64(3), mvcc.Version(tree))
+
+	assert.False(t, mvcc.Delete(tree, "x"))
+	assert.Equal(t, uint64(3), mvcc.Version(tree), "no-op deletes do not create a version")
+	assert.True(t, mvcc.Delete(tree, "b"))
+
+	cur := mvcc.Current(tree)
+	assert.Equal(t, uint64(4), cur.Version())
+	assert.Equal(t, 1, cur.Len())
+	v, ok := cur.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 10, v)
+	assert.False(t, cur.Contains("b"))
+}
+
+func TestAsOf(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 3))
+	tree := mvcc.New[int, int]()
+	history := []map[int]int{{}}
+	want := map[int]int{}
+	for i := range 3000 {
+		k := r.IntN(200)
+		if r.IntN(3) == 0 {
+			if mvcc.Delete(tree, k) {
+				delete(want, k)
+				history = append(history, maps.Clone(want))
+			}
+		} else {
+			mvcc.Insert(tree, k, i)
+			want[k] = i
+			history = append(history, maps.Clone(want))
+		}
+	}
+	require.Equal(t, uint64(len(history)-1), mvcc.Version(tree))
+	for v, h := range history {
+		s, ok := mvcc.AsOf(tree, uint64(v))
+		require.True(t, ok)
+		assert.Equal(t, h, entries(s), "version %d", v)
+		assert.Equal(t, len(h), s.Len())
+	}
+	_, ok := mvcc.AsOf(tree, uint64(len(history)))
+	assert.False(t, ok, "future versions do not exist")
+}
+
+func TestWithRetention(t *testing.T) {
+	tree := mvcc.New[int, int](mvcc.WithRetention(3))
+	for i := 1; i <= 10; i++ {
+		mvcc.Insert(tree, i, i)
+	}
+	for v := range uint64(8) {
+		_, ok := mvcc.AsOf(tree, v)
+		assert.False(t, ok, "version %d is released", v)
+	}
+	for v := uint64(8); v <= 10; v++ {
+		s, ok := mvcc.AsOf(tree, v)
+		require.True(t, ok)
+		assert.Equal(t, int(v), s.Len())
+	}
+	assert.Panics(t, func() { mvcc.WithRetention(0) })
+}
+
+func TestPrune(t *testing.T) {
+	tree := mvcc.New[int, int]()
+	for i := 1; i <= 5; i++ {
+		mvcc.Insert(tree, i, i)
+	}
+	old, _ := mvcc.AsOf(tree, 2)
+	assert.Equal(t, 3, mvcc.Prune(tree, 3))
+	_, ok := mvcc.AsOf(tree, 2)
+	assert.False(t, ok)
+	_, ok = mvcc.AsOf(tree, 3)
+	assert.True(t, ok)
+	assert.Equal(t, map[int]int{1: 1, 2: 2}, entries(old), "obtained snapshots stay readable")
+
+	assert.Equal(t, 2, mvcc.Prune(tree, 100), "the current version is kept")
+	assert.Equal(t, 5, mvcc.Current(tree).Len())
+}
+
+func TestSnapshotNavigation(t *testing.T) {
+	tree := mvcc.New[int, string]()
+	s := mvcc.Current(tree)
+	_, _, ok := s.Min()
+	assert.False(t, ok)
+	_, _, ok = s.Max()
+	assert.False(t, ok)
+
+	for i := 0; i < 20; i += 2 {
+		mvcc.Insert(tree, i, fmt.Sprint(i))
+	}
+	s = mvcc.Current(tree)
+	k, v, ok := s.Min()
+	assert.True(t, ok)
+	assert.Equal(t, 0, k)
+	assert.Equal(t, "0", v)
+	k, _, _ = s.Max()
+	assert.Equal(t, 18, k)
+
+	var keys []int
+	for k := range s.Range(5, 13) {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{6, 8, 10, 12}, keys)
+
+	keys = keys[:0]
+	for k := range s.All() {
+		if k > 4 {
+			break
+		}
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{0, 2, 4}, keys)
+}
+
+func TestSnapshotUnderWrites(t *testing.T) {
+	tree := mvcc.New[int, int]()
+	for i := range 1000 {
+		mvcc.Insert(tree, i, i)
+	}
+	snap := mvcc.Current(tree)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range 1000 {
+			mvcc.Delete(tree, i)
+			mvcc.Insert(tree, i+1000, i)
+		}
+	}()
+	var keys []int
+	for k := range snap.All() {
+		keys = append(keys, k)
+	}
+	wg.Wait()
+
+	assert.Len(t, keys, 1000)
+	assert.True(t, slices.IsSorted(keys))
+	assert.Equal(t, 999, keys[len(keys)-1])
+}
+
+func ExampleAsOf() {
+	prices := mvcc.New[string, int]()
+	mvcc.Insert(prices, "apple", 100)
+	before := mvcc.Version(prices)
+	mvcc.Insert(prices, "apple", 120)
+
+	then, _ := mvcc.AsOf(prices, before)
+	old, _ := then.Get("apple")
+	now, _ := mvcc.Current(prices).Get("apple")
+	fmt.Println(old, now)
+	// Output: 100 120
+}
+
+func ExampleWithRetention() {
+	tree := mvcc.New[int, string](mvcc.WithRetention(2))
+	mvcc.Insert(tree, 1, "a")
+	mvcc.Insert(tree, 2, "b")
+	mvcc.Insert(tree, 3, "c")
+
+	_, ok := mvcc.AsOf(tree, 1)
+	fmt.Println(ok)
+	// Output: false
+}
@@ -0,0 +1,64 @@
+package avltrees
+
+import "cmp"
+
+// The functions in this file mirror java.util.NavigableMap. They return the
+// entry as an Item instead of a node, so that it stays valid after the tree
+// changes, and report false where NavigableMap returns null.
+
+// FirstEntry returns the entry with the smallest key.
+func FirstEntry[K cmp.Ordered, V any](t *Tree[K, V]) (Item[K, V], bool) {
+	return itemOf(Min(t))
+}
+
+// LastEntry returns the entry with the largest key.
+func LastEntry[K cmp.Ordered, V any](t *Tree[K, V]) (Item[K, V], bool) {
+	return itemOf(Max(t))
+}
+
+// PollFirstEntry removes and returns the entry with the smallest key.
+func PollFirstEntry[K cmp.Ordered, V any](t *Tree[K, V]) (Item[K, V], bool) {
+	return poll(t, FirstEntry)
+}
+
+// PollLastEntry removes and returns the entry with the largest key.
+func PollLastEntry[K cmp.Ordered, V any](t *Tree[K, V]) (Item[K, V], bool) {
+	return poll(t, LastEntry)
+}
+
+// HigherEntry returns the entry with the smallest key greater than key.
+func HigherEntry[K cmp.Ordered, V any](t *Tree[K, V], key K) (Item[K, V], bool) {
+	return itemOf(Higher(t, key))
+}
+
+// LowerEntry returns the entry with the largest key less than key.
+func LowerEntry[K cmp.Ordered, V any](t *Tree[K, V], key K) (Item[K, V], bool) {
+	return itemOf(Lower(t, key))
+}
+
+// CeilingEntry returns the entry with the smallest key greater than or
+// equal to key.
+func CeilingEntry[K cmp.Ordered, V any](t *Tree[K, V], key K) (Item[K, V], bool) {
+	return itemOf(Ceiling(t, key))
+}
+
+// FloorEntry returns the entry with the largest key less than or equal to
+// key.
+func FloorEntry[K cmp.Ordered, V any](t *Tree[K, V], key K) (Item[K, V], bool) {
+	return itemOf(Floor(t, key))
+}
+
+func itemOf[K cmp.Ordered, V any](n *Node[K, V], ok bool) (Item[K, V], bool) {
+	if !ok {
+		return Item[K, V]{}, false
+	}
+	return Item[K, V]{n.key, n.value}, true
+}
+
+func poll[K cmp.Ordered, V any](t *Tree[K, V], entry func(*Tree[K, V]) (Item[K, V], bool)) (Item[K, V], bool) {
+	it, ok := entry(t)
+	if ok {
+		Delete(t, it.Key)
+	}
+	return it, ok
+}
@@ -0,0 +1,99 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFirstEntry(t *testing.T) {
+	it, ok := avlts.FirstEntry(treeOf([]int{10, 20, 30}, []string{"a", "b", "c"}))
+	assert.True(t, ok)
+	assert.Equal(t, avlts.Item[int, string]{Key: 10, Value: "a"}, it)
+	_, ok = avlts.FirstEntry(avlts.New[int, string]())
+	assert.False(t, ok)
+}
+
+func TestLastEntry(t *testing.T) {
+	it, ok := avlts.LastEntry(treeOf([]int{10, 20, 30}, []string{"a", "b", "c"}))
+	assert.True(t, ok)
+	assert.Equal(t, avlts.Item[int, string]{Key: 30, Value: "c"}, it)
+	_, ok = avlts.LastEntry(avlts.New[int, string]())
+	assert.False(t, ok)
+}
+
+func TestPollFirstEntry(t *testing.T) {
+	tree := treeOf([]int{10, 20, 30}, []string{"a", "b", "c"})
+	var keys []int
+	for it, ok := avlts.PollFirstEntry(tree); ok; it, ok = avlts.PollFirstEntry(tree) {
+		keys = append(keys, it.Key)
+	}
+	assert.Equal(t, []int{10, 20, 30}, keys)
+	assert.Equal(t, 0, avlts.Len(tree))
+}
+
+func TestPollLastEntry(t *testing.T) {
+	tree := treeOf([]int{10, 20, 30}, []string{"a", "b", "c"})
+	it, ok := avlts.PollLastEntry(tree)
+	assert.True(t, ok)
+	assert.Equal(t, avlts.Item[int, string]{Key: 30, Value: "c"}, it)
+	assert.Equal(t, []int{10, 20}, treeKeys(tree))
+
+	avlts.Freeze(tree)
+	assert.PanicsWithValue(t, avlts.ErrFrozen, func() { avlts.PollLastEntry(tree) })
+}
+
+func TestHigherLowerEntry(t *testing.T) {
+	tree := treeOf([]int{10, 20, 30}, []string{"a", "b", "c"})
+	it, ok := avlts.HigherEntry(tree, 20)
+	assert.True(t, ok)
+	assert.Equal(t, 30, it.Key)
+	_, ok = avlts.HigherEntry(tree, 30)
+	assert.False(t, ok)
+
+	it, ok = avlts.LowerEntry(tree, 20)
+	assert.True(t, ok)
+	assert.Equal(t, 10, it.Key)
+	_, ok = avlts.LowerEntry(tree, 10)
+	assert.False(t, ok)
+}
+
+func TestCeilingFloorEntry(t *testing.T) {
+	tree := treeOf([]int{10, 20, 30}, []string{"a", "b", "c"})
+	it, ok := avlts.CeilingEntry(tree, 20)
+	assert.True(t, ok)
+	assert.Equal(t, avlts.Item[int, string]{Key: 20, Value: "b"}, it)
+	it, ok = avlts.CeilingEntry(tree, 21)
+	assert.True(t, ok)
+	assert.Equal(t, 30, it.Key)
+	_, ok = avlts.CeilingEntry(tree, 31)
+	assert.False(t, ok)
+
+	it, ok = avlts.FloorEntry(tree, 29)
+	assert.True(t, ok)
+	assert.Equal(t, 20, it.Key)
+	_, ok = avlts.FloorEntry(tree, 9)
+	assert.False(t, ok)
+}
+
+func ExamplePollFirstEntry() {
+	tasks := avlts.New[int, string]()
+	avlts.InsertAll(tasks, map[int]string{3: "deploy", 1: "build", 2: "test"})
+	for it, ok := avlts.PollFirstEntry(tasks); ok; it, ok = avlts.PollFirstEntry(tasks) {
+		fmt.Println(it.Key, it.Value)
+	}
+	// Output:
+	// 1 build
+	// 2 test
+	// 3 deploy
+}
+
+func ExampleFloorEntry() {
+	rates := avlts.New[int, float64]()
+	avlts.InsertAll(rates, map[int]float64{0: 0.10, 10000: 0.20, 50000: 0.30})
+	it, _ := avlts.FloorEntry(rates, 25000)
+	fmt.Println(it.Key, it.Value)
+	// Output: 10000 0.2
+}
@@ -0,0 +1,37 @@
+package avltrees
+
+// NearestK returns the k nodes whose keys are closest to key, nearest first.
+// Of two keys equally far from key, the smaller comes first. It walks
+// outward from Floor and Higher with two cursors, so it costs
+// O(log n + k). If k is at least Len(t), every node is returned.
+func NearestK[K Number, V any](t *Tree[K, V], key K, k int) []*Node[K, V] {
+	if k <= 0 {
+		return nil
+	}
+	lo, hasLo := Floor(t, key)
+	hi, hasHi := Higher(t, key)
+	out := make([]*Node[K, V], 0, min(k, Len(t)))
+	for len(out) < k && (hasLo || hasHi) {
+		if hasLo && (!hasHi || !closer(key, lo.key, hi.key)) {
+			out = append(out, lo)
+			lo, hasLo = Predecessor(lo)
+		} else {
+			out = append(out, hi)
+			hi, hasHi = Successor(hi)
+		}
+	}
+	return out
+}
+
+// closer reports whether hi is strictly closer to key than lo, given
+// lo <= key <= hi. Integer distances are taken modulo 2^64, which is exact
+// because the true distance between two keys of at most 64 bits always
+// fits in a uint64, so keys near the limits of a signed type do not
+// overflow. Float keys, recognized by 1/2 not truncating to 0, are
+// subtracted directly.
+func closer[K Number](key, lo, hi K) bool {
+	if one := K(1); one/2 != 0 {
+		return hi-key < key-lo
+	}
+	return uint64(hi)-uint64(key) < uint64(key)-uint64(lo)
+}
@@ -0,0 +1,95 @@
+package avltrees_test
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+)
+
+func nodeKeys[K cmp.Ordered, V any](nodes []*avlts.Node[K, V]) []K {
+	var keys []K
+	for _, n := range nodes {
+		keys = append(keys, n.Key())
+	}
+	return keys
+}
+
+func TestNearestK(t *testing.T) {
+	tree := treeOf[int, struct{}]([]int{10, 20, 30, 40, 50}, nil)
+	assert.Equal(t, []int{30, 20, 40}, nodeKeys(avlts.NearestK(tree, 29, 3)))
+	assert.Equal(t, []int{20, 30}, nodeKeys(avlts.NearestK(tree, 25, 2)), "ties go to the smaller key")
+	assert.Equal(t, []int{30, 20, 40}, nodeKeys(avlts.NearestK(tree, 30, 3)), "an exact match comes first")
+	assert.Equal(t, []int{10, 20}, nodeKeys(avlts.NearestK(tree, -100, 2)))
+	assert.Equal(t, []int{50, 40}, nodeKeys(avlts.NearestK(tree, 100, 2)))
+	assert.Len(t, avlts.NearestK(tree, 30, 10), 5)
+	assert.Empty(t, avlts.NearestK(tree, 30, 0))
+	assert.Empty(t, avlts.NearestK(avlts.New[int, struct{}](), 30, 3))
+
+	unsigned := avlts.New[uint8, bool]()
+	for _, k := range []uint8{0, 3, 250, 255} {
+		avlts.Insert(unsigned, k, true)
+	}
+	assert.Equal(t, []uint8{3, 0}, nodeKeys(avlts.NearestK(unsigned, 2, 2)))
+	assert.Equal(t, []uint8{250, 255}, nodeKeys(avlts.NearestK(unsigned, 252, 2)))
+
+	signed := treeOf[int8, bool]([]int8{-128, 127}, nil)
+	assert.Equal(t, []int8{127, -128}, nodeKeys(avlts.NearestK(signed, 100, 2)))
+	assert.Equal(t, []int8{-128, 127}, nodeKeys(avlts.NearestK(signed, -100, 2)))
+	assert.Equal(t, []int8{-128}, nodeKeys(avlts.NearestK(signed, -1, 1)), "ties go to the smaller key")
+
+	wide := treeOf[int64, bool]([]int64{math.MinInt64, math.MaxInt64}, nil)
+	assert.Equal(t, []int64{math.MaxInt64}, nodeKeys(avlts.NearestK(wide, 1, 1)))
+	assert.Equal(t, []int64{math.MinInt64}, nodeKeys(avlts.NearestK(wide, -2, 1)))
+
+	floats := treeOf[float64, bool]([]float64{-1.5, 2.25}, nil)
+	assert.Equal(t, []float64{2.25}, nodeKeys(avlts.NearestK(floats, 0.5, 1)))
+}
+
+func TestNearestKRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tree := avlts.New[int, struct{}]()
+	var keys []int
+	for range 200 {
+		k := r.Intn(1000)
+		if avlts.Insert(tree, k, struct{}{}) {
+			keys = append(keys, k)
+		}
+	}
+	for range 100 {
+		q, k := r.Intn(1100)-50, 1+r.Intn(20)
+		want := slices.Clone(keys)
+		slices.SortFunc(want, func(a, b int) int {
+			if da, db := abs(a-q), abs(b-q); da != db {
+				return da - db
+			}
+			return a - b
+		})
+		assert.Equal(t, want[:k], nodeKeys(avlts.NearestK(tree, q, k)), "q=%d k=%d", q, k)
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func ExampleNearestK() {
+	samples := avlts.New[int, float64]()
+	for ts, v := range map[int]float64{100: 1.5, 160: 2.0, 220: 2.5, 400: 9.0} {
+		avlts.Insert(samples, ts, v)
+	}
+	for _, n := range avlts.NearestK(samples, 200, 2) {
+		fmt.Println(n.Key(), n.Value())
+	}
+	// Output:
+	// 220 2.5
+	// 160 2
+}
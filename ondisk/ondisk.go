@@ -0,0 +1,302 @@
+// Package ondisk stores an AVL tree in a file and queries it through a
+// read-only memory mapping, so indexes larger than RAM can be searched
+// without loading them.
+//
+// The file does not store the tree's nodes and links. Entries are laid out
+// in key order behind a table of record offsets, and lookups binary-search
+// that table, which makes Search, Rank, Kth, and Range O(log n) with no
+// per-entry link overhead. Because there are no nodes on disk, Search
+// returns the value itself rather than a node, and the tree has no Root to
+// walk.
+package ondisk
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"io"
+	"iter"
+	"math"
+	"os"
+
+	avlts "github.com/byExist/avltrees"
+)
+
+const (
+	magic       = "AVLD"
+	version     = 1
+	headerSize  = 16
+	offsetWidth = 8
+)
+
+// ErrFormat is returned when a file is not a valid ondisk tree.
+var ErrFormat = errors.New("ondisk: invalid file format")
+
+// Codec encodes and decodes keys or values stored on disk.
+type Codec[T any] interface {
+	// Append appends the encoding of v to dst and returns the extended slice.
+	Append(dst []byte, v T) []byte
+	// Decode decodes a value previously encoded by Append.
+	Decode(src []byte) T
+}
+
+// FixedWidth is implemented by codecs whose encodings all have the same
+// length. Open and FromBytes reject files holding a key or value of another
+// length, so Decode never sees a truncated encoding.
+type FixedWidth interface {
+	// Width returns the length of every encoding, in bytes.
+	Width() int
+}
+
+// StringCodec stores strings as their raw bytes.
+type StringCodec struct{}
+
+// Append appends the bytes of v to dst.
+func (StringCodec) Append(dst []byte, v string) []byte { return append(dst, v...) }
+
+// Decode returns src as a string.
+func (StringCodec) Decode(src []byte) string { return string(src) }
+
+// Int64Codec stores int64 values as 8 big-endian bytes.
+type Int64Codec struct{}
+
+// Append appends the big-endian encoding of v to dst.
+func (Int64Codec) Append(dst []byte, v int64) []byte {
+	return binary.BigEndian.AppendUint64(dst, uint64(v))
+}
+
+// Decode decodes an 8-byte big-endian int64.
+func (Int64Codec) Decode(src []byte) int64 { return int64(binary.BigEndian.Uint64(src)) }
+
+// Width returns 8.
+func (Int64Codec) Width() int { return 8 }
+
+// Float64Codec stores float64 values as their 8-byte IEEE 754 representation.
+type Float64Codec struct{}
+
+// Append appends the big-endian IEEE 754 encoding of v to dst.
+func (Float64Codec) Append(dst []byte, v float64) []byte {
+	return binary.BigEndian.AppendUint64(dst, math.Float64bits(v))
+}
+
+// Decode decodes an 8-byte big-endian IEEE 754 float64.
+func (Float64Codec) Decode(src []byte) float64 {
+	return math.Float64frombits(binary.BigEndian.Uint64(src))
+}
+
+// Width returns 8.
+func (Float64Codec) Width() int { return 8 }
+
+// Write serializes the AVL tree t to w. The entries are streamed in two
+// passes over t, one to write the offset table and one to write the
+// records, so t must not change during the call but is never copied.
+func Write[K cmp.Ordered, V any](w io.Writer, t *avlts.Tree[K, V], kc Codec[K], vc Codec[V]) error {
+	n := avlts.Len(t)
+	bw := bufio.NewWriter(w)
+	header := make([]byte, 0, headerSize)
+	header = append(header, magic...)
+	header = binary.LittleEndian.AppendUint32(header, version)
+	header = binary.LittleEndian.AppendUint64(header, uint64(n))
+	bw.Write(header)
+
+	var rec, key, word []byte
+	var off uint64
+	for node := range avlts.InOrder(t) {
+		bw.Write(binary.LittleEndian.AppendUint64(word[:0], off))
+		rec, key = appendRecord(rec[:0], key, node.Key(), node.Value(), kc, vc)
+		off += uint64(len(rec))
+	}
+	bw.Write(binary.LittleEndian.AppendUint64(word[:0], off))
+	for node := range avlts.InOrder(t) {
+		rec, key = appendRecord(rec[:0], key, node.Key(), node.Value(), kc, vc)
+		bw.Write(rec)
+	}
+	return bw.Flush()
+}
+
+// appendRecord appends the record for an entry to dst, using scratch to
+// encode the key, and returns the extended dst and scratch buffers.
+func appendRecord[K cmp.Ordered, V any](dst, scratch []byte, k K, v V, kc Codec[K], vc Codec[V]) ([]byte, []byte) {
+	scratch = kc.Append(scratch[:0], k)
+	dst = binary.AppendUvarint(dst, uint64(len(scratch)))
+	dst = append(dst, scratch...)
+	return vc.Append(dst, v), scratch
+}
+
+// Tree is a read-only AVL tree backed by a memory-mapped file.
+type Tree[K cmp.Ordered, V any] struct {
+	data    []byte
+	count   int
+	offsets []byte
+	records []byte
+	kc      Codec[K]
+	vc      Codec[V]
+	unmap   func() error
+}
+
+// Open maps the file at path and returns a read-only tree over it.
+// The file layout is validated before Open returns.
+func Open[K cmp.Ordered, V any](path string, kc Codec[K], vc Codec[V]) (*Tree[K, V], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	data, unmap, err := mapFile(f)
+	if err != nil {
+		return nil, err
+	}
+	t, err := parse(data, kc, vc)
+	if err != nil {
+		unmap()
+		return nil, err
+	}
+	t.unmap = unmap
+	return t, nil
+}
+
+// FromBytes returns a read-only tree over data produced by Write.
+func FromBytes[K cmp.Ordered, V any](data []byte, kc Codec[K], vc Codec[V]) (*Tree[K, V], error) {
+	return parse(data, kc, vc)
+}
+
+// Close releases the memory mapping. The tree must not be used afterwards.
+func Close[K cmp.Ordered, V any](t *Tree[K, V]) error {
+	if t.unmap == nil {
+		return nil
+	}
+	err := t.unmap()
+	t.unmap, t.data, t.offsets, t.records = nil, nil, nil, nil
+	return err
+}
+
+// Len returns the number of entries in the tree.
+func Len[K cmp.Ordered, V any](t *Tree[K, V]) int {
+	return t.count
+}
+
+// Search returns the value stored under key and whether it was found.
+func Search[K cmp.Ordered, V any](t *Tree[K, V], key K) (V, bool) {
+	i := lowerBound(t, key)
+	if i < t.count && t.keyAt(i) == key {
+		return t.valueAt(i), true
+	}
+	var zero V
+	return zero, false
+}
+
+// Rank returns the number of entries with keys less than the given key.
+func Rank[K cmp.Ordered, V any](t *Tree[K, V], key K) int {
+	return lowerBound(t, key)
+}
+
+// Kth returns the entry with the given 0-based rank.
+// Returns the key, value, and true if such rank exists.
+func Kth[K cmp.Ordered, V any](t *Tree[K, V], k int) (K, V, bool) {
+	if k < 0 || k >= t.count {
+		var zk K
+		var zv V
+		return zk, zv, false
+	}
+	return t.keyAt(k), t.valueAt(k), true
+}
+
+// Range returns an iterator over entries with keys in the range [from, to).
+func Range[K cmp.Ordered, V any](t *Tree[K, V], from, to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for i := lowerBound(t, from); i < t.count; i++ {
+			k := t.keyAt(i)
+			if k >= to || !yield(k, t.valueAt(i)) {
+				return
+			}
+		}
+	}
+}
+
+// lowerBound binary-searches for the first rank whose key is >= key.
+func lowerBound[K cmp.Ordered, V any](t *Tree[K, V], key K) int {
+	lo, hi := 0, t.count
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if t.keyAt(mid) < key {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+func (t *Tree[K, V]) record(i int) (key, value []byte) {
+	start := binary.LittleEndian.Uint64(t.offsets[i*offsetWidth:])
+	end := binary.LittleEndian.Uint64(t.offsets[(i+1)*offsetWidth:])
+	rec := t.records[start:end]
+	keyLen, n := binary.Uvarint(rec)
+	return rec[n : n+int(keyLen)], rec[n+int(keyLen):]
+}
+
+func (t *Tree[K, V]) keyAt(i int) K {
+	k, _ := t.record(i)
+	return t.kc.Decode(k)
+}
+
+func (t *Tree[K, V]) valueAt(i int) V {
+	_, v := t.record(i)
+	return t.vc.Decode(v)
+}
+
+func parse[K cmp.Ordered, V any](data []byte, kc Codec[K], vc Codec[V]) (*Tree[K, V], error) {
+	if len(data) < headerSize || string(data[:4]) != magic ||
+		binary.LittleEndian.Uint32(data[4:]) != version {
+		return nil, ErrFormat
+	}
+	count := binary.LittleEndian.Uint64(data[8:])
+	slots := uint64(len(data)-headerSize) / offsetWidth
+	if slots == 0 || count > slots-1 {
+		return nil, ErrFormat
+	}
+	tableEnd := headerSize + int(count+1)*offsetWidth
+	t := &Tree[K, V]{
+		data:    data,
+		count:   int(count),
+		offsets: data[headerSize:tableEnd],
+		records: data[tableEnd:],
+		kc:      kc,
+		vc:      vc,
+	}
+	keyWidth, valueWidth := width(kc), width(vc)
+	var prev uint64
+	for i := 0; i <= t.count; i++ {
+		off := binary.LittleEndian.Uint64(t.offsets[i*offsetWidth:])
+		if off < prev || off > uint64(len(t.records)) {
+			return nil, ErrFormat
+		}
+		if i > 0 {
+			rec := t.records[prev:off]
+			keyLen, n := binary.Uvarint(rec)
+			if n <= 0 || keyLen > uint64(len(rec)-n) {
+				return nil, ErrFormat
+			}
+			valueLen := uint64(len(rec)-n) - keyLen
+			if (keyWidth >= 0 && keyLen != uint64(keyWidth)) ||
+				(valueWidth >= 0 && valueLen != uint64(valueWidth)) {
+				return nil, ErrFormat
+			}
+		}
+		prev = off
+	}
+	if prev != uint64(len(t.records)) {
+		return nil, ErrFormat
+	}
+	return t, nil
+}
+
+// width returns the encoding length of a FixedWidth codec, or -1 for a
+// codec whose encodings vary in length.
+func width(c any) int {
+	if fw, ok := c.(FixedWidth); ok {
+		return fw.Width()
+	}
+	return -1
+}
@@ -0,0 +1,230 @@
+package ondisk_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/byExist/avltrees/ondisk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, tree *avlts.Tree[string, int64]) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "index.avld")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, ondisk.Write(f, tree, ondisk.StringCodec{}, ondisk.Int64Codec{}))
+	require.NoError(t, f.Close())
+	return path
+}
+
+func TestOpen(t *testing.T) {
+	src := avlts.New[string, int64]()
+	for i, k := range []string{"delta", "alpha", "echo", "charlie", "bravo"} {
+		avlts.Insert(src, k, int64(i))
+	}
+	path := writeFile(t, src)
+	tree, err := ondisk.Open(path, ondisk.StringCodec{}, ondisk.Int64Codec{})
+	require.NoError(t, err)
+	defer ondisk.Close(tree)
+
+	assert.Equal(t, 5, ondisk.Len(tree))
+
+	_, err = ondisk.Open(filepath.Join(t.TempDir(), "missing"), ondisk.StringCodec{}, ondisk.Int64Codec{})
+	assert.Error(t, err)
+}
+
+func TestOpenEmpty(t *testing.T) {
+	path := writeFile(t, avlts.New[string, int64]())
+	tree, err := ondisk.Open(path, ondisk.StringCodec{}, ondisk.Int64Codec{})
+	require.NoError(t, err)
+	defer ondisk.Close(tree)
+
+	assert.Equal(t, 0, ondisk.Len(tree))
+	_, ok := ondisk.Search(tree, "a")
+	assert.False(t, ok)
+
+	empty := filepath.Join(t.TempDir(), "empty")
+	require.NoError(t, os.WriteFile(empty, nil, 0o644))
+	_, err = ondisk.Open(empty, ondisk.StringCodec{}, ondisk.Int64Codec{})
+	assert.ErrorIs(t, err, ondisk.ErrFormat)
+}
+
+func TestFromBytes(t *testing.T) {
+	var buf bytes.Buffer
+	src := avlts.New[string, int64]()
+	for i, k := range []string{"delta", "alpha", "echo", "charlie", "bravo"} {
+		avlts.Insert(src, k, int64(i))
+	}
+	require.NoError(t, ondisk.Write(&buf, src, ondisk.StringCodec{}, ondisk.Int64Codec{}))
+	data := buf.Bytes()
+
+	tree, err := ondisk.FromBytes(data, ondisk.StringCodec{}, ondisk.Int64Codec{})
+	require.NoError(t, err)
+	assert.Equal(t, 5, ondisk.Len(tree))
+
+	for _, bad := range [][]byte{
+		nil,
+		[]byte("AVLD"),
+		append([]byte("XXXX"), data[4:]...),
+		data[:len(data)-1],
+		data[:20],
+	} {
+		_, err := ondisk.FromBytes(bad, ondisk.StringCodec{}, ondisk.Int64Codec{})
+		assert.ErrorIs(t, err, ondisk.ErrFormat)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	src := avlts.New[string, int64]()
+	for i, k := range []string{"delta", "alpha", "echo", "charlie", "bravo"} {
+		avlts.Insert(src, k, int64(i))
+	}
+	path := writeFile(t, src)
+	tree, err := ondisk.Open(path, ondisk.StringCodec{}, ondisk.Int64Codec{})
+	require.NoError(t, err)
+	defer ondisk.Close(tree)
+
+	v, ok := ondisk.Search(tree, "charlie")
+	require.True(t, ok)
+	assert.Equal(t, int64(3), v)
+
+	_, ok = ondisk.Search(tree, "foxtrot")
+	assert.False(t, ok)
+	_, ok = ondisk.Search(tree, "b")
+	assert.False(t, ok)
+}
+
+func TestRankKth(t *testing.T) {
+	src := avlts.New[string, int64]()
+	for i, k := range []string{"delta", "alpha", "echo", "charlie", "bravo"} {
+		avlts.Insert(src, k, int64(i))
+	}
+	path := writeFile(t, src)
+	tree, err := ondisk.Open(path, ondisk.StringCodec{}, ondisk.Int64Codec{})
+	require.NoError(t, err)
+	defer ondisk.Close(tree)
+
+	assert.Equal(t, 0, ondisk.Rank(tree, "alpha"))
+	assert.Equal(t, 2, ondisk.Rank(tree, "c"))
+	assert.Equal(t, 5, ondisk.Rank(tree, "zulu"))
+
+	k, v, ok := ondisk.Kth(tree, 1)
+	require.True(t, ok)
+	assert.Equal(t, "bravo", k)
+	assert.Equal(t, int64(4), v)
+
+	_, _, ok = ondisk.Kth(tree, 5)
+	assert.False(t, ok)
+	_, _, ok = ondisk.Kth(tree, -1)
+	assert.False(t, ok)
+}
+
+func TestRange(t *testing.T) {
+	src := avlts.New[string, int64]()
+	for i, k := range []string{"delta", "alpha", "echo", "charlie", "bravo"} {
+		avlts.Insert(src, k, int64(i))
+	}
+	path := writeFile(t, src)
+	tree, err := ondisk.Open(path, ondisk.StringCodec{}, ondisk.Int64Codec{})
+	require.NoError(t, err)
+	defer ondisk.Close(tree)
+
+	var keys []string
+	for k := range ondisk.Range(tree, "b", "e") {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []string{"bravo", "charlie", "delta"}, keys)
+
+	keys = nil
+	for k := range ondisk.Range(tree, "a", "z") {
+		keys = append(keys, k)
+		if len(keys) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"alpha", "bravo"}, keys)
+}
+
+func TestFloat64Codec(t *testing.T) {
+	src := avlts.New[float64, float64]()
+	for _, k := range []float64{-1.5, 0, 2.25} {
+		avlts.Insert(src, k, k*2)
+	}
+	var buf bytes.Buffer
+	require.NoError(t, ondisk.Write(&buf, src, ondisk.Float64Codec{}, ondisk.Float64Codec{}))
+	tree, err := ondisk.FromBytes(buf.Bytes(), ondisk.Float64Codec{}, ondisk.Float64Codec{})
+	require.NoError(t, err)
+
+	v, ok := ondisk.Search(tree, -1.5)
+	require.True(t, ok)
+	assert.Equal(t, -3.0, v)
+}
+
+func TestFixedWidthCodecs(t *testing.T) {
+	src := avlts.New[string, string]()
+	avlts.Insert(src, "a", "short")
+	var buf bytes.Buffer
+	require.NoError(t, ondisk.Write(&buf, src, ondisk.StringCodec{}, ondisk.StringCodec{}))
+
+	_, err := ondisk.FromBytes(buf.Bytes(), ondisk.StringCodec{}, ondisk.Int64Codec{})
+	assert.ErrorIs(t, err, ondisk.ErrFormat, "a 5-byte value is not an int64")
+	_, err = ondisk.FromBytes(buf.Bytes(), ondisk.Float64Codec{}, ondisk.StringCodec{})
+	assert.ErrorIs(t, err, ondisk.ErrFormat, "a 1-byte key is not a float64")
+	_, err = ondisk.FromBytes(buf.Bytes(), ondisk.StringCodec{}, ondisk.StringCodec{})
+	assert.NoError(t, err)
+}
+
+// largestWrite records the size of the largest Write it receives.
+type largestWrite struct {
+	bytes.Buffer
+	max int
+}
+
+func (w *largestWrite) Write(p []byte) (int, error) {
+	w.max = max(w.max, len(p))
+	return w.Buffer.Write(p)
+}
+
+func TestWriteStreams(t *testing.T) {
+	src := avlts.New[string, int64]()
+	for i := range 10000 {
+		avlts.Insert(src, fmt.Sprintf("key%05d", i), int64(i))
+	}
+	var w largestWrite
+	require.NoError(t, ondisk.Write(&w, src, ondisk.StringCodec{}, ondisk.Int64Codec{}))
+	assert.LessOrEqual(t, w.max, 4096, "Write should not buffer the whole file")
+
+	tree, err := ondisk.FromBytes(w.Bytes(), ondisk.StringCodec{}, ondisk.Int64Codec{})
+	require.NoError(t, err)
+	assert.Equal(t, 10000, ondisk.Len(tree))
+	for _, i := range []int{0, 1, 4999, 9999} {
+		k, v, ok := ondisk.Kth(tree, i)
+		require.True(t, ok)
+		assert.Equal(t, fmt.Sprintf("key%05d", i), k)
+		assert.Equal(t, int64(i), v)
+	}
+}
+
+func ExampleOpen() {
+	src := avlts.New[string, int64]()
+	avlts.Insert(src, "b", 2)
+	avlts.Insert(src, "a", 1)
+
+	path := filepath.Join(os.TempDir(), "ondisk-example.avld")
+	defer os.Remove(path)
+	f, _ := os.Create(path)
+	ondisk.Write(f, src, ondisk.StringCodec{}, ondisk.Int64Codec{})
+	f.Close()
+
+	tree, _ := ondisk.Open(path, ondisk.StringCodec{}, ondisk.Int64Codec{})
+	defer ondisk.Close(tree)
+	v, ok := ondisk.Search(tree, "b")
+	fmt.Println(v, ok, ondisk.Rank(tree, "b"))
+	// Output: 2 true 1
+}
@@ -0,0 +1,130 @@
+package avltrees
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// ErrOpSequence is returned by Replay when an op does not directly follow
+// the previously applied one.
+var ErrOpSequence = errors.New("avltrees: op out of sequence")
+
+// OpKind is the kind of change recorded in an Op.
+type OpKind uint8
+
+const (
+	// OpInsert sets Key to Value, whether or not the key existed.
+	OpInsert OpKind = iota + 1
+	// OpDelete removes Key.
+	OpDelete
+)
+
+// Op is one recorded change to a tree. Seq numbers the operations of an
+// OpLog consecutively from 1.
+type Op[K cmp.Ordered, V any] struct {
+	Seq   uint64
+	Kind  OpKind
+	Key   K
+	Value V
+}
+
+// OpLog records the mutations of a tree in memory, so that they can be
+// shipped to followers and applied there with Replay. Unlike WAL, it does
+// no encoding; callers serialize the ops in whatever format they need.
+type OpLog[K cmp.Ordered, V any] struct {
+	ops    []Op[K, V]
+	seq    uint64
+	limit  int
+	detach []func()
+}
+
+// EnableOpLog starts recording every mutation of the AVL tree.
+// If limit is positive, only the most recent limit ops are kept; a
+// follower that falls further behind must be resynchronized from a full
+// copy of the tree.
+func EnableOpLog[K cmp.Ordered, V any](t *Tree[K, V], limit int) *OpLog[K, V] {
+	l := &OpLog[K, V]{limit: limit}
+	l.detach = []func(){
+		OnInsert(t, func(key K, value V) { l.record(OpInsert, key, value) }),
+		OnUpdate(t, func(key K, _, value V) { l.record(OpInsert, key, value) }),
+		OnDelete(t, func(key K, _ V) {
+			var zero V
+			l.record(OpDelete, key, zero)
+		}),
+	}
+	return l
+}
+
+// Seq returns the sequence number of the last recorded op, or 0 if none.
+func (l *OpLog[K, V]) Seq() uint64 {
+	return l.seq
+}
+
+// Since returns the ops recorded after sequence number seq, in order.
+// Returns false if some of them have already been dropped because of the
+// log's limit.
+func (l *OpLog[K, V]) Since(seq uint64) ([]Op[K, V], bool) {
+	if seq >= l.seq {
+		return nil, true
+	}
+	first := l.seq - uint64(len(l.ops)) + 1
+	if seq+1 < first {
+		return nil, false
+	}
+	return slices.Clone(l.ops[seq+1-first:]), true
+}
+
+// Detach stops recording mutations of the tree.
+func (l *OpLog[K, V]) Detach() {
+	for _, remove := range l.detach {
+		remove()
+	}
+	l.detach = nil
+}
+
+func (l *OpLog[K, V]) record(kind OpKind, key K, value V) {
+	l.seq++
+	l.ops = append(l.ops, Op[K, V]{l.seq, kind, key, value})
+	if l.limit > 0 && len(l.ops) > l.limit {
+		n := len(l.ops) - l.limit
+		clear(l.ops[:n])
+		l.ops = l.ops[n:]
+	}
+}
+
+// Replay applies ops, as exported by OpLog.Since, to the AVL tree in
+// order. Each op must have the sequence number following the previous
+// one, starting right after AppliedSeq(t); a tree that has not replayed
+// any ops yet accepts any starting number, so that a follower seeded with
+// a copy of the leader can start at the leader's position.
+//
+// Replay stops at the first op that is out of sequence, returning an
+// error wrapping ErrOpSequence, or that has an unknown kind. The ops
+// before it stay applied, and AppliedSeq reports the last of them, so the
+// follower can resume from there.
+func Replay[K cmp.Ordered, V any](t *Tree[K, V], ops iter.Seq[Op[K, V]]) error {
+	for op := range ops {
+		if t.appliedSeq != 0 && op.Seq != t.appliedSeq+1 {
+			return fmt.Errorf("%w: expected %d, got %d", ErrOpSequence, t.appliedSeq+1, op.Seq)
+		}
+		switch op.Kind {
+		case OpInsert:
+			Insert(t, op.Key, op.Value)
+		case OpDelete:
+			Delete(t, op.Key)
+		default:
+			return fmt.Errorf("avltrees: unknown op kind %d", op.Kind)
+		}
+		t.appliedSeq = op.Seq
+	}
+	return nil
+}
+
+// AppliedSeq returns the sequence number of the last op applied to the
+// AVL tree by Replay, or 0 if none.
+func AppliedSeq[K cmp.Ordered, V any](t *Tree[K, V]) uint64 {
+	return t.appliedSeq
+}
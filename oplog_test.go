@@ -0,0 +1,158 @@
+package avltrees_test
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type strOp = avlts.Op[string, int]
+
+func TestEnableOpLog(t *testing.T) {
+	tree := avlts.New[string, int]()
+	log := avlts.EnableOpLog(tree, 0)
+	assert.Equal(t, uint64(0), log.Seq())
+
+	avlts.Insert(tree, "a", 1)
+	avlts.Insert(tree, "b", 2)
+	avlts.Insert(tree, "a", 3)
+	avlts.Delete(tree, "b")
+	avlts.Delete(tree, "missing")
+
+	ops, ok := log.Since(0)
+	require.True(t, ok)
+	assert.Equal(t, []strOp{
+		{Seq: 1, Kind: avlts.OpInsert, Key: "a", Value: 1},
+		{Seq: 2, Kind: avlts.OpInsert, Key: "b", Value: 2},
+		{Seq: 3, Kind: avlts.OpInsert, Key: "a", Value: 3},
+		{Seq: 4, Kind: avlts.OpDelete, Key: "b"},
+	}, ops)
+	assert.Equal(t, uint64(4), log.Seq())
+
+	ops, ok = log.Since(2)
+	require.True(t, ok)
+	assert.Len(t, ops, 2)
+	assert.Equal(t, uint64(3), ops[0].Seq)
+
+	ops, ok = log.Since(4)
+	assert.True(t, ok)
+	assert.Empty(t, ops)
+
+	log.Detach()
+	avlts.Insert(tree, "c", 4)
+	assert.Equal(t, uint64(4), log.Seq())
+}
+
+func TestOpLogBatchOperations(t *testing.T) {
+	tree := avlts.New[string, int]()
+	avlts.Insert(tree, "a", 1)
+	log := avlts.EnableOpLog(tree, 0)
+
+	avlts.InsertBatch(tree, []avlts.Item[string, int]{{Key: "a", Value: 10}, {Key: "b", Value: 2}})
+	avlts.Clear(tree)
+
+	ops, _ := log.Since(0)
+	assert.Equal(t, []strOp{
+		{Seq: 1, Kind: avlts.OpInsert, Key: "a", Value: 10},
+		{Seq: 2, Kind: avlts.OpInsert, Key: "b", Value: 2},
+		{Seq: 3, Kind: avlts.OpDelete, Key: "a"},
+		{Seq: 4, Kind: avlts.OpDelete, Key: "b"},
+	}, ops)
+}
+
+func TestOpLogLimit(t *testing.T) {
+	tree := avlts.New[int, int]()
+	log := avlts.EnableOpLog(tree, 3)
+	for i := range 10 {
+		avlts.Insert(tree, i, i)
+	}
+	assert.Equal(t, uint64(10), log.Seq())
+
+	ops, ok := log.Since(7)
+	require.True(t, ok)
+	assert.Equal(t, []uint64{8, 9, 10}, []uint64{ops[0].Seq, ops[1].Seq, ops[2].Seq})
+
+	_, ok = log.Since(6)
+	assert.False(t, ok, "op 7 has been dropped")
+}
+
+func ExampleEnableOpLog() {
+	leader := avlts.New[string, string]()
+	log := avlts.EnableOpLog(leader, 1000)
+
+	avlts.Insert(leader, "region", "eu")
+	avlts.Insert(leader, "tier", "gold")
+	avlts.Delete(leader, "region")
+
+	ops, _ := log.Since(1)
+	for _, op := range ops {
+		fmt.Println(op.Seq, op.Kind == avlts.OpDelete, op.Key)
+	}
+	// Output:
+	// 2 false tier
+	// 3 true region
+}
+
+func TestReplay(t *testing.T) {
+	leader := avlts.New[string, int]()
+	log := avlts.EnableOpLog(leader, 0)
+	follower := avlts.New[string, int]()
+
+	avlts.Insert(leader, "a", 1)
+	avlts.Insert(leader, "b", 2)
+	ops, _ := log.Since(avlts.AppliedSeq(follower))
+	require.NoError(t, avlts.Replay(follower, slices.Values(ops)))
+	assert.Equal(t, uint64(2), avlts.AppliedSeq(follower))
+
+	avlts.Delete(leader, "a")
+	avlts.Insert(leader, "c", 3)
+	ops, _ = log.Since(avlts.AppliedSeq(follower))
+	require.NoError(t, avlts.Replay(follower, slices.Values(ops)))
+	assert.Equal(t, uint64(4), avlts.AppliedSeq(follower))
+	assert.Zero(t, avlts.Compare(leader, follower, cmp.Compare[int]))
+
+	// Replaying the same ops again is rejected.
+	err := avlts.Replay(follower, slices.Values(ops))
+	assert.ErrorIs(t, err, avlts.ErrOpSequence)
+	assert.Equal(t, uint64(4), avlts.AppliedSeq(follower))
+}
+
+func TestReplayGap(t *testing.T) {
+	tree := avlts.New[string, int]()
+	err := avlts.Replay(tree, slices.Values([]strOp{
+		{Seq: 5, Kind: avlts.OpInsert, Key: "a", Value: 1},
+		{Seq: 6, Kind: avlts.OpInsert, Key: "b", Value: 2},
+		{Seq: 8, Kind: avlts.OpInsert, Key: "c", Value: 3},
+	}))
+	assert.ErrorIs(t, err, avlts.ErrOpSequence)
+	assert.EqualError(t, err, "avltrees: op out of sequence: expected 7, got 8")
+	assert.Equal(t, uint64(6), avlts.AppliedSeq(tree), "ops before the gap stay applied")
+	assert.Equal(t, 2, avlts.Len(tree))
+}
+
+func TestReplayUnknownKind(t *testing.T) {
+	tree := avlts.New[string, int]()
+	err := avlts.Replay(tree, slices.Values([]strOp{{Seq: 1, Kind: 9, Key: "a"}}))
+	assert.EqualError(t, err, "avltrees: unknown op kind 9")
+	assert.Equal(t, uint64(0), avlts.AppliedSeq(tree))
+}
+
+func ExampleReplay() {
+	leader := avlts.New[string, int]()
+	log := avlts.EnableOpLog(leader, 0)
+	avlts.Insert(leader, "x", 1)
+	avlts.Insert(leader, "y", 2)
+
+	follower := avlts.New[string, int]()
+	ops, _ := log.Since(avlts.AppliedSeq(follower))
+	if err := avlts.Replay(follower, slices.Values(ops)); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(avlts.AppendKeys(follower, nil), avlts.AppliedSeq(follower))
+	// Output: [x y] 2
+}
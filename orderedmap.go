@@ -0,0 +1,261 @@
+package avltrees
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/byExist/avltrees/btree"
+	"github.com/byExist/avltrees/slab"
+)
+
+// OrderedMap is the map API common to the balanced-tree implementations in
+// this module. Code written against it can switch implementations with the
+// Backend passed to NewOrderedMap, for example to benchmark them on a real
+// workload, without changing call sites. Entries are returned as Items, so
+// no implementation's node type leaks through.
+type OrderedMap[K cmp.Ordered, V any] interface {
+	// Len returns the number of entries.
+	Len() int
+	// Get returns the value stored under key.
+	Get(key K) (V, bool)
+	// Put stores value under key and reports whether key was newly added.
+	Put(key K, value V) bool
+	// Delete removes key and reports whether it was present.
+	Delete(key K) bool
+	// Min returns the entry with the smallest key.
+	Min() (Item[K, V], bool)
+	// Max returns the entry with the largest key.
+	Max() (Item[K, V], bool)
+	// Floor returns the entry with the largest key less than or equal to key.
+	Floor(key K) (Item[K, V], bool)
+	// Ceiling returns the entry with the smallest key greater than or equal
+	// to key.
+	Ceiling(key K) (Item[K, V], bool)
+	// All returns an iterator over the entries in key order.
+	All() iter.Seq2[K, V]
+	// Range returns an iterator over the entries with keys in [from, to).
+	Range(from, to K) iter.Seq2[K, V]
+	// Rank returns the number of keys less than key.
+	Rank(key K) int
+	// Kth returns the entry with the given 0-based rank.
+	Kth(k int) (Item[K, V], bool)
+}
+
+// Backend selects the implementation behind an OrderedMap.
+type Backend int
+
+const (
+	// BackendAVL is a Tree: nodes are allocated individually and linked to
+	// their parents, and every package-level function works on it.
+	BackendAVL Backend = iota
+	// BackendSlab is a slab.Tree: nodes live in one growing slice and are
+	// linked by index, which uses less memory and puts less load on the
+	// garbage collector.
+	BackendSlab
+	// BackendBTree is a btree.Tree: each node holds up to 31 sorted
+	// entries, so lookups follow far fewer pointers. It suits read-heavy
+	// workloads on large maps.
+	BackendBTree
+)
+
+// NewOrderedMap returns a new empty OrderedMap implemented by backend.
+// It panics if backend is unknown.
+func NewOrderedMap[K cmp.Ordered, V any](backend Backend) OrderedMap[K, V] {
+	switch backend {
+	case BackendAVL:
+		return &avlMap[K, V]{}
+	case BackendSlab:
+		return &slabMap[K, V]{slab.New[K, V]()}
+	case BackendBTree:
+		return &btreeMap[K, V]{}
+	default:
+		panic("avltrees: unknown Backend")
+	}
+}
+
+type avlMap[K cmp.Ordered, V any] struct {
+	t Tree[K, V]
+}
+
+func (m *avlMap[K, V]) Len() int {
+	return Len(&m.t)
+}
+
+func (m *avlMap[K, V]) Get(key K) (V, bool) {
+	return Get(&m.t, key)
+}
+
+func (m *avlMap[K, V]) Put(key K, value V) bool {
+	return Insert(&m.t, key, value)
+}
+
+func (m *avlMap[K, V]) Delete(key K) bool {
+	return Delete(&m.t, key)
+}
+
+func (m *avlMap[K, V]) Min() (Item[K, V], bool) {
+	return itemOf(Min(&m.t))
+}
+
+func (m *avlMap[K, V]) Max() (Item[K, V], bool) {
+	return itemOf(Max(&m.t))
+}
+
+func (m *avlMap[K, V]) Floor(key K) (Item[K, V], bool) {
+	return itemOf(Floor(&m.t, key))
+}
+
+func (m *avlMap[K, V]) Ceiling(key K) (Item[K, V], bool) {
+	return itemOf(Ceiling(&m.t, key))
+}
+
+func (m *avlMap[K, V]) All() iter.Seq2[K, V] {
+	return pairs(InOrder(&m.t))
+}
+
+func (m *avlMap[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return pairs(Range(&m.t, from, to))
+}
+
+func (m *avlMap[K, V]) Rank(key K) int {
+	return Rank(&m.t, key)
+}
+
+func (m *avlMap[K, V]) Kth(k int) (Item[K, V], bool) {
+	return itemOf(Kth(&m.t, k))
+}
+
+func pairs[K cmp.Ordered, V any](seq iter.Seq[Node[K, V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := range seq {
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
+
+type slabMap[K cmp.Ordered, V any] struct {
+	t *slab.Tree[K, V]
+}
+
+func (m *slabMap[K, V]) Len() int {
+	return slab.Len(m.t)
+}
+
+func (m *slabMap[K, V]) Get(key K) (V, bool) {
+	return slab.Get(m.t, key)
+}
+
+func (m *slabMap[K, V]) Put(key K, value V) bool {
+	return slab.Insert(m.t, key, value)
+}
+
+func (m *slabMap[K, V]) Delete(key K) bool {
+	return slab.Delete(m.t, key)
+}
+
+func (m *slabMap[K, V]) Min() (Item[K, V], bool) {
+	return slabItem(slab.Min(m.t))
+}
+
+func (m *slabMap[K, V]) Max() (Item[K, V], bool) {
+	return slabItem(slab.Max(m.t))
+}
+
+func (m *slabMap[K, V]) Floor(key K) (Item[K, V], bool) {
+	return slabItem(slab.Floor(m.t, key))
+}
+
+func (m *slabMap[K, V]) Ceiling(key K) (Item[K, V], bool) {
+	return slabItem(slab.Ceiling(m.t, key))
+}
+
+func (m *slabMap[K, V]) All() iter.Seq2[K, V] {
+	return slabPairs(slab.InOrder(m.t))
+}
+
+func (m *slabMap[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return slabPairs(slab.Range(m.t, from, to))
+}
+
+func (m *slabMap[K, V]) Rank(key K) int {
+	return slab.Rank(m.t, key)
+}
+
+func (m *slabMap[K, V]) Kth(k int) (Item[K, V], bool) {
+	return slabItem(slab.Kth(m.t, k))
+}
+
+func slabItem[K cmp.Ordered, V any](n *slab.Node[K, V], ok bool) (Item[K, V], bool) {
+	if !ok {
+		return Item[K, V]{}, false
+	}
+	return Item[K, V]{n.Key(), n.Value()}, true
+}
+
+func slabPairs[K cmp.Ordered, V any](seq iter.Seq[slab.Node[K, V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := range seq {
+			if !yield(n.Key(), n.Value()) {
+				return
+			}
+		}
+	}
+}
+
+type btreeMap[K cmp.Ordered, V any] struct {
+	t btree.Tree[K, V]
+}
+
+func (m *btreeMap[K, V]) Len() int {
+	return btree.Len(&m.t)
+}
+
+func (m *btreeMap[K, V]) Get(key K) (V, bool) {
+	return btree.Get(&m.t, key)
+}
+
+func (m *btreeMap[K, V]) Put(key K, value V) bool {
+	return btree.Insert(&m.t, key, value)
+}
+
+func (m *btreeMap[K, V]) Delete(key K) bool {
+	return btree.Delete(&m.t, key)
+}
+
+func (m *btreeMap[K, V]) Min() (Item[K, V], bool) {
+	return btreeItem(btree.Min(&m.t))
+}
+
+func (m *btreeMap[K, V]) Max() (Item[K, V], bool) {
+	return btreeItem(btree.Max(&m.t))
+}
+
+func (m *btreeMap[K, V]) Floor(key K) (Item[K, V], bool) {
+	return btreeItem(btree.Floor(&m.t, key))
+}
+
+func (m *btreeMap[K, V]) Ceiling(key K) (Item[K, V], bool) {
+	return btreeItem(btree.Ceiling(&m.t, key))
+}
+
+func (m *btreeMap[K, V]) All() iter.Seq2[K, V] {
+	return btree.All(&m.t)
+}
+
+func (m *btreeMap[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return btree.Range(&m.t, from, to)
+}
+
+func (m *btreeMap[K, V]) Rank(key K) int {
+	return btree.Rank(&m.t, key)
+}
+
+func (m *btreeMap[K, V]) Kth(k int) (Item[K, V], bool) {
+	return btreeItem(btree.Kth(&m.t, k))
+}
+
+func btreeItem[K cmp.Ordered, V any](key K, value V, ok bool) (Item[K, V], bool) {
+	return Item[K, V]{key, value}, ok
+}
@@ -0,0 +1,147 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"math/rand"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var backends = map[string]avlts.Backend{
+	"AVL":   avlts.BackendAVL,
+	"Slab":  avlts.BackendSlab,
+	"BTree": avlts.BackendBTree,
+}
+
+func TestOrderedMap(t *testing.T) {
+	for name, backend := range backends {
+		t.Run(name, func(t *testing.T) {
+			m := avlts.NewOrderedMap[int, string](backend)
+			_, ok := m.Min()
+			assert.False(t, ok)
+
+			assert.True(t, m.Put(20, "b"))
+			assert.True(t, m.Put(10, "a"))
+			assert.True(t, m.Put(30, "c"))
+			assert.False(t, m.Put(20, "B"))
+			assert.Equal(t, 3, m.Len())
+
+			v, ok := m.Get(20)
+			assert.True(t, ok)
+			assert.Equal(t, "B", v)
+
+			it, ok := m.Min()
+			assert.True(t, ok)
+			assert.Equal(t, avlts.Item[int, string]{Key: 10, Value: "a"}, it)
+			it, _ = m.Max()
+			assert.Equal(t, 30, it.Key)
+			it, _ = m.Floor(25)
+			assert.Equal(t, 20, it.Key)
+			it, _ = m.Ceiling(25)
+			assert.Equal(t, 30, it.Key)
+			_, ok = m.Ceiling(31)
+			assert.False(t, ok)
+
+			assert.Equal(t, map[int]string{10: "a", 20: "B"}, maps.Collect(m.Range(0, 30)))
+			assert.Equal(t, 2, m.Rank(25))
+			it, ok = m.Kth(1)
+			assert.True(t, ok)
+			assert.Equal(t, 20, it.Key)
+			_, ok = m.Kth(3)
+			assert.False(t, ok)
+			assert.True(t, m.Delete(10))
+			assert.False(t, m.Delete(10))
+			assert.Equal(t, map[int]string{20: "B", 30: "c"}, maps.Collect(m.All()))
+		})
+	}
+
+	assert.Panics(t, func() { avlts.NewOrderedMap[int, int](avlts.Backend(-1)) })
+}
+
+func TestOrderedMapBackendsAgree(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	impls := map[string]avlts.OrderedMap[int, int]{}
+	for name, backend := range backends {
+		impls[name] = avlts.NewOrderedMap[int, int](backend)
+	}
+	ref := impls["AVL"]
+	for i := range 5000 {
+		k := r.Intn(500)
+		del := r.Intn(3) == 0
+		var want bool
+		if del {
+			want = ref.Delete(k)
+		} else {
+			want = ref.Put(k, i)
+		}
+		for name, m := range impls {
+			if name == "AVL" {
+				continue
+			}
+			if del {
+				require.Equal(t, want, m.Delete(k), name)
+			} else {
+				require.Equal(t, want, m.Put(k, i), name)
+			}
+		}
+	}
+	for name, m := range impls {
+		assert.Equal(t, entries(ref.All()), entries(m.All()), name)
+		for k := -1; k <= 500; k++ {
+			require.Equal(t, ref.Rank(k), m.Rank(k), name)
+		}
+	}
+}
+
+func entries(seq iter.Seq2[int, int]) []int {
+	var out []int
+	for k, v := range seq {
+		out = append(out, k, v)
+	}
+	return out
+}
+
+func benchmarkOrderedMap(b *testing.B, backend avlts.Backend) {
+	keys := rand.New(rand.NewSource(1)).Perm(1 << 16)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m := avlts.NewOrderedMap[int, int](backend)
+		for _, k := range keys {
+			m.Put(k, k)
+		}
+		for _, k := range keys {
+			m.Get(k)
+		}
+	}
+}
+
+func BenchmarkOrderedMapAVL(b *testing.B) {
+	benchmarkOrderedMap(b, avlts.BackendAVL)
+}
+
+func BenchmarkOrderedMapSlab(b *testing.B) {
+	benchmarkOrderedMap(b, avlts.BackendSlab)
+}
+
+func BenchmarkOrderedMapBTree(b *testing.B) {
+	benchmarkOrderedMap(b, avlts.BackendBTree)
+}
+
+func ExampleNewOrderedMap() {
+	for _, backend := range []avlts.Backend{avlts.BackendAVL, avlts.BackendSlab, avlts.BackendBTree} {
+		m := avlts.NewOrderedMap[string, int](backend)
+		m.Put("b", 2)
+		m.Put("a", 1)
+		first, _ := m.Min()
+		fmt.Println(first.Key, m.Len())
+	}
+	// Output:
+	// a 2
+	// a 2
+	// a 2
+}
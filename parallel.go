@@ -0,0 +1,142 @@
+package avltrees
+
+import (
+	"cmp"
+	"math/bits"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// parallelCutoff is the smallest slice worth handing to another goroutine
+// when sorting or building in parallel.
+const parallelCutoff = 1 << 13
+
+// BuildParallel returns a new AVL tree holding entries, using up to workers
+// goroutines to sort the entries and to link the tree, one subtree per
+// goroutine. When the same key appears more than once, the last one wins.
+// If workers is not positive, GOMAXPROCS goroutines are used.
+// entries is not modified.
+func BuildParallel[K cmp.Ordered, V any](entries []Item[K, V], workers int, opts ...Option) *Tree[K, V] {
+	t := newTree[K, V](opts)
+	if len(entries) == 0 {
+		return t
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	items := sortItemsParallel(slices.Clone(entries), workers)
+
+	// Keep the last of each run of equal keys.
+	w := 0
+	for i := range items {
+		if i+1 < len(items) && items[i+1].Key == items[i].Key {
+			continue
+		}
+		items[w] = items[i]
+		w++
+	}
+	items = items[:w]
+
+	t.Root = buildFromItems(t, items, nil, bits.Len(uint(workers-1)))
+	t.count = len(items)
+	t.version++
+	recordInserts(t, len(items))
+	return t
+}
+
+// sortItemsParallel stable-sorts items by key. Chunks are sorted
+// concurrently and then merged pairwise, also concurrently.
+func sortItemsParallel[K cmp.Ordered, V any](items []Item[K, V], workers int) []Item[K, V] {
+	byKey := func(a, b Item[K, V]) int { return cmp.Compare(a.Key, b.Key) }
+	chunk := max((len(items)+workers-1)/workers, parallelCutoff)
+	if chunk >= len(items) {
+		slices.SortStableFunc(items, byKey)
+		return items
+	}
+
+	var runs [][]Item[K, V]
+	for lo := 0; lo < len(items); lo += chunk {
+		runs = append(runs, items[lo:min(lo+chunk, len(items))])
+	}
+	var wg sync.WaitGroup
+	for _, run := range runs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slices.SortStableFunc(run, byKey)
+		}()
+	}
+	wg.Wait()
+
+	buf := make([]Item[K, V], len(items))
+	for len(runs) > 1 {
+		merged := make([][]Item[K, V], 0, (len(runs)+1)/2)
+		off := 0
+		for i := 0; i < len(runs); i += 2 {
+			if i+1 == len(runs) {
+				dst := buf[off : off+len(runs[i])]
+				copy(dst, runs[i])
+				merged = append(merged, dst)
+				break
+			}
+			a, b := runs[i], runs[i+1]
+			dst := buf[off : off+len(a)+len(b)]
+			off += len(dst)
+			merged = append(merged, dst)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				mergeItems(dst, a, b)
+			}()
+		}
+		wg.Wait()
+		runs = merged
+		items, buf = buf, items
+	}
+	return runs[0]
+}
+
+// mergeItems merges the sorted slices a and b into dst, taking from a
+// first on equal keys so that the merge is stable.
+func mergeItems[K cmp.Ordered, V any](dst, a, b []Item[K, V]) {
+	i, j := 0, 0
+	for k := range dst {
+		if j == len(b) || i < len(a) && a[i].Key <= b[j].Key {
+			dst[k] = a[i]
+			i++
+		} else {
+			dst[k] = b[j]
+			j++
+		}
+	}
+}
+
+// buildFromItems links sorted, distinct items into a balanced subtree and
+// returns its root. The left subtrees of the top depth levels are built
+// on their own goroutines; t has no preallocated nodes, so allocNode is
+// safe to call from all of them.
+func buildFromItems[K cmp.Ordered, V any](t *Tree[K, V], items []Item[K, V], parent *Node[K, V], depth int) *Node[K, V] {
+	if len(items) == 0 {
+		return nil
+	}
+	mid := len(items) / 2
+	n := allocNode(t)
+	n.key, n.value, n.parent = items[mid].Key, items[mid].Value, parent
+	if depth > 0 && len(items) >= parallelCutoff {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n.left = buildFromItems(t, items[:mid], n, depth-1)
+		}()
+		n.right = buildFromItems(t, items[mid+1:], n, depth-1)
+		wg.Wait()
+	} else {
+		n.left = buildFromItems(t, items[:mid], n, 0)
+		n.right = buildFromItems(t, items[mid+1:], n, 0)
+	}
+	n.balance = medianBalance(len(items))
+	updateSize(t, n)
+	return n
+}
@@ -0,0 +1,91 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildParallel(t *testing.T) {
+	tree := avlts.BuildParallel([]avlts.Item[int, string]{
+		{Key: 3, Value: "c"}, {Key: 1, Value: "a"}, {Key: 2, Value: "b"}, {Key: 1, Value: "A"},
+	}, 4)
+	assert.Equal(t, []int{1, 2, 3}, treeKeys(tree))
+	v, _ := avlts.Get(tree, 1)
+	assert.Equal(t, "A", v, "the last duplicate wins")
+	assert.NoError(t, avlts.Validate(tree))
+
+	empty := avlts.BuildParallel[int, string](nil, 0)
+	assert.Equal(t, 0, avlts.Len(empty))
+	assert.Nil(t, empty.Root)
+}
+
+func TestBuildParallelLarge(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	entries := make([]avlts.Item[int, int], 200_000)
+	for i := range entries {
+		entries[i] = avlts.Item[int, int]{Key: r.IntN(100_000), Value: i}
+	}
+	orig := append([]avlts.Item[int, int](nil), entries...)
+
+	want := avlts.New[int, int]()
+	avlts.InsertBatch(want, entries)
+
+	for _, workers := range []int{0, 1, 3, 8} {
+		t.Run(fmt.Sprint("workers=", workers), func(t *testing.T) {
+			got := avlts.BuildParallel(entries, workers)
+			require.NoError(t, avlts.Validate(got))
+			assert.Equal(t, avlts.Len(want), avlts.Len(got))
+			assert.Zero(t, avlts.Compare(want, got, func(a, b int) int { return a - b }))
+		})
+	}
+	assert.Equal(t, orig, entries, "entries are not modified")
+}
+
+func TestBuildParallelOptions(t *testing.T) {
+	entries := make([]avlts.Item[int, int], 50_000)
+	for i := range entries {
+		entries[i] = avlts.Item[int, int]{Key: len(entries) - i, Value: 1}
+	}
+	tree := avlts.BuildParallel(entries, 4, avlts.WithPrefixSums[int]())
+	require.NoError(t, avlts.Validate(tree))
+	sum, ok := avlts.RangeSum(tree, 100, 200)
+	require.True(t, ok)
+	assert.Equal(t, 100, sum)
+}
+
+func ExampleBuildParallel() {
+	entries := []avlts.Item[string, int]{
+		{Key: "carol", Value: 3}, {Key: "alice", Value: 1}, {Key: "bob", Value: 2},
+	}
+	tree := avlts.BuildParallel(entries, 0)
+	for n := range avlts.InOrder(tree) {
+		fmt.Println(n.Key(), n.Value())
+	}
+	// Output:
+	// alice 1
+	// bob 2
+	// carol 3
+}
+
+func BenchmarkBuildParallel(b *testing.B) {
+	r := rand.New(rand.NewPCG(1, 1))
+	entries := make([]avlts.Item[int, int], 1_000_000)
+	for i := range entries {
+		entries[i] = avlts.Item[int, int]{Key: r.Int(), Value: i}
+	}
+	b.Run("InsertBatch", func(b *testing.B) {
+		for range b.N {
+			avlts.InsertBatch(avlts.New[int, int](), entries)
+		}
+	})
+	b.Run("BuildParallel", func(b *testing.B) {
+		for range b.N {
+			avlts.BuildParallel(entries, 0)
+		}
+	})
+}
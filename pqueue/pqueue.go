@@ -0,0 +1,115 @@
+// Package pqueue provides a priority queue backed by an AVL tree.
+//
+// Unlike a binary heap, the queue supports changing the priority of an item
+// and removing an arbitrary item in O(log n), and items of equal priority
+// are dequeued in insertion order. Each priority holds its items in a
+// second tree ordered by a queueing sequence number, so operations stay
+// O(log n) however many items share a priority.
+package pqueue
+
+import (
+	"cmp"
+
+	avlts "github.com/byExist/avltrees"
+)
+
+// Queue is a min-priority queue of distinct items.
+type Queue[T comparable, P cmp.Ordered] struct {
+	buckets avlts.Tree[P, *avlts.Tree[uint64, T]] // priority -> items by queueing sequence
+	queued  map[T]slot[P]
+	seq     uint64
+}
+
+// slot locates a queued item in its bucket.
+type slot[P cmp.Ordered] struct {
+	priority P
+	seq      uint64
+}
+
+// New returns a new empty Queue.
+func New[T comparable, P cmp.Ordered]() *Queue[T, P] {
+	return &Queue[T, P]{queued: map[T]slot[P]{}}
+}
+
+// Len returns the number of items in the queue.
+func (q *Queue[T, P]) Len() int {
+	return len(q.queued)
+}
+
+// Push adds item with the given priority.
+// Returns false and leaves the queue unchanged if item is already queued;
+// use Update to change its priority.
+func (q *Queue[T, P]) Push(item T, priority P) bool {
+	if _, ok := q.queued[item]; ok {
+		return false
+	}
+	q.seq++
+	q.queued[item] = slot[P]{priority, q.seq}
+	bucket := avlts.Entry(&q.buckets, priority).OrInsertWith(func() *avlts.Tree[uint64, T] {
+		return avlts.New[uint64, T]()
+	})
+	avlts.Insert(bucket.Value(), q.seq, item)
+	return true
+}
+
+// PeekMin returns the item with the lowest priority without removing it.
+func (q *Queue[T, P]) PeekMin() (item T, priority P, ok bool) {
+	n, ok := avlts.Min(&q.buckets)
+	if !ok {
+		return item, priority, false
+	}
+	first, _ := avlts.Min(n.Value())
+	return first.Value(), n.Key(), true
+}
+
+// PopMin removes and returns the item with the lowest priority.
+// Items with equal priority are popped in the order they were queued.
+func (q *Queue[T, P]) PopMin() (item T, priority P, ok bool) {
+	n, ok := avlts.Min(&q.buckets)
+	if !ok {
+		return item, priority, false
+	}
+	first, _ := avlts.PollFirstEntry(n.Value())
+	if avlts.Len(n.Value()) == 0 {
+		avlts.Delete(&q.buckets, n.Key())
+	}
+	delete(q.queued, first.Value)
+	return first.Value, n.Key(), true
+}
+
+// Priority returns the priority of item, if it is queued.
+func (q *Queue[T, P]) Priority(item T) (P, bool) {
+	s, ok := q.queued[item]
+	return s.priority, ok
+}
+
+// Contains reports whether item is queued.
+func (q *Queue[T, P]) Contains(item T) bool {
+	_, ok := q.queued[item]
+	return ok
+}
+
+// Update changes the priority of a queued item, in either direction.
+// The item moves behind any items already queued at the new priority.
+// Returns false if item is not queued.
+func (q *Queue[T, P]) Update(item T, priority P) bool {
+	if !q.Remove(item) {
+		return false
+	}
+	return q.Push(item, priority)
+}
+
+// Remove removes item from the queue. Returns false if item is not queued.
+func (q *Queue[T, P]) Remove(item T) bool {
+	s, ok := q.queued[item]
+	if !ok {
+		return false
+	}
+	delete(q.queued, item)
+	n, _ := avlts.Search(&q.buckets, s.priority)
+	avlts.Delete(n.Value(), s.seq)
+	if avlts.Len(n.Value()) == 0 {
+		avlts.Delete(&q.buckets, s.priority)
+	}
+	return true
+}
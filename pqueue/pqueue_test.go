@@ -0,0 +1,156 @@
+package pqueue_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/byExist/avltrees/pqueue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPush(t *testing.T) {
+	q := pqueue.New[string, int]()
+	assert.True(t, q.Push("a", 3))
+	assert.True(t, q.Push("b", 1))
+	assert.False(t, q.Push("a", 0), "duplicate items are rejected")
+	assert.Equal(t, 2, q.Len())
+
+	p, ok := q.Priority("a")
+	require.True(t, ok)
+	assert.Equal(t, 3, p)
+	assert.True(t, q.Contains("b"))
+	assert.False(t, q.Contains("c"))
+}
+
+func TestPeekMin(t *testing.T) {
+	q := pqueue.New[string, int]()
+	_, _, ok := q.PeekMin()
+	assert.False(t, ok)
+
+	q.Push("a", 3)
+	q.Push("b", 1)
+	item, p, ok := q.PeekMin()
+	require.True(t, ok)
+	assert.Equal(t, "b", item)
+	assert.Equal(t, 1, p)
+	assert.Equal(t, 2, q.Len())
+}
+
+func TestPopMin(t *testing.T) {
+	q := pqueue.New[string, int]()
+	q.Push("c", 2)
+	q.Push("a", 1)
+	q.Push("d", 2)
+	q.Push("b", 1)
+
+	var order []string
+	for {
+		item, _, ok := q.PopMin()
+		if !ok {
+			break
+		}
+		order = append(order, item)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d"}, order, "ties pop in FIFO order")
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestUpdate(t *testing.T) {
+	q := pqueue.New[string, int]()
+	q.Push("a", 5)
+	q.Push("b", 3)
+
+	assert.True(t, q.Update("a", 1))
+	item, _, _ := q.PeekMin()
+	assert.Equal(t, "a", item)
+
+	assert.True(t, q.Update("a", 10))
+	item, _, _ = q.PeekMin()
+	assert.Equal(t, "b", item)
+
+	assert.False(t, q.Update("z", 0))
+	assert.Equal(t, 2, q.Len())
+}
+
+func TestRemove(t *testing.T) {
+	q := pqueue.New[string, int]()
+	q.Push("a", 1)
+	q.Push("b", 1)
+	q.Push("c", 2)
+
+	assert.True(t, q.Remove("a"))
+	assert.False(t, q.Remove("a"))
+	item, _, _ := q.PeekMin()
+	assert.Equal(t, "b", item)
+
+	assert.True(t, q.Remove("b"))
+	item, p, _ := q.PeekMin()
+	assert.Equal(t, "c", item)
+	assert.Equal(t, 2, p)
+}
+
+func TestEqualPriority(t *testing.T) {
+	q := pqueue.New[int, int]()
+	for i := range 1000 {
+		q.Push(i, 0)
+	}
+	for i := 0; i < 1000; i += 2 {
+		require.True(t, q.Remove(i))
+	}
+	q.Update(1, 0)
+	for i := 3; i < 1000; i += 2 {
+		item, _, ok := q.PopMin()
+		require.True(t, ok)
+		assert.Equal(t, i, item)
+	}
+	item, _, _ := q.PopMin()
+	assert.Equal(t, 1, item, "Update moves the item to the back")
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	q := pqueue.New[int, int]()
+	want := map[int]int{}
+	for i := range 500 {
+		p := r.Intn(50)
+		q.Push(i, p)
+		want[i] = p
+		if i%7 == 0 {
+			victim := r.Intn(i + 1)
+			_, had := want[victim]
+			assert.Equal(t, had, q.Remove(victim))
+			delete(want, victim)
+		}
+	}
+	var got []int
+	for {
+		_, p, ok := q.PopMin()
+		if !ok {
+			break
+		}
+		got = append(got, p)
+	}
+	assert.Len(t, got, len(want))
+	assert.True(t, slices.IsSorted(got))
+}
+
+func Example() {
+	q := pqueue.New[string, int]()
+	q.Push("write report", 3)
+	q.Push("fix outage", 1)
+	q.Push("lunch", 2)
+	q.Update("write report", 0)
+
+	for q.Len() > 0 {
+		task, prio, _ := q.PopMin()
+		fmt.Println(prio, task)
+	}
+	// Output:
+	// 0 write report
+	// 1 fix outage
+	// 2 lunch
+}
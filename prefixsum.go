@@ -0,0 +1,52 @@
+package avltrees
+
+import "cmp"
+
+// WithPrefixSums makes the tree maintain the sum of the values in every
+// subtree, enabling PrefixSum and RangeSum in O(log n). The type parameter
+// V must match the tree's value type; New panics otherwise.
+func WithPrefixSums[V Number]() Option {
+	return func(o *options) {
+		o.sum = func(a, b V) V { return a + b }
+	}
+}
+
+// PrefixSum returns the sum of the values of all keys less than or equal to key.
+// Returns false if the tree was not created WithPrefixSums.
+func PrefixSum[K cmp.Ordered, V Number](t *Tree[K, V], key K) (V, bool) {
+	if t.aug == nil || t.aug.add == nil {
+		return 0, false
+	}
+	return sumBefore(t, key, true), true
+}
+
+// RangeSum returns the sum of the values of the keys in the range [from, to).
+// Returns false if the tree was not created WithPrefixSums.
+func RangeSum[K cmp.Ordered, V Number](t *Tree[K, V], from, to K) (V, bool) {
+	if t.aug == nil || t.aug.add == nil {
+		return 0, false
+	}
+	if from >= to {
+		return 0, true
+	}
+	return sumBefore(t, to, false) - sumBefore(t, from, false), true
+}
+
+// sumBefore returns the sum of the values of the keys less than key,
+// or less than or equal to key if inclusive is set.
+func sumBefore[K cmp.Ordered, V Number](t *Tree[K, V], key K, inclusive bool) V {
+	var sum V
+	curr := t.Root
+	for curr != nil {
+		if key < curr.key || (key == curr.key && !inclusive) {
+			curr = curr.left
+			continue
+		}
+		if curr.left != nil {
+			sum += ext(curr.left).sum
+		}
+		sum += curr.value
+		curr = curr.right
+	}
+	return sum
+}
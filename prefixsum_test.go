@@ -0,0 +1,91 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixSum(t *testing.T) {
+	tree := treeOf([]int{10, 20, 30, 40}, []int{1, 2, 3, 4}, avlts.WithPrefixSums[int]())
+	for key, want := range map[int]int{5: 0, 10: 1, 15: 1, 30: 6, 40: 10, 99: 10} {
+		sum, ok := avlts.PrefixSum(tree, key)
+		require.True(t, ok)
+		assert.Equal(t, want, sum, "key=%d", key)
+	}
+
+	avlts.Insert(tree, 20, 100)
+	sum, _ := avlts.PrefixSum(tree, 25)
+	assert.Equal(t, 101, sum)
+	avlts.Delete(tree, 10)
+	sum, _ = avlts.PrefixSum(tree, 25)
+	assert.Equal(t, 100, sum)
+
+	_, ok := avlts.PrefixSum(avlts.New[int, int](), 1)
+	assert.False(t, ok)
+	assert.Panics(t, func() { avlts.New[int, float64](avlts.WithPrefixSums[int]()) })
+}
+
+func TestRangeSum(t *testing.T) {
+	tree := treeOf([]int{10, 20, 30, 40}, []int{1, 2, 3, 4}, avlts.WithPrefixSums[int]())
+	for _, tt := range []struct{ from, to, want int }{
+		{10, 30, 3},
+		{11, 41, 9},
+		{0, 100, 10},
+		{30, 30, 0},
+		{40, 10, 0},
+	} {
+		sum, ok := avlts.RangeSum(tree, tt.from, tt.to)
+		require.True(t, ok)
+		assert.Equal(t, tt.want, sum, "[%d, %d)", tt.from, tt.to)
+	}
+	_, ok := avlts.RangeSum(avlts.New[int, int](), 0, 1)
+	assert.False(t, ok)
+}
+
+func TestPrefixSumRandomized(t *testing.T) {
+	rng := rand.New(rand.NewPCG(7, 8))
+	tree := avlts.New[int, int64](avlts.WithPrefixSums[int64]())
+	oracle := map[int]int64{}
+	for range 3000 {
+		k := rng.IntN(200)
+		if rng.IntN(3) == 0 {
+			avlts.Delete(tree, k)
+			delete(oracle, k)
+		} else {
+			v := rng.Int64N(1000) - 500
+			avlts.Insert(tree, k, v)
+			oracle[k] = v
+		}
+	}
+	var want int64
+	for k := range 200 {
+		want += oracle[k]
+		got, _ := avlts.PrefixSum(tree, k)
+		require.Equal(t, want, got, "key=%d", k)
+	}
+}
+
+func ExamplePrefixSum() {
+	sales := avlts.New[string, float64](avlts.WithPrefixSums[float64]()) // date -> revenue
+	avlts.Insert(sales, "2024-01-01", 120)
+	avlts.Insert(sales, "2024-01-02", 80.5)
+	avlts.Insert(sales, "2024-01-03", 42)
+
+	toDate, _ := avlts.PrefixSum(sales, "2024-01-02")
+	fmt.Println(toDate)
+	// Output:
+	// 200.5
+}
+
+func ExampleRangeSum() {
+	tree := treeOf([]int{10, 20, 30, 40}, []int{1, 2, 3, 4}, avlts.WithPrefixSums[int]())
+	sum, _ := avlts.RangeSum(tree, 20, 40)
+	fmt.Println(sum)
+	// Output:
+	// 5
+}
@@ -0,0 +1,93 @@
+package avltrees
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// String returns an indented ASCII rendering of the AVL tree.
+// It implements fmt.Stringer and is equivalent to Sprint(t).
+func (t *Tree[K, V]) String() string {
+	return Sprint(t)
+}
+
+// Sprint returns an indented ASCII rendering of the AVL tree structure.
+// Each line shows a key followed by the height and balance factor of its node.
+// Left children are printed before right children.
+func Sprint[K cmp.Ordered, V any](t *Tree[K, V]) string {
+	if t.Root == nil {
+		return "<empty>\n"
+	}
+	var sb strings.Builder
+	writeNode(&sb, t.Root)
+	sprintChildren(&sb, t.Root, "")
+	return sb.String()
+}
+
+func sprintChildren[K cmp.Ordered, V any](sb *strings.Builder, n *Node[K, V], prefix string) {
+	if n.left == nil && n.right == nil {
+		return
+	}
+	sprintChild(sb, n.left, "L", prefix, false)
+	sprintChild(sb, n.right, "R", prefix, true)
+}
+
+func sprintChild[K cmp.Ordered, V any](sb *strings.Builder, n *Node[K, V], side, prefix string, last bool) {
+	branch, indent := "|-- ", "|   "
+	if last {
+		branch, indent = "`-- ", "    "
+	}
+	sb.WriteString(prefix)
+	sb.WriteString(branch)
+	sb.WriteString(side)
+	sb.WriteString(": ")
+	if n == nil {
+		sb.WriteString("<nil>\n")
+		return
+	}
+	writeNode(sb, n)
+	sprintChildren(sb, n, prefix+indent)
+}
+
+func writeNode[K cmp.Ordered, V any](sb *strings.Builder, n *Node[K, V]) {
+	fmt.Fprintf(sb, "%v [h=%d bf=%d]\n", n.key, height(n), balanceFactor(n))
+}
+
+// ToDOT writes a Graphviz DOT graph of the AVL tree to w.
+// Nodes are labeled with their key, height, and subtree size, which is
+// omitted for trees created with WithoutOrderStatistics.
+func ToDOT[K cmp.Ordered, V any](t *Tree[K, V], w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("digraph avltree {\n")
+	bw.WriteString("\tnode [shape=box];\n")
+	id := 0
+	var walk func(n *Node[K, V]) int
+	walk = func(n *Node[K, V]) int {
+		self := id
+		id++
+		label := fmt.Sprintf("%s\\nh=%d", dotEscaper.Replace(fmt.Sprint(n.key)), height(n))
+		if !t.noOrderStats {
+			label += fmt.Sprintf(" size=%d", n.size)
+		}
+		fmt.Fprintf(bw, "\tn%d [label=\"%s\"];\n", self, label)
+		if n.left != nil {
+			child := walk(n.left)
+			fmt.Fprintf(bw, "\tn%d -> n%d [label=\"L\"];\n", self, child)
+		}
+		if n.right != nil {
+			child := walk(n.right)
+			fmt.Fprintf(bw, "\tn%d -> n%d [label=\"R\"];\n", self, child)
+		}
+		return self
+	}
+	if t.Root != nil {
+		walk(t.Root)
+	}
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
@@ -0,0 +1,88 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSprint(t *testing.T) {
+	tree := avlts.New[int, string]()
+	assert.Equal(t, "<empty>\n", avlts.Sprint(tree))
+
+	for _, v := range []int{20, 10, 30, 5} {
+		avlts.Insert(tree, v, "")
+	}
+
+	expected := "20 [h=3 bf=1]\n" +
+		"|-- L: 10 [h=2 bf=1]\n" +
+		"|   |-- L: 5 [h=1 bf=0]\n" +
+		"|   `-- R: <nil>\n" +
+		"`-- R: 30 [h=1 bf=0]\n"
+	assert.Equal(t, expected, avlts.Sprint(tree))
+}
+
+func TestString(t *testing.T) {
+	tree := avlts.New[int, string]()
+	avlts.Insert(tree, 10, "")
+	avlts.Insert(tree, 20, "")
+
+	assert.Equal(t, avlts.Sprint(tree), tree.String())
+	assert.Equal(t, avlts.Sprint(tree), fmt.Sprint(tree))
+}
+
+func TestToDOT(t *testing.T) {
+	tree := avlts.New[string, int]()
+	var sb strings.Builder
+	require.NoError(t, avlts.ToDOT(tree, &sb))
+	assert.Equal(t, "digraph avltree {\n\tnode [shape=box];\n}\n", sb.String())
+
+	avlts.Insert(tree, "b", 0)
+	avlts.Insert(tree, `a"`, 0)
+	sb.Reset()
+	require.NoError(t, avlts.ToDOT(tree, &sb))
+	out := sb.String()
+	assert.Contains(t, out, "\tn0 [label=\"b\\nh=2 size=2\"];\n")
+	assert.Contains(t, out, "\tn1 [label=\"a\\\"\\nh=1 size=1\"];\n")
+	assert.Contains(t, out, "\tn0 -> n1 [label=\"L\"];\n")
+}
+
+func TestToDOTWithoutOrderStatistics(t *testing.T) {
+	tree := treeOf[int, struct{}]([]int{1, 2}, nil, avlts.WithoutOrderStatistics())
+	var sb strings.Builder
+	require.NoError(t, avlts.ToDOT(tree, &sb))
+	out := sb.String()
+	assert.Contains(t, out, "\tn0 [label=\"1\\nh=2\"];\n")
+	assert.NotContains(t, out, "size=")
+}
+
+func ExampleSprint() {
+	tree := avlts.New[int, string]()
+	for _, v := range []int{20, 10, 30} {
+		avlts.Insert(tree, v, "")
+	}
+	fmt.Print(avlts.Sprint(tree))
+	// Output:
+	// 20 [h=2 bf=0]
+	// |-- L: 10 [h=1 bf=0]
+	// `-- R: 30 [h=1 bf=0]
+}
+
+func ExampleToDOT() {
+	tree := avlts.New[int, string]()
+	avlts.Insert(tree, 10, "")
+	avlts.Insert(tree, 20, "")
+	avlts.ToDOT(tree, os.Stdout)
+	// Output:
+	// digraph avltree {
+	// 	node [shape=box];
+	// 	n0 [label="10\nh=2 size=2"];
+	// 	n1 [label="20\nh=1 size=1"];
+	// 	n0 -> n1 [label="R"];
+	// }
+}
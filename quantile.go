@@ -0,0 +1,138 @@
+package avltrees
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// Number is a constraint for keys that Percentile can interpolate between.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Interpolation selects how Percentile computes a result that falls
+// between two keys.
+type Interpolation int
+
+const (
+	// Linear interpolates linearly between the two surrounding keys.
+	Linear Interpolation = iota
+	// LowerKey returns the smaller of the two surrounding keys.
+	LowerKey
+	// HigherKey returns the larger of the two surrounding keys.
+	HigherKey
+	// Nearest returns the closer of the two surrounding keys,
+	// preferring the smaller one when they are equally close.
+	Nearest
+	// Midpoint returns the mean of the two surrounding keys.
+	Midpoint
+)
+
+// Quantile returns the node at the q-th quantile of the keys, for q in [0, 1].
+// The result is the node with rank floor(q * (Len(t) - 1)), so q = 0 yields
+// the minimum and q = 1 the maximum.
+// Returns false if the tree is empty, q is out of range, or the tree was
+// created WithoutOrderStatistics.
+func Quantile[K cmp.Ordered, V any](t *Tree[K, V], q float64) (*Node[K, V], bool) {
+	if !(q >= 0 && q <= 1) {
+		return nil, false
+	}
+	n := Len(t)
+	if n == 0 {
+		return nil, false
+	}
+	return Kth(t, int(math.Floor(q*float64(n-1))))
+}
+
+// Median returns the node with the median key. For an even number of keys
+// it returns the lower of the two middle nodes, matching Quantile(t, 0.5);
+// use Medians to get both.
+// Returns false if the tree is empty or was created WithoutOrderStatistics.
+func Median[K cmp.Ordered, V any](t *Tree[K, V]) (*Node[K, V], bool) {
+	return Kth(t, (Len(t)-1)/2)
+}
+
+// Medians returns the two middle nodes of the tree. For an odd number of
+// keys both are the single median node. The median of numeric keys is the
+// mean of the two keys.
+// Returns false if the tree is empty or was created WithoutOrderStatistics.
+func Medians[K cmp.Ordered, V any](t *Tree[K, V]) (lo, hi *Node[K, V], ok bool) {
+	lo, ok = Median(t)
+	if !ok {
+		return nil, nil, false
+	}
+	if Len(t)%2 == 1 {
+		return lo, lo, true
+	}
+	hi, _ = Successor(lo)
+	return lo, hi, true
+}
+
+// Percentile returns the p-th percentile of the keys, for p in [0, 100],
+// using interp to resolve positions between two keys.
+// Returns false if the tree is empty, p is out of range, or the tree was
+// created WithoutOrderStatistics.
+func Percentile[K Number, V any](t *Tree[K, V], p float64, interp Interpolation) (float64, bool) {
+	if !(p >= 0 && p <= 100) {
+		return 0, false
+	}
+	n := Len(t)
+	if n == 0 {
+		return 0, false
+	}
+	pos := p / 100 * float64(n-1)
+	lo, ok := Kth(t, int(math.Floor(pos)))
+	if !ok {
+		return 0, false
+	}
+	frac := pos - math.Floor(pos)
+	if frac == 0 {
+		return float64(lo.key), true
+	}
+	hi, _ := Successor(lo)
+	a, b := float64(lo.key), float64(hi.key)
+	switch interp {
+	case LowerKey:
+		return a, true
+	case HigherKey:
+		return b, true
+	case Nearest:
+		if frac <= 0.5 {
+			return a, true
+		}
+		return b, true
+	case Midpoint:
+		return (a + b) / 2, true
+	default:
+		return a + (b-a)*frac, true
+	}
+}
+
+// Histogram counts the keys falling into the buckets delimited by the
+// ascending boundaries. Bucket 0 holds the keys less than boundaries[0],
+// bucket i the keys in [boundaries[i-1], boundaries[i]), and the last
+// bucket the keys greater than or equal to the last boundary, so the
+// result has len(boundaries)+1 counts summing to Len(t). Each boundary
+// costs one Rank, O(log n), however many keys the buckets hold.
+// Returns nil if the tree was created WithoutOrderStatistics. It panics if
+// boundaries is not sorted.
+func Histogram[K cmp.Ordered, V any](t *Tree[K, V], boundaries []K) []int {
+	if t.noOrderStats {
+		return nil
+	}
+	if !slices.IsSorted(boundaries) {
+		panic("avltrees: Histogram boundaries must be sorted")
+	}
+	counts := make([]int, len(boundaries)+1)
+	prev := 0
+	for i, b := range boundaries {
+		r := Rank(t, b)
+		counts[i] = r - prev
+		prev = r
+	}
+	counts[len(boundaries)] = Len(t) - prev
+	return counts
+}
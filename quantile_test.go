@@ -0,0 +1,129 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuantile(t *testing.T) {
+	tree := treeOf[int, struct{}]([]int{50, 10, 40, 20, 30}, nil)
+	for q, want := range map[float64]int{0: 10, 0.25: 20, 0.5: 30, 0.9: 40, 1: 50} {
+		n, ok := avlts.Quantile(tree, q)
+		require.True(t, ok)
+		assert.Equal(t, want, n.Key(), "q=%v", q)
+	}
+
+	_, ok := avlts.Quantile(tree, 1.5)
+	assert.False(t, ok)
+	_, ok = avlts.Quantile(tree, -0.1)
+	assert.False(t, ok)
+	_, ok = avlts.Quantile(avlts.New[int, struct{}](), 0.5)
+	assert.False(t, ok)
+	_, ok = avlts.Quantile(avlts.New[int, int](avlts.WithoutOrderStatistics()), 0.5)
+	assert.False(t, ok)
+}
+
+func TestMedian(t *testing.T) {
+	n, ok := avlts.Median(treeOf[int, struct{}]([]int{50, 10, 30}, nil))
+	require.True(t, ok)
+	assert.Equal(t, 30, n.Key())
+
+	n, ok = avlts.Median(treeOf[int, struct{}]([]int{40, 10, 30, 20}, nil))
+	require.True(t, ok)
+	assert.Equal(t, 20, n.Key(), "lower middle for an even count")
+
+	_, ok = avlts.Median(avlts.New[int, struct{}]())
+	assert.False(t, ok)
+	_, ok = avlts.Median(avlts.New[int, int](avlts.WithoutOrderStatistics()))
+	assert.False(t, ok)
+}
+
+func TestMedians(t *testing.T) {
+	lo, hi, ok := avlts.Medians(treeOf[int, struct{}]([]int{40, 10, 30, 20}, nil))
+	require.True(t, ok)
+	assert.Equal(t, []int{20, 30}, []int{lo.Key(), hi.Key()})
+
+	lo, hi, ok = avlts.Medians(treeOf[int, struct{}]([]int{7}, nil))
+	require.True(t, ok)
+	assert.Same(t, lo, hi)
+	assert.Equal(t, 7, lo.Key())
+
+	_, _, ok = avlts.Medians(avlts.New[int, struct{}]())
+	assert.False(t, ok)
+}
+
+func TestPercentile(t *testing.T) {
+	tree := treeOf[int, struct{}]([]int{10, 20, 30, 40}, nil)
+	tests := []struct {
+		interp avlts.Interpolation
+		p      float64
+		want   float64
+	}{
+		{avlts.Linear, 50, 25},
+		{avlts.Linear, 90, 37},
+		{avlts.LowerKey, 50, 20},
+		{avlts.HigherKey, 50, 30},
+		{avlts.Nearest, 50, 20},
+		{avlts.Nearest, 90, 40},
+		{avlts.Midpoint, 90, 35},
+		{avlts.Midpoint, 100, 40},
+		{avlts.Linear, 0, 10},
+	}
+	for _, tt := range tests {
+		got, ok := avlts.Percentile(tree, tt.p, tt.interp)
+		require.True(t, ok)
+		assert.InDelta(t, tt.want, got, 1e-9, "p=%v interp=%v", tt.p, tt.interp)
+	}
+
+	_, ok := avlts.Percentile(tree, 101, avlts.Linear)
+	assert.False(t, ok)
+	_, ok = avlts.Percentile(avlts.New[int, struct{}](), 50, avlts.Linear)
+	assert.False(t, ok)
+}
+
+func TestHistogram(t *testing.T) {
+	tree := treeOf[int, struct{}]([]int{1, 5, 10, 12, 20, 45, 100, 250}, nil)
+	assert.Equal(t, []int{2, 2, 2, 1, 1}, avlts.Histogram(tree, []int{10, 20, 50, 200}))
+	assert.Equal(t, []int{0, 8, 0}, avlts.Histogram(tree, []int{0, 1000}))
+	assert.Equal(t, []int{3, 0, 5}, avlts.Histogram(tree, []int{12, 12}), "duplicate boundaries give an empty bucket")
+	assert.Equal(t, []int{8}, avlts.Histogram(tree, nil))
+	assert.Equal(t, []int{0, 0}, avlts.Histogram(avlts.New[int, struct{}](), []int{5}))
+
+	assert.Nil(t, avlts.Histogram(avlts.New[int, int](avlts.WithoutOrderStatistics()), []int{5}))
+	assert.Panics(t, func() { avlts.Histogram(tree, []int{20, 10}) })
+}
+
+func ExampleQuantile() {
+	tree := treeOf[int, struct{}]([]int{12, 15, 11, 90, 13}, nil)
+	p50, _ := avlts.Quantile(tree, 0.5)
+	fmt.Println(p50.Key())
+	// Output:
+	// 13
+}
+
+func ExampleMedians() {
+	tree := treeOf[int, struct{}]([]int{12, 15, 11, 90}, nil)
+	lo, hi, _ := avlts.Medians(tree)
+	fmt.Println(lo.Key(), hi.Key(), float64(lo.Key()+hi.Key())/2)
+	// Output:
+	// 12 15 13.5
+}
+
+func ExampleHistogram() {
+	tree := treeOf[int, struct{}]([]int{3, 8, 12, 40, 41, 95, 180, 700}, nil)
+	fmt.Println(avlts.Histogram(tree, []int{10, 50, 100, 500}))
+	// Output:
+	// [2 3 1 1 1]
+}
+
+func ExamplePercentile() {
+	tree := treeOf[int, struct{}]([]int{10, 20, 30, 40}, nil)
+	p90, _ := avlts.Percentile(tree, 90, avlts.Linear)
+	fmt.Println(p90)
+	// Output:
+	// 37
+}
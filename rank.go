@@ -0,0 +1,162 @@
+package avltrees
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+	"slices"
+)
+
+// RangePage returns an iterator over at most limit nodes with keys in the
+// range [from, to), skipping the first offset of them. The skip uses the
+// subtree sizes to seek in O(log n); on a tree created
+// WithoutOrderStatistics it falls back to stepping over the skipped nodes.
+func RangePage[K cmp.Ordered, V any](t *Tree[K, V], from, to K, offset, limit int) iter.Seq[Node[K, V]] {
+	return func(yield func(Node[K, V]) bool) {
+		if offset < 0 || limit <= 0 {
+			return
+		}
+		var n *Node[K, V]
+		var ok bool
+		if t.noOrderStats {
+			n, ok = Ceiling(t, from)
+			for i := 0; ok && i < offset; i++ {
+				n, ok = Successor(n)
+			}
+		} else {
+			n, ok = Kth(t, Rank(t, from)+offset)
+		}
+		for yielded := 0; ok && n.key < to && yielded < limit; n, ok = Successor(n) {
+			if !yield(*n) {
+				return
+			}
+			yielded++
+		}
+	}
+}
+
+// RangeByRank returns an iterator over the nodes with 0-based ranks in the
+// range [i, j). It seeks to rank i in O(log n) and then walks in key order.
+// Ranks outside [0, Len(t)) are ignored. Yields nothing if the tree was
+// created WithoutOrderStatistics.
+func RangeByRank[K cmp.Ordered, V any](t *Tree[K, V], i, j int) iter.Seq[Node[K, V]] {
+	return func(yield func(Node[K, V]) bool) {
+		r := max(i, 0)
+		n, ok := Kth(t, r)
+		for ; ok && r < j; r++ {
+			if !yield(*n) {
+				return
+			}
+			n, ok = Successor(n)
+		}
+	}
+}
+
+// BisectLeft returns the rank at which key would be inserted to keep the
+// keys sorted, before any equal key, like Python's bisect.bisect_left.
+// It is the number of keys less than key, the same as Rank.
+// Returns -1 if the tree was created WithoutOrderStatistics.
+func BisectLeft[K cmp.Ordered, V any](t *Tree[K, V], key K) int {
+	return Rank(t, key)
+}
+
+// BisectRight returns the rank at which key would be inserted to keep the
+// keys sorted, after any equal key, like Python's bisect.bisect_right.
+// It is the number of keys less than or equal to key, so it exceeds
+// BisectLeft by one exactly when key is in the tree.
+// Returns -1 if the tree was created WithoutOrderStatistics.
+func BisectRight[K cmp.Ordered, V any](t *Tree[K, V], key K) int {
+	if t.noOrderStats {
+		return -1
+	}
+	rank := 0
+	for curr := t.Root; curr != nil; {
+		if key < curr.key {
+			curr = curr.left
+		} else {
+			rank += size(curr.left) + 1
+			curr = curr.right
+		}
+	}
+	return rank
+}
+
+// TopK returns an iterator over the k nodes with the largest keys, from the
+// largest down. It starts at the maximum and follows parent links, so it
+// costs O(log n + k) and works on any tree.
+func TopK[K cmp.Ordered, V any](t *Tree[K, V], k int) iter.Seq[Node[K, V]] {
+	return func(yield func(Node[K, V]) bool) {
+		n, ok := Max(t)
+		for i := 0; ok && i < k; i++ {
+			if !yield(*n) {
+				return
+			}
+			n, ok = Predecessor(n)
+		}
+	}
+}
+
+// BottomK returns an iterator over the k nodes with the smallest keys, in
+// key order. Like TopK, it costs O(log n + k).
+func BottomK[K cmp.Ordered, V any](t *Tree[K, V], k int) iter.Seq[Node[K, V]] {
+	return func(yield func(Node[K, V]) bool) {
+		n, ok := Min(t)
+		for i := 0; ok && i < k; i++ {
+			if !yield(*n) {
+				return
+			}
+			n, ok = Successor(n)
+		}
+	}
+}
+
+// Partitions splits the AVL tree into at most n iterators over consecutive
+// key ranges whose lengths differ by at most one, so that a large tree can
+// be scanned with one goroutine per partition. Each iterator seeks to its
+// first rank in O(log n). The tree must not be modified while the
+// partitions are in use. Returns nil if the tree is empty, n is not
+// positive, or the tree was created WithoutOrderStatistics.
+func Partitions[K cmp.Ordered, V any](t *Tree[K, V], n int) []iter.Seq[Node[K, V]] {
+	size := Len(t)
+	if size == 0 || n <= 0 || t.noOrderStats {
+		return nil
+	}
+	n = min(n, size)
+	parts := make([]iter.Seq[Node[K, V]], n)
+	for p := range parts {
+		parts[p] = RangeByRank(t, p*size/n, (p+1)*size/n)
+	}
+	return parts
+}
+
+// Sample returns k distinct nodes chosen uniformly at random using rng,
+// in key order. Each choice descends to a random rank in O(log n), so the
+// cost is O(k log n) regardless of the size of the tree. If k is at least
+// Len(t), every node is returned. Returns nil if the tree was created
+// WithoutOrderStatistics.
+func Sample[K cmp.Ordered, V any](t *Tree[K, V], k int, rng *rand.Rand) []*Node[K, V] {
+	n := Len(t)
+	if t.noOrderStats || k <= 0 || n == 0 {
+		return nil
+	}
+	k = min(k, n)
+	// Floyd's algorithm picks k distinct ranks with k draws.
+	picked := make(map[int]struct{}, k)
+	for j := n - k; j < n; j++ {
+		r := rng.IntN(j + 1)
+		if _, dup := picked[r]; dup {
+			r = j
+		}
+		picked[r] = struct{}{}
+	}
+	ranks := make([]int, 0, k)
+	for r := range picked {
+		ranks = append(ranks, r)
+	}
+	slices.Sort(ranks)
+	nodes := make([]*Node[K, V], len(ranks))
+	for i, r := range ranks {
+		nodes[i], _ = Kth(t, r)
+	}
+	return nodes
+}
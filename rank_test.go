@@ -0,0 +1,278 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"sync"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+)
+
+func seqKeys[V any](seq iter.Seq[avlts.Node[int, V]]) []int {
+	var keys []int
+	for n := range seq {
+		keys = append(keys, n.Key())
+	}
+	return keys
+}
+
+func TestRangePage(t *testing.T) {
+	for _, opts := range [][]avlts.Option{nil, {avlts.WithoutOrderStatistics()}} {
+		tree := treeOf[int, string](evenInts(100), nil, opts...)
+		assert.Equal(t, []int{10, 12, 14}, seqKeys(avlts.RangePage(tree, 10, 50, 0, 3)))
+		assert.Equal(t, []int{16, 18, 20}, seqKeys(avlts.RangePage(tree, 10, 50, 3, 3)))
+		assert.Equal(t, []int{46, 48}, seqKeys(avlts.RangePage(tree, 9, 50, 18, 10)))
+		assert.Empty(t, seqKeys(avlts.RangePage(tree, 10, 50, 20, 10)))
+		assert.Empty(t, seqKeys(avlts.RangePage(tree, 10, 50, 0, 0)))
+		assert.Empty(t, seqKeys(avlts.RangePage(tree, 10, 50, -1, 5)))
+		assert.Empty(t, seqKeys(avlts.RangePage(tree, 200, 300, 0, 5)))
+
+		seq := avlts.RangePage(tree, 0, 100, 1, 2)
+		assert.Equal(t, seqKeys(seq), seqKeys(seq), "iterator is reusable")
+	}
+}
+
+func TestRangePageStopsEarly(t *testing.T) {
+	tree := treeOf[int, string](evenInts(100), nil)
+	var keys []int
+	for n := range avlts.RangePage(tree, 0, 100, 5, 10) {
+		keys = append(keys, n.Key())
+		if len(keys) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []int{10, 12}, keys)
+}
+
+func TestRangeByRank(t *testing.T) {
+	tree := treeOf[int, string](evenInts(100), nil)
+	assert.Equal(t, []int{20, 22, 24}, seqKeys(avlts.RangeByRank(tree, 10, 13)))
+	assert.Equal(t, []int{0, 2}, seqKeys(avlts.RangeByRank(tree, -5, 2)))
+	assert.Equal(t, []int{96, 98}, seqKeys(avlts.RangeByRank(tree, 48, 100)))
+	assert.Empty(t, seqKeys(avlts.RangeByRank(tree, 50, 60)))
+	assert.Empty(t, seqKeys(avlts.RangeByRank(tree, 5, 5)))
+	assert.Empty(t, seqKeys(avlts.RangeByRank(tree, 7, 3)))
+
+	seq := avlts.RangeByRank(tree, 1, 3)
+	assert.Equal(t, seqKeys(seq), seqKeys(seq), "iterator is reusable")
+
+	assert.Empty(t, seqKeys(avlts.RangeByRank(treeOf[int, string](evenInts(100), nil, avlts.WithoutOrderStatistics()), 0, 5)))
+}
+
+func TestBisectLeft(t *testing.T) {
+	tree := treeOf[int, string](evenInts(100), nil)
+	assert.Equal(t, 5, avlts.BisectLeft(tree, 10))
+	assert.Equal(t, 6, avlts.BisectLeft(tree, 11))
+	assert.Equal(t, 0, avlts.BisectLeft(tree, -1))
+	assert.Equal(t, 50, avlts.BisectLeft(tree, 100))
+	assert.Equal(t, -1, avlts.BisectLeft(treeOf[int, string](evenInts(100), nil, avlts.WithoutOrderStatistics()), 10))
+}
+
+func TestBisectRight(t *testing.T) {
+	tree := treeOf[int, string](evenInts(100), nil)
+	assert.Equal(t, 6, avlts.BisectRight(tree, 10))
+	assert.Equal(t, 6, avlts.BisectRight(tree, 11))
+	assert.Equal(t, 0, avlts.BisectRight(tree, -1))
+	assert.Equal(t, 50, avlts.BisectRight(tree, 98))
+	assert.Equal(t, 0, avlts.BisectRight(avlts.New[int, int](), 1))
+	assert.Equal(t, -1, avlts.BisectRight(treeOf[int, string](evenInts(100), nil, avlts.WithoutOrderStatistics()), 10))
+
+	for k := -1; k <= 100; k++ {
+		want := avlts.BisectLeft(tree, k)
+		if avlts.Contains(tree, k) {
+			want++
+		}
+		assert.Equal(t, want, avlts.BisectRight(tree, k), "key %d", k)
+	}
+}
+
+func TestTopK(t *testing.T) {
+	tree := treeOf[int, string](evenInts(100), nil)
+	assert.Equal(t, []int{98, 96, 94}, seqKeys(avlts.TopK(tree, 3)))
+	assert.Len(t, seqKeys(avlts.TopK(tree, 100)), 50)
+	assert.Empty(t, seqKeys(avlts.TopK(tree, 0)))
+	assert.Empty(t, seqKeys(avlts.TopK(avlts.New[int, string](), 3)))
+
+	seq := avlts.TopK(tree, 2)
+	assert.Equal(t, seqKeys(seq), seqKeys(seq), "iterator is reusable")
+	assert.Equal(t, []int{98, 96}, seqKeys(avlts.TopK(treeOf[int, string](evenInts(100), nil, avlts.WithoutOrderStatistics()), 2)))
+}
+
+func TestBottomK(t *testing.T) {
+	tree := treeOf[int, string](evenInts(100), nil)
+	assert.Equal(t, []int{0, 2, 4}, seqKeys(avlts.BottomK(tree, 3)))
+	assert.Len(t, seqKeys(avlts.BottomK(tree, 100)), 50)
+	assert.Empty(t, seqKeys(avlts.BottomK(tree, -1)))
+
+	seq := avlts.BottomK(tree, 2)
+	assert.Equal(t, seqKeys(seq), seqKeys(seq), "iterator is reusable")
+}
+
+func TestPartitions(t *testing.T) {
+	tree := treeOf[int, string](evenInts(100), nil)
+	parts := avlts.Partitions(tree, 3)
+	assert.Len(t, parts, 3)
+
+	var all []int
+	for _, p := range parts {
+		keys := seqKeys(p)
+		assert.InDelta(t, 50/3, len(keys), 1)
+		all = append(all, keys...)
+	}
+	assert.Equal(t, treeKeys(tree), all)
+
+	assert.Len(t, avlts.Partitions(tree, 1000), 50, "no empty partitions")
+	assert.Nil(t, avlts.Partitions(tree, 0))
+	assert.Nil(t, avlts.Partitions(avlts.New[int, string](), 4))
+	assert.Nil(t, avlts.Partitions(treeOf[int, string](evenInts(100), nil, avlts.WithoutOrderStatistics()), 4))
+}
+
+func TestPartitionsConcurrent(t *testing.T) {
+	tree := treeOf[int, string](evenInts(100), nil)
+	parts := avlts.Partitions(tree, 4)
+	sums := make([]int, len(parts))
+	var wg sync.WaitGroup
+	for i, p := range parts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range p {
+				sums[i] += n.Key()
+			}
+		}()
+	}
+	wg.Wait()
+	total := 0
+	for _, s := range sums {
+		total += s
+	}
+	assert.Equal(t, 2450, total)
+}
+
+func TestSample(t *testing.T) {
+	tree := treeOf[int, string](evenInts(100), nil)
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	got := avlts.Sample(tree, 10, rng)
+	assert.Len(t, got, 10)
+	for i := 1; i < len(got); i++ {
+		assert.Less(t, got[i-1].Key(), got[i].Key(), "distinct and in key order")
+	}
+	assert.Len(t, avlts.Sample(tree, 500, rng), 50)
+	assert.Empty(t, avlts.Sample(tree, 0, rng))
+	assert.Empty(t, avlts.Sample(avlts.New[int, string](), 3, rng))
+	assert.Empty(t, avlts.Sample(treeOf[int, string](evenInts(100), nil, avlts.WithoutOrderStatistics()), 3, rng))
+}
+
+func TestSampleUniform(t *testing.T) {
+	tree := treeOf[int, string](evenInts(100), nil)
+	rng := rand.New(rand.NewPCG(3, 4))
+	counts := map[int]int{}
+	const rounds = 20000
+	for range rounds {
+		for _, n := range avlts.Sample(tree, 5, rng) {
+			counts[n.Key()]++
+		}
+	}
+	want := float64(rounds*5) / 50
+	for k := 0; k < 100; k += 2 {
+		assert.InEpsilon(t, want, float64(counts[k]), 0.1, "key %d", k)
+	}
+}
+
+func ExampleRangePage() {
+	tree := avlts.New[int, string]()
+	for i := 1; i <= 10; i++ {
+		avlts.Insert(tree, i, fmt.Sprint("row", i))
+	}
+	const pageSize = 3
+	for n := range avlts.RangePage(tree, 1, 11, 1*pageSize, pageSize) {
+		fmt.Println(n.Value())
+	}
+	// Output:
+	// row4
+	// row5
+	// row6
+}
+
+func ExampleRangeByRank() {
+	scores := avlts.New[int, string]()
+	for i := range 2000 {
+		avlts.Insert(scores, i, fmt.Sprint("player", i))
+	}
+	for n := range avlts.RangeByRank(scores, 1000, 1003) {
+		fmt.Println(n.Value())
+	}
+	// Output:
+	// player1000
+	// player1001
+	// player1002
+}
+
+func ExampleBisectRight() {
+	grades := avlts.New[int, string]()
+	avlts.InsertAll(grades, map[int]string{60: "D", 70: "C", 80: "B", 90: "A"})
+	for _, score := range []int{59, 70, 85} {
+		fmt.Println(score, avlts.BisectLeft(grades, score), avlts.BisectRight(grades, score))
+	}
+	// Output:
+	// 59 0 0
+	// 70 1 2
+	// 85 3 3
+}
+
+func ExampleTopK() {
+	latency := avlts.New[int, string]()
+	for ms, path := range map[int]string{120: "/search", 15: "/health", 980: "/export", 45: "/login"} {
+		avlts.Insert(latency, ms, path)
+	}
+	for n := range avlts.TopK(latency, 2) {
+		fmt.Println(n.Key(), n.Value())
+	}
+	// Output:
+	// 980 /export
+	// 120 /search
+}
+
+func ExampleBottomK() {
+	tree := avlts.New[string, int]()
+	avlts.InsertAll(tree, map[string]int{"d": 4, "a": 1, "c": 3, "b": 2})
+	for n := range avlts.BottomK(tree, 2) {
+		fmt.Println(n.Key())
+	}
+	// Output:
+	// a
+	// b
+}
+
+func ExamplePartitions() {
+	tree := avlts.New[int, string]()
+	for i := 1; i <= 10; i++ {
+		avlts.Insert(tree, i, "")
+	}
+	for _, p := range avlts.Partitions(tree, 3) {
+		var keys []int
+		for n := range p {
+			keys = append(keys, n.Key())
+		}
+		fmt.Println(keys)
+	}
+	// Output:
+	// [1 2 3]
+	// [4 5 6]
+	// [7 8 9 10]
+}
+
+func ExampleSample() {
+	tree := avlts.New[string, int]()
+	for _, k := range []string{"a", "b", "c", "d", "e"} {
+		avlts.Insert(tree, k, 0)
+	}
+	sample := avlts.Sample(tree, 2, rand.New(rand.NewPCG(1, 1)))
+	fmt.Println(len(sample))
+	// Output:
+	// 2
+}
@@ -0,0 +1,68 @@
+package avltrees
+
+import (
+	"cmp"
+	"iter"
+)
+
+// ReadOnlyTree is the read-only subset of the Tree API. Accept it instead
+// of *Tree in functions that only look up entries, so callers know their
+// tree will not be modified.
+type ReadOnlyTree[K cmp.Ordered, V any] interface {
+	Search(key K) (*Node[K, V], bool)
+	Min() (*Node[K, V], bool)
+	Max() (*Node[K, V], bool)
+	Floor(key K) (*Node[K, V], bool)
+	Ceiling(key K) (*Node[K, V], bool)
+	Range(from, to K) iter.Seq[Node[K, V]]
+	Rank(key K) int
+	Kth(k int) (*Node[K, V], bool)
+	Len() int
+}
+
+var _ ReadOnlyTree[int, int] = (*Tree[int, int])(nil)
+
+// Search is the method form of the Search function.
+func (t *Tree[K, V]) Search(key K) (*Node[K, V], bool) {
+	return Search(t, key)
+}
+
+// Min is the method form of the Min function.
+func (t *Tree[K, V]) Min() (*Node[K, V], bool) {
+	return Min(t)
+}
+
+// Max is the method form of the Max function.
+func (t *Tree[K, V]) Max() (*Node[K, V], bool) {
+	return Max(t)
+}
+
+// Floor is the method form of the Floor function.
+func (t *Tree[K, V]) Floor(key K) (*Node[K, V], bool) {
+	return Floor(t, key)
+}
+
+// Ceiling is the method form of the Ceiling function.
+func (t *Tree[K, V]) Ceiling(key K) (*Node[K, V], bool) {
+	return Ceiling(t, key)
+}
+
+// Range is the method form of the Range function.
+func (t *Tree[K, V]) Range(from, to K) iter.Seq[Node[K, V]] {
+	return Range(t, from, to)
+}
+
+// Rank is the method form of the Rank function.
+func (t *Tree[K, V]) Rank(key K) int {
+	return Rank(t, key)
+}
+
+// Kth is the method form of the Kth function.
+func (t *Tree[K, V]) Kth(k int) (*Node[K, V], bool) {
+	return Kth(t, k)
+}
+
+// Len is the method form of the Len function.
+func (t *Tree[K, V]) Len() int {
+	return Len(t)
+}
@@ -0,0 +1,61 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyTree(t *testing.T) {
+	tree := avlts.New[int, string]()
+	for _, k := range []int{10, 20, 30, 40} {
+		avlts.Insert(tree, k, fmt.Sprint(k))
+	}
+	var ro avlts.ReadOnlyTree[int, string] = tree
+
+	assert.Equal(t, 4, ro.Len())
+	n, ok := ro.Search(20)
+	require.True(t, ok)
+	assert.Equal(t, "20", n.Value())
+	_, ok = ro.Search(25)
+	assert.False(t, ok)
+
+	n, _ = ro.Min()
+	assert.Equal(t, 10, n.Key())
+	n, _ = ro.Max()
+	assert.Equal(t, 40, n.Key())
+	n, _ = ro.Floor(25)
+	assert.Equal(t, 20, n.Key())
+	n, _ = ro.Ceiling(25)
+	assert.Equal(t, 30, n.Key())
+
+	var keys []int
+	for n := range ro.Range(15, 40) {
+		keys = append(keys, n.Key())
+	}
+	assert.Equal(t, []int{20, 30}, keys)
+
+	assert.Equal(t, 2, ro.Rank(30))
+	n, ok = ro.Kth(3)
+	require.True(t, ok)
+	assert.Equal(t, 40, n.Key())
+	_, ok = ro.Kth(4)
+	assert.False(t, ok)
+}
+
+func ExampleReadOnlyTree() {
+	// countAtLeast only needs to read the tree.
+	countAtLeast := func(t avlts.ReadOnlyTree[int, string], min int) int {
+		return t.Len() - t.Rank(min)
+	}
+
+	tree := avlts.New[int, string]()
+	for _, k := range []int{5, 15, 25, 35} {
+		avlts.Insert(tree, k, "")
+	}
+	fmt.Println(countAtLeast(tree, 20))
+	// Output: 2
+}
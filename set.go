@@ -0,0 +1,102 @@
+package avltrees
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Set is an ordered set of keys. It is a Tree with struct{} values, which
+// take no space, so each key costs only the key itself and the links of
+// its node.
+// Use NewSet to create one; the zero value is not usable.
+type Set[T cmp.Ordered] struct {
+	tree *Tree[T, struct{}]
+}
+
+// NewSet returns a new empty Set configured by opts.
+func NewSet[T cmp.Ordered](opts ...Option) *Set[T] {
+	return &Set[T]{tree: newTree[T, struct{}](opts)}
+}
+
+// Len returns the number of keys in the set.
+func (s *Set[T]) Len() int {
+	return Len(s.tree)
+}
+
+// Add inserts key into the set.
+// Returns false if key was already present.
+func (s *Set[T]) Add(key T) bool {
+	return InsertIfAbsent(s.tree, key, struct{}{})
+}
+
+// Remove removes key from the set.
+// Returns false if key was not present.
+func (s *Set[T]) Remove(key T) bool {
+	return Delete(s.tree, key)
+}
+
+// Contains reports whether key is in the set.
+func (s *Set[T]) Contains(key T) bool {
+	return Contains(s.tree, key)
+}
+
+// Min returns the smallest key in the set.
+func (s *Set[T]) Min() (T, bool) {
+	return keyOf(Min(s.tree))
+}
+
+// Max returns the largest key in the set.
+func (s *Set[T]) Max() (T, bool) {
+	return keyOf(Max(s.tree))
+}
+
+// Floor returns the largest key less than or equal to key.
+func (s *Set[T]) Floor(key T) (T, bool) {
+	return keyOf(Floor(s.tree, key))
+}
+
+// Ceiling returns the smallest key greater than or equal to key.
+func (s *Set[T]) Ceiling(key T) (T, bool) {
+	return keyOf(Ceiling(s.tree, key))
+}
+
+// At returns the key at index i in ascending order.
+// Returns false if i is out of range or the set was created
+// WithoutOrderStatistics.
+func (s *Set[T]) At(i int) (T, bool) {
+	return keyOf(Kth(s.tree, i))
+}
+
+// Rank returns the number of keys less than key.
+// Returns -1 if the set was created WithoutOrderStatistics.
+func (s *Set[T]) Rank(key T) int {
+	return Rank(s.tree, key)
+}
+
+// All returns an iterator over the keys in ascending order.
+func (s *Set[T]) All() iter.Seq[T] {
+	return keySeq(InOrder(s.tree))
+}
+
+// Range returns an iterator over the keys in the range [from, to).
+func (s *Set[T]) Range(from, to T) iter.Seq[T] {
+	return keySeq(Range(s.tree, from, to))
+}
+
+func keyOf[K cmp.Ordered, V any](n *Node[K, V], ok bool) (K, bool) {
+	if !ok {
+		var zero K
+		return zero, false
+	}
+	return n.key, true
+}
+
+func keySeq[K cmp.Ordered, V any](seq iter.Seq[Node[K, V]]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for n := range seq {
+			if !yield(n.key) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"unsafe"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAdd(t *testing.T) {
+	s := avlts.NewSet[int]()
+	assert.True(t, s.Add(3))
+	assert.True(t, s.Add(1))
+	assert.False(t, s.Add(3))
+	assert.Equal(t, 2, s.Len())
+	assert.Equal(t, []int{1, 3}, slices.Collect(s.All()))
+
+	// A struct{} value adds nothing to the size of a node.
+	assert.Less(t, unsafe.Sizeof(avlts.Node[int, struct{}]{}), unsafe.Sizeof(avlts.Node[int, int]{}))
+}
+
+func TestSetRemove(t *testing.T) {
+	s := avlts.NewSet[int]()
+	for _, k := range []int{1, 2, 3} {
+		s.Add(k)
+	}
+	assert.True(t, s.Remove(2))
+	assert.False(t, s.Remove(2))
+	assert.False(t, s.Contains(2))
+	assert.True(t, s.Contains(3))
+	assert.Equal(t, 2, s.Len())
+}
+
+func TestSetMinMax(t *testing.T) {
+	s := avlts.NewSet[int]()
+	_, ok := s.Min()
+	assert.False(t, ok)
+	_, ok = s.Max()
+	assert.False(t, ok)
+
+	s = avlts.NewSet[int]()
+	for _, k := range []int{5, 1, 9} {
+		s.Add(k)
+	}
+	k, ok := s.Min()
+	assert.True(t, ok)
+	assert.Equal(t, 1, k)
+	k, ok = s.Max()
+	assert.True(t, ok)
+	assert.Equal(t, 9, k)
+}
+
+func TestSetFloorCeiling(t *testing.T) {
+	s := avlts.NewSet[int]()
+	for _, k := range []int{10, 20, 30} {
+		s.Add(k)
+	}
+	k, ok := s.Floor(25)
+	assert.True(t, ok)
+	assert.Equal(t, 20, k)
+	k, ok = s.Ceiling(25)
+	assert.True(t, ok)
+	assert.Equal(t, 30, k)
+	_, ok = s.Floor(5)
+	assert.False(t, ok)
+	_, ok = s.Ceiling(35)
+	assert.False(t, ok)
+}
+
+func TestSetAtRank(t *testing.T) {
+	s := avlts.NewSet[int]()
+	for _, k := range []int{10, 20, 30} {
+		s.Add(k)
+	}
+	k, ok := s.At(1)
+	assert.True(t, ok)
+	assert.Equal(t, 20, k)
+	_, ok = s.At(3)
+	assert.False(t, ok)
+	assert.Equal(t, 2, s.Rank(25))
+
+	plain := avlts.NewSet[int](avlts.WithoutOrderStatistics())
+	plain.Add(1)
+	_, ok = plain.At(0)
+	assert.False(t, ok)
+	assert.Equal(t, -1, plain.Rank(1))
+}
+
+func TestSetRange(t *testing.T) {
+	s := avlts.NewSet[int]()
+	for _, k := range []int{1, 3, 5, 7, 9} {
+		s.Add(k)
+	}
+	assert.Equal(t, []int{3, 5, 7}, slices.Collect(s.Range(2, 8)))
+	for k := range s.Range(3, 9) {
+		assert.Equal(t, 3, k)
+		break
+	}
+}
+
+func ExampleSet() {
+	seen := avlts.NewSet[string]()
+	for _, word := range []string{"b", "a", "b", "c"} {
+		if !seen.Add(word) {
+			fmt.Println("duplicate:", word)
+		}
+	}
+	fmt.Println(slices.Collect(seen.All()))
+	// Output:
+	// duplicate: b
+	// [a b c]
+}
+
+func ExampleSet_Range() {
+	ports := avlts.NewSet[int]()
+	for _, k := range []int{22, 80, 443, 8080} {
+		ports.Add(k)
+	}
+	fmt.Println(slices.Collect(ports.Range(0, 1024)))
+	// Output: [22 80 443]
+}
@@ -0,0 +1,234 @@
+package avltrees
+
+import "cmp"
+
+// Union returns a new AVL tree holding the entries of a and b. For keys
+// present in both, the value from b is kept. The inputs are left unchanged.
+//
+// The smaller input, of size m, is copied and then split and joined
+// against the larger one, of size n, in O(m log(n/m + 1)). Nodes have
+// parent links, so the result cannot share subtrees with the inputs; the
+// parts of the larger tree that end up in the result are copied as well.
+// The result has the order-statistics setting and aggregates of a but no
+// capacity bound, hooks or metrics.
+func Union[K cmp.Ordered, V any](a, b *Tree[K, V]) *Tree[K, V] {
+	out := newTreeLike(a)
+	var root *Node[K, V]
+	if Len(a) <= Len(b) {
+		own, h := copySubtree(out, a.Root, nil)
+		root, _ = union(out, own, h, b.Root, true)
+	} else {
+		own, h := copySubtree(out, b.Root, nil)
+		root, _ = union(out, own, h, a.Root, false)
+	}
+	return finishSetOp(out, root)
+}
+
+// Intersect returns a new AVL tree holding the entries of a whose keys are
+// also in b. The inputs are left unchanged. The smaller input is copied
+// and split and joined against the larger one in O(m log(n/m + 1)), and
+// the result is configured like that of Union.
+func Intersect[K cmp.Ordered, V any](a, b *Tree[K, V]) *Tree[K, V] {
+	out := newTreeLike(a)
+	var root *Node[K, V]
+	if Len(a) <= Len(b) {
+		own, h := copySubtree(out, a.Root, nil)
+		root, _ = intersect(out, own, h, b.Root, false)
+	} else {
+		own, h := copySubtree(out, b.Root, nil)
+		root, _ = intersect(out, own, h, a.Root, true)
+	}
+	return finishSetOp(out, root)
+}
+
+// Difference returns a new AVL tree holding the entries of a whose keys
+// are not in b. The inputs are left unchanged. Like Union, it copies the
+// smaller input and splits and joins it against the larger one in
+// O(m log(n/m + 1)), plus the copying of the entries of a that remain.
+// The result is configured like that of Union.
+func Difference[K cmp.Ordered, V any](a, b *Tree[K, V]) *Tree[K, V] {
+	out := newTreeLike(a)
+	var root *Node[K, V]
+	if Len(a) <= Len(b) {
+		own, h := copySubtree(out, a.Root, nil)
+		root, _ = subtract(out, own, h, b.Root)
+	} else {
+		own, h := copySubtree(out, b.Root, nil)
+		root, _ = without(out, a.Root, own, h)
+	}
+	return finishSetOp(out, root)
+}
+
+func finishSetOp[K cmp.Ordered, V any](t *Tree[K, V], root *Node[K, V]) *Tree[K, V] {
+	t.Root = root
+	if t.noOrderStats {
+		t.count = len(appendNodes(nil, root))
+	} else {
+		t.count = size(root)
+	}
+	return t
+}
+
+// union, intersect, subtract, and without combine a detached subtree own
+// of height h, whose nodes belong to t and are reused, with a subtree other
+// of an input tree, which is only read. Each splits own at the root key of
+// other, recurses on the halves, and joins the results, copying the nodes
+// of other that the result needs, and returns the result with its height.
+// When otherWins is set, keys present in both take their value from other.
+func union[K cmp.Ordered, V any](t *Tree[K, V], own *Node[K, V], h int, other *Node[K, V], otherWins bool) (*Node[K, V], int) {
+	if other == nil {
+		return own, h
+	}
+	if own == nil {
+		return copySubtree(t, other, nil)
+	}
+	l, hl, mid, r, hr := splitAt(t, own, h, other.key)
+	if mid == nil {
+		mid = copyNode(t, other)
+	} else if otherWins {
+		mid.value = other.value
+	}
+	l, hl = union(t, l, hl, other.left, otherWins)
+	r, hr = union(t, r, hr, other.right, otherWins)
+	return join(t, l, hl, mid, r, hr)
+}
+
+func intersect[K cmp.Ordered, V any](t *Tree[K, V], own *Node[K, V], h int, other *Node[K, V], otherWins bool) (*Node[K, V], int) {
+	if own == nil || other == nil {
+		return nil, 0
+	}
+	l, hl, mid, r, hr := splitAt(t, own, h, other.key)
+	l, hl = intersect(t, l, hl, other.left, otherWins)
+	r, hr = intersect(t, r, hr, other.right, otherWins)
+	if mid == nil {
+		return join2(t, l, hl, r, hr)
+	}
+	if otherWins {
+		mid.value = other.value
+	}
+	return join(t, l, hl, mid, r, hr)
+}
+
+// subtract returns own without the keys of other.
+func subtract[K cmp.Ordered, V any](t *Tree[K, V], own *Node[K, V], h int, other *Node[K, V]) (*Node[K, V], int) {
+	if own == nil || other == nil {
+		return own, h
+	}
+	l, hl, _, r, hr := splitAt(t, own, h, other.key)
+	l, hl = subtract(t, l, hl, other.left)
+	r, hr = subtract(t, r, hr, other.right)
+	return join2(t, l, hl, r, hr)
+}
+
+// without returns copies of the entries of other whose keys are not in own.
+func without[K cmp.Ordered, V any](t *Tree[K, V], other, own *Node[K, V], h int) (*Node[K, V], int) {
+	if other == nil {
+		return nil, 0
+	}
+	if own == nil {
+		return copySubtree(t, other, nil)
+	}
+	l, hl, mid, r, hr := splitAt(t, own, h, other.key)
+	l, hl = without(t, other.left, l, hl)
+	r, hr = without(t, other.right, r, hr)
+	if mid != nil {
+		return join2(t, l, hl, r, hr)
+	}
+	return join(t, l, hl, copyNode(t, other), r, hr)
+}
+
+// splitAt divides the subtree rooted at n, of height h, into the keys less
+// than key, the node holding key if any, and the keys greater than key, all
+// detached. The two subtrees are returned with their heights.
+func splitAt[K cmp.Ordered, V any](t *Tree[K, V], n *Node[K, V], h int, key K) (*Node[K, V], int, *Node[K, V], *Node[K, V], int) {
+	if n == nil {
+		return nil, 0, nil, nil, 0
+	}
+	hl, hr := childHeights(n, h)
+	l, r := detachChildren(n)
+	switch {
+	case key < n.key:
+		ll, hll, found, lr, hlr := splitAt(t, l, hl, key)
+		r, hr = join(t, lr, hlr, n, r, hr)
+		return ll, hll, found, r, hr
+	case key > n.key:
+		rl, hrl, found, rr, hrr := splitAt(t, r, hr, key)
+		l, hl = join(t, l, hl, n, rl, hrl)
+		return l, hl, found, rr, hrr
+	default:
+		return l, hl, n, r, hr
+	}
+}
+
+// join2 joins two detached subtrees of heights hl and hr where every key
+// in l is less than every key in r.
+func join2[K cmp.Ordered, V any](t *Tree[K, V], l *Node[K, V], hl int, r *Node[K, V], hr int) (*Node[K, V], int) {
+	if l == nil {
+		return r, hr
+	}
+	rest, hrest, last := splitLast(t, l, hl)
+	return join(t, rest, hrest, last, r, hr)
+}
+
+// splitLast detaches the node with the largest key from the subtree rooted
+// at n, of height h, and returns the remaining subtree, its height, and
+// that node.
+func splitLast[K cmp.Ordered, V any](t *Tree[K, V], n *Node[K, V], h int) (*Node[K, V], int, *Node[K, V]) {
+	hl, hr := childHeights(n, h)
+	l, r := detachChildren(n)
+	if r == nil {
+		return l, hl, n
+	}
+	rest, hrest, last := splitLast(t, r, hr)
+	l, hl = join(t, l, hl, n, rest, hrest)
+	return l, hl, last
+}
+
+// copySubtree copies the subtree rooted at n node for node into t,
+// recomputing sizes and aggregates for t, and returns the copy and its
+// height.
+func copySubtree[K cmp.Ordered, V any](t *Tree[K, V], n, parent *Node[K, V]) (*Node[K, V], int) {
+	if n == nil {
+		return nil, 0
+	}
+	m := copyNode(t, n)
+	m.parent = parent
+	var hl, hr int
+	m.left, hl = copySubtree(t, n.left, m)
+	m.right, hr = copySubtree(t, n.right, m)
+	m.balance = n.balance
+	updateSize(t, m)
+	return m, max(hl, hr) + 1
+}
+
+// copyNode returns a new node of t with the entry of n.
+func copyNode[K cmp.Ordered, V any](t *Tree[K, V], n *Node[K, V]) *Node[K, V] {
+	m := allocNode(t)
+	m.key, m.value = n.key, n.value
+	return m
+}
+
+// IsSubset reports whether every key of a is also a key of b. Values are
+// ignored. The trees are walked in step and the walk stops at the first
+// key of a missing from b.
+func IsSubset[K cmp.Ordered, V any](a, b *Tree[K, V]) bool {
+	if Len(a) > Len(b) {
+		return false
+	}
+	nb, okb := Min(b)
+	for na, ok := Min(a); ok; na, ok = Successor(na) {
+		for okb && nb.key < na.key {
+			nb, okb = Successor(nb)
+		}
+		if !okb || nb.key != na.key {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset reports whether every key of b is also a key of a.
+// It is IsSubset with its arguments swapped.
+func IsSuperset[K cmp.Ordered, V any](a, b *Tree[K, V]) bool {
+	return IsSubset(b, a)
+}
@@ -0,0 +1,224 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnion(t *testing.T) {
+	a := treeOf([]int{1, 3, 5, 7}, []string{"a", "a", "a", "a"})
+	b := treeOf([]int{2, 3, 4, 7, 9}, []string{"b", "b", "b", "b", "b"})
+	u := avlts.Union(a, b)
+	require.NoError(t, avlts.Validate(u))
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 7, 9}, treeKeys(u))
+	assert.Equal(t, "b", avlts.GetOrDefault(u, 3, ""), "b wins on shared keys")
+	assert.Equal(t, "a", avlts.GetOrDefault(u, 5, ""))
+
+	assert.Equal(t, []int{1, 3, 5, 7}, treeKeys(a), "inputs are unchanged")
+	assert.Equal(t, []int{2, 3, 4, 7, 9}, treeKeys(b))
+	require.NoError(t, avlts.Validate(a))
+	require.NoError(t, avlts.Validate(b))
+
+	empty := avlts.New[int, string]()
+	assert.Equal(t, treeKeys(a), treeKeys(avlts.Union(a, empty)))
+	assert.Equal(t, treeKeys(a), treeKeys(avlts.Union(empty, a)))
+}
+
+func TestIntersect(t *testing.T) {
+	a := treeOf([]int{1, 3, 5, 7}, []string{"a", "a", "a", "a"})
+	b := treeOf([]int{2, 3, 4, 7, 9}, []string{"b", "b", "b", "b", "b"})
+	x := avlts.Intersect(a, b)
+	require.NoError(t, avlts.Validate(x))
+	assert.Equal(t, []int{3, 7}, treeKeys(x))
+	assert.Equal(t, "a", avlts.GetOrDefault(x, 3, ""), "values come from a")
+	assert.Equal(t, 0, avlts.Len(avlts.Intersect(a, avlts.New[int, string]())))
+}
+
+func TestDifference(t *testing.T) {
+	a := treeOf([]int{1, 3, 5, 7}, []string{"a", "a", "a", "a"})
+	b := treeOf([]int{2, 3, 4, 7, 9}, []string{"b", "b", "b", "b", "b"})
+	d := avlts.Difference(a, b)
+	require.NoError(t, avlts.Validate(d))
+	assert.Equal(t, []int{1, 5}, treeKeys(d))
+	assert.Equal(t, []int{2, 4, 9}, treeKeys(avlts.Difference(b, a)))
+	assert.Equal(t, treeKeys(a), treeKeys(avlts.Difference(a, avlts.New[int, string]())))
+}
+
+func TestIsSubset(t *testing.T) {
+	a := treeOf([]int{3, 7}, []string{"a", "a"})
+	b := treeOf([]int{2, 3, 4, 7, 9}, []string{"b", "b", "b", "b", "b"})
+	empty := avlts.New[int, string]()
+
+	assert.True(t, avlts.IsSubset(a, b))
+	assert.False(t, avlts.IsSubset(b, a))
+	assert.True(t, avlts.IsSubset(empty, a))
+	assert.False(t, avlts.IsSubset(a, empty))
+	assert.True(t, avlts.IsSubset(a, a))
+	assert.False(t, avlts.IsSubset(treeOf[int, string]([]int{3, 8}, nil), b))
+	assert.False(t, avlts.IsSubset(treeOf[int, string]([]int{10}, nil), b), "key past the end of b")
+	assert.False(t, avlts.IsSubset(treeOf[int, string]([]int{1}, nil), b), "key before the start of b")
+}
+
+func TestIsSuperset(t *testing.T) {
+	a := treeOf([]int{3, 7}, []string{"a", "a"})
+	b := treeOf([]int{2, 3, 4, 7, 9}, []string{"b", "b", "b", "b", "b"})
+	assert.True(t, avlts.IsSuperset(b, a))
+	assert.False(t, avlts.IsSuperset(a, b))
+}
+
+func TestSetOpsRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(9, 9))
+	for range 50 {
+		var ak, bk []int
+		inA, inB := map[int]bool{}, map[int]bool{}
+		for range r.IntN(300) {
+			k := r.IntN(400)
+			ak = append(ak, k)
+			inA[k] = true
+		}
+		for range r.IntN(30) {
+			k := r.IntN(400)
+			bk = append(bk, k)
+			inB[k] = true
+		}
+		a, b := treeOf(ak, slices.Repeat([]string{"a"}, len(ak))), treeOf(bk, slices.Repeat([]string{"b"}, len(bk)))
+
+		var wantU, wantI, wantD, wantR []int
+		for k := range 400 {
+			if inA[k] || inB[k] {
+				wantU = append(wantU, k)
+			}
+			if inA[k] && inB[k] {
+				wantI = append(wantI, k)
+			}
+			if inA[k] && !inB[k] {
+				wantD = append(wantD, k)
+			}
+			if inB[k] && !inA[k] {
+				wantR = append(wantR, k)
+			}
+		}
+		for _, c := range []struct {
+			got  *avlts.Tree[int, string]
+			want []int
+		}{
+			{avlts.Union(a, b), wantU},
+			{avlts.Union(b, a), wantU},
+			{avlts.Intersect(a, b), wantI},
+			{avlts.Intersect(b, a), wantI},
+			{avlts.Difference(a, b), wantD},
+			{avlts.Difference(b, a), wantR},
+		} {
+			assert.True(t, avlts.IsSubset(c.got, avlts.Union(a, b)))
+			require.NoError(t, avlts.Validate(c.got))
+			assert.Equal(t, c.want, treeKeys(c.got))
+			assert.Equal(t, len(c.want), avlts.Len(c.got))
+		}
+		assert.Equal(t, len(wantD) == 0, avlts.IsSubset(a, b))
+
+		for n := range avlts.InOrder(avlts.Union(a, b)) {
+			assert.Equal(t, map[bool]string{true: "b", false: "a"}[inB[n.Key()]], n.Value(), "b wins in Union(a, b)")
+		}
+		for n := range avlts.InOrder(avlts.Union(b, a)) {
+			assert.Equal(t, map[bool]string{true: "a", false: "b"}[inA[n.Key()]], n.Value(), "a wins in Union(b, a)")
+		}
+		for n := range avlts.InOrder(avlts.Intersect(a, b)) {
+			assert.Equal(t, "a", n.Value())
+		}
+		for n := range avlts.InOrder(avlts.Intersect(b, a)) {
+			assert.Equal(t, "b", n.Value())
+		}
+		require.NoError(t, avlts.Validate(a))
+		require.NoError(t, avlts.Validate(b))
+		assert.Len(t, inA, avlts.Len(a), "inputs are left unchanged")
+		assert.Len(t, inB, avlts.Len(b), "inputs are left unchanged")
+	}
+}
+
+func TestSetOpsWithoutOrderStatistics(t *testing.T) {
+	a := avlts.New[int, int](avlts.WithoutOrderStatistics())
+	b := avlts.New[int, int]()
+	for i := range 10 {
+		avlts.Insert(a, i, i)
+		avlts.Insert(b, i+5, i)
+	}
+	u := avlts.Union(a, b)
+	assert.Equal(t, 15, avlts.Len(u))
+	assert.Equal(t, -1, avlts.Rank(u, 3))
+	assert.Equal(t, 5, avlts.Len(avlts.Intersect(b, a)))
+	assert.Equal(t, 2, avlts.Rank(avlts.Intersect(b, a), 7), "sizes are recomputed when copying a")
+}
+
+func TestSetOpsKeepAggregates(t *testing.T) {
+	hash := avlts.WithMultisetHash(func(k, v int) uint64 { return uint64(k*31 + v) })
+	a := avlts.New[int, int](hash, avlts.WithPrefixSums[int]())
+	b := avlts.New[int, int]()
+	want := avlts.New[int, int](hash)
+	for i := range 10 {
+		avlts.Insert(a, i, 1)
+		avlts.Insert(b, i+5, 1)
+		avlts.Insert(want, i, 1)
+		avlts.Insert(want, i+5, 1)
+	}
+	u := avlts.Union(a, b)
+	require.NoError(t, avlts.Validate(u))
+	assert.Equal(t, avlts.MultisetHash(want), avlts.MultisetHash(u))
+	for tree, want := range map[*avlts.Tree[int, int]]int{u: 15, avlts.Intersect(a, b): 5, avlts.Difference(a, b): 5} {
+		sum, ok := avlts.PrefixSum(tree, 100)
+		assert.True(t, ok)
+		assert.Equal(t, want, sum)
+	}
+}
+
+func BenchmarkIntersectSmallLarge(b *testing.B) {
+	large := avlts.New[int, int]()
+	for i := range 1 << 16 {
+		avlts.Insert(large, i, i)
+	}
+	small := treeOf[int, int]([]int{10, 1000, 50000}, nil)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		avlts.Intersect(large, small)
+	}
+}
+
+func ExampleUnion() {
+	a := treeOf[int, string]([]int{1, 2, 3}, nil)
+	b := treeOf[int, string]([]int{3, 4}, nil)
+	fmt.Println(treeKeys(avlts.Union(a, b)))
+	// Output: [1 2 3 4]
+}
+
+func ExampleIntersect() {
+	a := treeOf[int, string]([]int{1, 2, 3}, nil)
+	b := treeOf[int, string]([]int{2, 3, 4}, nil)
+	fmt.Println(treeKeys(avlts.Intersect(a, b)))
+	// Output: [2 3]
+}
+
+func ExampleDifference() {
+	a := treeOf[int, string]([]int{1, 2, 3}, nil)
+	b := treeOf[int, string]([]int{2, 4}, nil)
+	fmt.Println(treeKeys(avlts.Difference(a, b)))
+	// Output: [1 3]
+}
+
+func ExampleIsSubset() {
+	granted := treeOf[int, string]([]int{1, 2, 3, 5, 8}, nil)
+	required := treeOf[int, string]([]int{2, 5}, nil)
+	fmt.Println(avlts.IsSubset(required, granted))
+	// Output: true
+}
+
+func ExampleIsSuperset() {
+	installed := treeOf[int, string]([]int{1, 2, 3}, nil)
+	deps := treeOf[int, string]([]int{2, 4}, nil)
+	fmt.Println(avlts.IsSuperset(installed, deps))
+	// Output: false
+}
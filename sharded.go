@@ -0,0 +1,159 @@
+package avltrees
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+)
+
+type shard[K cmp.Ordered, V any] struct {
+	mu   sync.RWMutex
+	tree Tree[K, V]
+}
+
+// ShardedTree partitions the key space across several AVL trees, each guarded
+// by its own lock, so writers touching different key ranges do not contend.
+// Shard i holds the keys in [splits[i-1], splits[i]), with the first and last
+// shards unbounded below and above. ShardedTree is safe for concurrent use.
+type ShardedTree[K cmp.Ordered, V any] struct {
+	splits []K
+	shards []*shard[K, V]
+}
+
+// NewSharded returns a new empty ShardedTree with len(splits)+1 shards.
+// Panics if splits are not in strictly ascending order.
+func NewSharded[K cmp.Ordered, V any](splits ...K) *ShardedTree[K, V] {
+	for i := 1; i < len(splits); i++ {
+		if splits[i-1] >= splits[i] {
+			panic("avltrees: NewSharded requires strictly ascending splits")
+		}
+	}
+	s := &ShardedTree[K, V]{splits: slices.Clone(splits)}
+	s.shards = make([]*shard[K, V], len(splits)+1)
+	for i := range s.shards {
+		s.shards[i] = &shard[K, V]{}
+	}
+	return s
+}
+
+// Insert inserts a key-value pair.
+// Returns true if the key was inserted, or false if it replaced an existing key.
+func (s *ShardedTree[K, V]) Insert(key K, value V) bool {
+	sh := s.shardFor(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	return Insert(&sh.tree, key, value)
+}
+
+// Delete removes key. Returns true if the key existed and was deleted.
+func (s *ShardedTree[K, V]) Delete(key K) bool {
+	sh := s.shardFor(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	return Delete(&sh.tree, key)
+}
+
+// Get returns the value stored under key and whether it was found.
+func (s *ShardedTree[K, V]) Get(key K) (V, bool) {
+	sh := s.shardFor(key)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	if n, ok := Search(&sh.tree, key); ok {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// CompareAndSwap replaces the value of key with new if the stored value equals old.
+// Returns true if the swap happened. As with sync.Map, the values are compared
+// with ==, which panics if V's dynamic type is not comparable.
+func (s *ShardedTree[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	sh := s.shardFor(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	n, ok := Search(&sh.tree, key)
+	if !ok || any(n.value) != any(old) {
+		return false
+	}
+	Insert(&sh.tree, key, new)
+	return true
+}
+
+// CompareAndDelete removes key if its stored value equals old.
+// Returns true if the entry was deleted. As with sync.Map, the values are
+// compared with ==, which panics if V's dynamic type is not comparable.
+func (s *ShardedTree[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
+	sh := s.shardFor(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	n, ok := Search(&sh.tree, key)
+	if !ok || any(n.value) != any(old) {
+		return false
+	}
+	return Delete(&sh.tree, key)
+}
+
+// Len returns the total number of entries across all shards.
+// Under concurrent writes the result is not a consistent snapshot.
+func (s *ShardedTree[K, V]) Len() int {
+	total := 0
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+		total += Len(&sh.tree)
+		sh.mu.RUnlock()
+	}
+	return total
+}
+
+// Ascend calls visit for every entry in ascending key order until visit returns false.
+// Each shard is read-locked while it is visited, so visit must not modify the tree.
+func (s *ShardedTree[K, V]) Ascend(visit func(key K, value V) bool) {
+	for _, sh := range s.shards {
+		if !sh.ascend(visit, func(t *Tree[K, V]) (*Node[K, V], bool) { return Min(t) }, nil) {
+			return
+		}
+	}
+}
+
+// Range calls visit for every entry with key in [from, to) in ascending order
+// until visit returns false. Each shard is read-locked while it is visited,
+// so visit must not modify the tree.
+func (s *ShardedTree[K, V]) Range(from, to K, visit func(key K, value V) bool) {
+	if from >= to {
+		return
+	}
+	first, last := s.shardIndex(from), s.shardIndex(to)
+	for i := first; i <= last; i++ {
+		start := func(t *Tree[K, V]) (*Node[K, V], bool) { return Ceiling(t, from) }
+		if !s.shards[i].ascend(visit, start, &to) {
+			return
+		}
+	}
+}
+
+func (sh *shard[K, V]) ascend(visit func(K, V) bool, start func(*Tree[K, V]) (*Node[K, V], bool), to *K) bool {
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	for n, ok := start(&sh.tree); ok; n, ok = Successor(n) {
+		if to != nil && n.key >= *to {
+			return false
+		}
+		if !visit(n.key, n.value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *ShardedTree[K, V]) shardIndex(key K) int {
+	i, found := slices.BinarySearch(s.splits, key)
+	if found {
+		i++
+	}
+	return i
+}
+
+func (s *ShardedTree[K, V]) shardFor(key K) *shard[K, V] {
+	return s.shards[s.shardIndex(key)]
+}
@@ -0,0 +1,185 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSharded(t *testing.T) {
+	s := avlts.NewSharded[int, string]()
+	s.Insert(1, "one")
+	assert.Equal(t, 1, s.Len())
+
+	assert.Panics(t, func() { avlts.NewSharded[int, string](10, 10) })
+	assert.Panics(t, func() { avlts.NewSharded[int, string](20, 10) })
+}
+
+func TestShardedTreeInsertGetDelete(t *testing.T) {
+	s := avlts.NewSharded[int, string](10, 20)
+
+	assert.True(t, s.Insert(5, "five"))
+	assert.True(t, s.Insert(10, "ten"))
+	assert.True(t, s.Insert(25, "twenty-five"))
+	assert.False(t, s.Insert(10, "TEN"))
+	assert.Equal(t, 3, s.Len())
+
+	v, ok := s.Get(10)
+	require.True(t, ok)
+	assert.Equal(t, "TEN", v)
+	_, ok = s.Get(15)
+	assert.False(t, ok)
+
+	assert.True(t, s.Delete(25))
+	assert.False(t, s.Delete(25))
+	assert.Equal(t, 2, s.Len())
+}
+
+func TestShardedTreeCompareAndSwap(t *testing.T) {
+	s := avlts.NewSharded[string, int]("m")
+	s.Insert("a", 1)
+
+	assert.False(t, s.CompareAndSwap("a", 2, 3))
+	assert.False(t, s.CompareAndSwap("z", 0, 3))
+	assert.True(t, s.CompareAndSwap("a", 1, 3))
+	v, _ := s.Get("a")
+	assert.Equal(t, 3, v)
+
+	_, ok := s.Get("z")
+	assert.False(t, ok, "CompareAndSwap must not insert missing keys")
+
+	slices := avlts.NewSharded[int, any]()
+	slices.Insert(1, []int{1})
+	assert.Panics(t, func() { slices.CompareAndSwap(1, []int{1}, nil) })
+}
+
+func TestShardedTreeCompareAndDelete(t *testing.T) {
+	s := avlts.NewSharded[string, int]("m")
+	s.Insert("a", 1)
+	s.Insert("x", 2)
+
+	assert.False(t, s.CompareAndDelete("a", 2))
+	assert.False(t, s.CompareAndDelete("b", 0))
+	assert.True(t, s.CompareAndDelete("a", 1))
+	assert.True(t, s.CompareAndDelete("x", 2))
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestShardedTreeCompareAndSwapConcurrent(t *testing.T) {
+	s := avlts.NewSharded[string, int]()
+	s.Insert("counter", 0)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				for {
+					v, _ := s.Get("counter")
+					if s.CompareAndSwap("counter", v, v+1) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	v, _ := s.Get("counter")
+	assert.Equal(t, 800, v)
+}
+
+func TestShardedTreeAscend(t *testing.T) {
+	s := avlts.NewSharded[int, int](10, 20, 30)
+	for _, k := range []int{35, 5, 25, 15, 10, 30, 20} {
+		s.Insert(k, k)
+	}
+
+	var keys []int
+	s.Ascend(func(k, v int) bool {
+		keys = append(keys, k)
+		return true
+	})
+	assert.Equal(t, []int{5, 10, 15, 20, 25, 30, 35}, keys)
+
+	keys = nil
+	s.Ascend(func(k, v int) bool {
+		keys = append(keys, k)
+		return k < 15
+	})
+	assert.Equal(t, []int{5, 10, 15}, keys)
+}
+
+func TestShardedTreeRange(t *testing.T) {
+	s := avlts.NewSharded[int, int](10, 20, 30)
+	for k := range 40 {
+		s.Insert(k, k)
+	}
+
+	var keys []int
+	s.Range(8, 22, func(k, v int) bool {
+		keys = append(keys, k)
+		return true
+	})
+	assert.Equal(t, []int{8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21}, keys)
+
+	keys = nil
+	s.Range(18, 40, func(k, v int) bool {
+		keys = append(keys, k)
+		return len(keys) < 4
+	})
+	assert.Equal(t, []int{18, 19, 20, 21}, keys)
+
+	keys = nil
+	s.Range(20, 20, func(k, v int) bool {
+		keys = append(keys, k)
+		return true
+	})
+	assert.Empty(t, keys)
+}
+
+func TestShardedTreeConcurrent(t *testing.T) {
+	s := avlts.NewSharded[int, int](250, 500, 750)
+	var wg sync.WaitGroup
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := w * 250; i < (w+1)*250; i++ {
+				s.Insert(i, i)
+				s.Get(i)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1000, s.Len())
+}
+
+func ExampleShardedTree_CompareAndSwap() {
+	s := avlts.NewSharded[string, int]()
+	s.Insert("version", 1)
+	fmt.Println(s.CompareAndSwap("version", 1, 2))
+	fmt.Println(s.CompareAndSwap("version", 1, 3))
+	v, _ := s.Get("version")
+	fmt.Println(v)
+	// Output:
+	// true
+	// false
+	// 2
+}
+
+func ExampleShardedTree() {
+	s := avlts.NewSharded[string, int]("h", "p")
+	for i, k := range []string{"zebra", "apple", "kiwi", "mango", "banana"} {
+		s.Insert(k, i)
+	}
+	s.Ascend(func(k string, v int) bool {
+		fmt.Print(k, " ")
+		return true
+	})
+	fmt.Println()
+	// Output: apple banana kiwi mango zebra
+}
@@ -0,0 +1,425 @@
+// Package slab provides an AVL tree that stores its nodes in one contiguous
+// slice and links them by uint32 indices instead of pointers.
+//
+// A node of a slab tree is two thirds the size of an avltrees.Node, the
+// garbage collector does not have to follow links between nodes when keys
+// and values hold no pointers, and neighbouring nodes tend to share cache
+// lines. The functions mirror those of the avltrees package. Nodes have no
+// parent links, so stepping to a neighbour goes through the tree with
+// Higher and Lower rather than Successor and Predecessor.
+//
+// A tree holds at most math.MaxUint32-1 nodes.
+package slab
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"slices"
+)
+
+// maxHeight bounds the height of an AVL tree with fewer than 1<<32 nodes
+// (about 1.44*log2(n)), so traversals can use a fixed-size stack.
+const maxHeight = 48
+
+// Node is an entry of a slab tree.
+//
+// Pointers to nodes returned by the functions in this package point into
+// the tree's slab and are only valid until the tree is next modified.
+type Node[K cmp.Ordered, V any] struct {
+	key    K
+	value  V
+	left   uint32
+	right  uint32
+	size   uint32
+	height int8
+}
+
+// Key returns the key of the node.
+func (n *Node[K, V]) Key() K {
+	return n.key
+}
+
+// Value returns the value of the node.
+func (n *Node[K, V]) Value() V {
+	return n.value
+}
+
+// Tree is an AVL tree backed by a slab of nodes.
+// The zero value is an empty tree ready to use.
+type Tree[K cmp.Ordered, V any] struct {
+	// nodes[0] is a sentinel standing for "no node": its height and size
+	// are zero, so child links need no nil checks. It is never written.
+	nodes []Node[K, V]
+	root  uint32
+	free  uint32 // head of the list of released slots, linked through left
+	count int
+}
+
+// New creates a new empty slab tree.
+func New[K cmp.Ordered, V any]() *Tree[K, V] {
+	return &Tree[K, V]{}
+}
+
+// Clear removes all nodes from the tree and releases its slab.
+func Clear[K cmp.Ordered, V any](t *Tree[K, V]) {
+	*t = Tree[K, V]{}
+}
+
+// Insert adds a key-value pair to the tree, overwriting the value if the
+// key already exists.
+// Returns true if the key was newly inserted, or false if it was updated.
+func Insert[K cmp.Ordered, V any](t *Tree[K, V], key K, value V) bool {
+	if len(t.nodes) == 0 {
+		t.nodes = make([]Node[K, V], 1, 8)
+	}
+	if t.free == 0 {
+		if len(t.nodes) > math.MaxUint32-1 {
+			panic("slab: tree is full")
+		}
+		// Grow before descending so that node pointers stay valid below.
+		t.nodes = slices.Grow(t.nodes, 1)
+	}
+	var inserted bool
+	t.root, inserted = insert(t, t.root, key, value)
+	if inserted {
+		t.count++
+	}
+	return inserted
+}
+
+// Delete removes the node with the given key from the tree.
+// Returns true if the key was found and removed.
+func Delete[K cmp.Ordered, V any](t *Tree[K, V], key K) bool {
+	var deleted bool
+	t.root, deleted = remove(t, t.root, key)
+	if deleted {
+		t.count--
+	}
+	return deleted
+}
+
+// Search finds and returns the node with the given key in the tree.
+// Returns the node and true if found, or nil and false otherwise.
+func Search[K cmp.Ordered, V any](t *Tree[K, V], key K) (*Node[K, V], bool) {
+	i := t.root
+	for i != 0 {
+		n := &t.nodes[i]
+		switch {
+		case key < n.key:
+			i = n.left
+		case key > n.key:
+			i = n.right
+		default:
+			return n, true
+		}
+	}
+	return nil, false
+}
+
+// Contains reports whether the key exists in the tree.
+func Contains[K cmp.Ordered, V any](t *Tree[K, V], key K) bool {
+	_, ok := Search(t, key)
+	return ok
+}
+
+// Get returns the value stored under key.
+// Returns the zero value and false if the key does not exist.
+func Get[K cmp.Ordered, V any](t *Tree[K, V], key K) (V, bool) {
+	if n, ok := Search(t, key); ok {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Min returns the node with the smallest key in the tree.
+func Min[K cmp.Ordered, V any](t *Tree[K, V]) (*Node[K, V], bool) {
+	if t.root == 0 {
+		return nil, false
+	}
+	i := t.root
+	for t.nodes[i].left != 0 {
+		i = t.nodes[i].left
+	}
+	return &t.nodes[i], true
+}
+
+// Max returns the node with the largest key in the tree.
+func Max[K cmp.Ordered, V any](t *Tree[K, V]) (*Node[K, V], bool) {
+	if t.root == 0 {
+		return nil, false
+	}
+	i := t.root
+	for t.nodes[i].right != 0 {
+		i = t.nodes[i].right
+	}
+	return &t.nodes[i], true
+}
+
+// Ceiling returns the node with the smallest key greater than or equal to key.
+func Ceiling[K cmp.Ordered, V any](t *Tree[K, V], key K) (*Node[K, V], bool) {
+	return bound(t, func(k K) bool { return k >= key }, true)
+}
+
+// Higher returns the node with the smallest key strictly greater than key.
+func Higher[K cmp.Ordered, V any](t *Tree[K, V], key K) (*Node[K, V], bool) {
+	return bound(t, func(k K) bool { return k > key }, true)
+}
+
+// Floor returns the node with the largest key less than or equal to key.
+func Floor[K cmp.Ordered, V any](t *Tree[K, V], key K) (*Node[K, V], bool) {
+	return bound(t, func(k K) bool { return k <= key }, false)
+}
+
+// Lower returns the node with the largest key strictly less than key.
+func Lower[K cmp.Ordered, V any](t *Tree[K, V], key K) (*Node[K, V], bool) {
+	return bound(t, func(k K) bool { return k < key }, false)
+}
+
+// bound returns the smallest (or, if lowest is false, the largest) node
+// whose key satisfies ok, which must be monotonic in the key.
+func bound[K cmp.Ordered, V any](t *Tree[K, V], ok func(K) bool, lowest bool) (*Node[K, V], bool) {
+	var found uint32
+	i := t.root
+	for i != 0 {
+		n := &t.nodes[i]
+		if ok(n.key) {
+			found = i
+			if lowest {
+				i = n.left
+			} else {
+				i = n.right
+			}
+		} else if lowest {
+			i = n.right
+		} else {
+			i = n.left
+		}
+	}
+	if found == 0 {
+		return nil, false
+	}
+	return &t.nodes[found], true
+}
+
+// InOrder returns an iterator for in-order traversal of the tree.
+func InOrder[K cmp.Ordered, V any](t *Tree[K, V]) iter.Seq[Node[K, V]] {
+	return func(yield func(Node[K, V]) bool) {
+		var stack [maxHeight]uint32
+		walk(t, &stack, 0, t.root, func(n *Node[K, V]) bool { return yield(*n) })
+	}
+}
+
+// Range returns an iterator for nodes with keys in the range [from, to).
+// It seeks to from in O(log n) before walking in key order.
+func Range[K cmp.Ordered, V any](t *Tree[K, V], from, to K) iter.Seq[Node[K, V]] {
+	return func(yield func(Node[K, V]) bool) {
+		// Push the ancestors that are at or after from, as the in-order
+		// walk would have them on its stack on reaching the first key.
+		var stack [maxHeight]uint32
+		top := 0
+		for i := t.root; i != 0; {
+			if t.nodes[i].key < from {
+				i = t.nodes[i].right
+			} else {
+				stack[top] = i
+				top++
+				i = t.nodes[i].left
+			}
+		}
+		walk(t, &stack, top, 0, func(n *Node[K, V]) bool {
+			return n.key < to && yield(*n)
+		})
+	}
+}
+
+// walk continues an in-order traversal whose pending ancestors are
+// stack[:top], starting with the subtree rooted at i.
+func walk[K cmp.Ordered, V any](t *Tree[K, V], stack *[maxHeight]uint32, top int, i uint32, visit func(*Node[K, V]) bool) {
+	for i != 0 || top > 0 {
+		for i != 0 {
+			stack[top] = i
+			top++
+			i = t.nodes[i].left
+		}
+		top--
+		n := &t.nodes[stack[top]]
+		if !visit(n) {
+			return
+		}
+		i = n.right
+	}
+}
+
+// Rank returns the number of keys in the tree that are less than key.
+func Rank[K cmp.Ordered, V any](t *Tree[K, V], key K) int {
+	rank := 0
+	i := t.root
+	for i != 0 {
+		n := &t.nodes[i]
+		if key <= n.key {
+			i = n.left
+		} else {
+			rank += int(t.nodes[n.left].size) + 1
+			i = n.right
+		}
+	}
+	return rank
+}
+
+// Kth returns the node with the 0-based k-th smallest key in the tree.
+func Kth[K cmp.Ordered, V any](t *Tree[K, V], k int) (*Node[K, V], bool) {
+	if k < 0 || k >= t.count {
+		return nil, false
+	}
+	i := t.root
+	for {
+		n := &t.nodes[i]
+		leftSize := int(t.nodes[n.left].size)
+		switch {
+		case k < leftSize:
+			i = n.left
+		case k > leftSize:
+			k -= leftSize + 1
+			i = n.right
+		default:
+			return n, true
+		}
+	}
+}
+
+// Len returns the number of nodes in the tree.
+func Len[K cmp.Ordered, V any](t *Tree[K, V]) int {
+	return t.count
+}
+
+// Height returns the height of the tree.
+func Height[K cmp.Ordered, V any](t *Tree[K, V]) int {
+	if t.root == 0 {
+		return 0
+	}
+	return int(t.nodes[t.root].height)
+}
+
+// alloc stores a new leaf and returns its index. Insert has already made
+// room, so the slab is never reallocated here.
+func alloc[K cmp.Ordered, V any](t *Tree[K, V], key K, value V) uint32 {
+	n := Node[K, V]{key: key, value: value, size: 1, height: 1}
+	if i := t.free; i != 0 {
+		t.free = t.nodes[i].left
+		t.nodes[i] = n
+		return i
+	}
+	t.nodes = append(t.nodes, n)
+	return uint32(len(t.nodes) - 1)
+}
+
+// release puts slot i on the free list, dropping its key and value.
+func release[K cmp.Ordered, V any](t *Tree[K, V], i uint32) {
+	t.nodes[i] = Node[K, V]{left: t.free}
+	t.free = i
+}
+
+func insert[K cmp.Ordered, V any](t *Tree[K, V], i uint32, key K, value V) (uint32, bool) {
+	if i == 0 {
+		return alloc(t, key, value), true
+	}
+	n := &t.nodes[i]
+	var inserted bool
+	switch {
+	case key < n.key:
+		n.left, inserted = insert(t, n.left, key, value)
+	case key > n.key:
+		n.right, inserted = insert(t, n.right, key, value)
+	default:
+		n.value = value
+		return i, false
+	}
+	return rebalance(t, i), inserted
+}
+
+func remove[K cmp.Ordered, V any](t *Tree[K, V], i uint32, key K) (uint32, bool) {
+	if i == 0 {
+		return 0, false
+	}
+	n := &t.nodes[i]
+	var deleted bool
+	switch {
+	case key < n.key:
+		n.left, deleted = remove(t, n.left, key)
+	case key > n.key:
+		n.right, deleted = remove(t, n.right, key)
+	case n.left == 0 || n.right == 0:
+		child := n.left | n.right
+		release(t, i)
+		return child, true
+	default:
+		var m uint32
+		n.right, m = removeMin(t, n.right)
+		n.key, n.value = t.nodes[m].key, t.nodes[m].value
+		release(t, m)
+		deleted = true
+	}
+	return rebalance(t, i), deleted
+}
+
+// removeMin unlinks the smallest node of the subtree rooted at i without
+// releasing it. It returns the new subtree root and the unlinked index.
+func removeMin[K cmp.Ordered, V any](t *Tree[K, V], i uint32) (uint32, uint32) {
+	n := &t.nodes[i]
+	if n.left == 0 {
+		return n.right, i
+	}
+	var m uint32
+	n.left, m = removeMin(t, n.left)
+	return rebalance(t, i), m
+}
+
+func update[K cmp.Ordered, V any](t *Tree[K, V], i uint32) {
+	n := &t.nodes[i]
+	l, r := &t.nodes[n.left], &t.nodes[n.right]
+	n.height = max(l.height, r.height) + 1
+	n.size = l.size + r.size + 1
+}
+
+func rebalance[K cmp.Ordered, V any](t *Tree[K, V], i uint32) uint32 {
+	update(t, i)
+	n := &t.nodes[i]
+	switch bf := balanceFactor(t, i); {
+	case bf > 1:
+		if balanceFactor(t, n.left) < 0 {
+			n.left = rotateLeft(t, n.left)
+		}
+		return rotateRight(t, i)
+	case bf < -1:
+		if balanceFactor(t, n.right) > 0 {
+			n.right = rotateRight(t, n.right)
+		}
+		return rotateLeft(t, i)
+	}
+	return i
+}
+
+func balanceFactor[K cmp.Ordered, V any](t *Tree[K, V], i uint32) int {
+	n := &t.nodes[i]
+	return int(t.nodes[n.left].height) - int(t.nodes[n.right].height)
+}
+
+func rotateLeft[K cmp.Ordered, V any](t *Tree[K, V], z uint32) uint32 {
+	y := t.nodes[z].right
+	t.nodes[z].right = t.nodes[y].left
+	t.nodes[y].left = z
+	update(t, z)
+	update(t, y)
+	return y
+}
+
+func rotateRight[K cmp.Ordered, V any](t *Tree[K, V], z uint32) uint32 {
+	y := t.nodes[z].left
+	t.nodes[z].left = t.nodes[y].right
+	t.nodes[y].right = z
+	update(t, z)
+	update(t, y)
+	return y
+}
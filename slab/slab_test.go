@@ -0,0 +1,228 @@
+package slab_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"unsafe"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/byExist/avltrees/slab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func keys[V any](t *slab.Tree[int, V]) []int {
+	var out []int
+	for n := range slab.InOrder(t) {
+		out = append(out, n.Key())
+	}
+	return out
+}
+
+func TestInsert(t *testing.T) {
+	tree := slab.New[int, string]()
+	assert.True(t, slab.Insert(tree, 2, "b"))
+	assert.True(t, slab.Insert(tree, 1, "a"))
+	assert.False(t, slab.Insert(tree, 2, "B"), "existing keys are updated")
+	assert.Equal(t, 2, slab.Len(tree))
+
+	v, ok := slab.Get(tree, 2)
+	require.True(t, ok)
+	assert.Equal(t, "B", v)
+	assert.Equal(t, []int{1, 2}, keys(tree))
+}
+
+func TestZeroValue(t *testing.T) {
+	var tree slab.Tree[int, int]
+	_, ok := slab.Min(&tree)
+	assert.False(t, ok)
+	assert.False(t, slab.Delete(&tree, 1))
+	slab.Insert(&tree, 1, 1)
+	assert.True(t, slab.Contains(&tree, 1))
+}
+
+func TestDelete(t *testing.T) {
+	tree := slab.New[int, int]()
+	for i := range 10 {
+		slab.Insert(tree, i, i)
+	}
+	assert.True(t, slab.Delete(tree, 3))
+	assert.False(t, slab.Delete(tree, 3))
+	assert.True(t, slab.Delete(tree, 0))
+	assert.Equal(t, []int{1, 2, 4, 5, 6, 7, 8, 9}, keys(tree))
+
+	// Released slots are reused.
+	slab.Insert(tree, 3, 3)
+	slab.Insert(tree, 0, 0)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, keys(tree))
+}
+
+func TestClear(t *testing.T) {
+	tree := slab.New[int, int]()
+	slab.Insert(tree, 1, 1)
+	slab.Clear(tree)
+	assert.Equal(t, 0, slab.Len(tree))
+	assert.Equal(t, 0, slab.Height(tree))
+	assert.Empty(t, keys(tree))
+}
+
+func TestNeighbours(t *testing.T) {
+	tree := slab.New[int, string]()
+	for _, k := range []int{10, 20, 30} {
+		slab.Insert(tree, k, "")
+	}
+	cases := []struct {
+		name string
+		f    func(*slab.Tree[int, string], int) (*slab.Node[int, string], bool)
+		key  int
+		want int
+		ok   bool
+	}{
+		{"Ceiling", slab.Ceiling[int, string], 15, 20, true},
+		{"CeilingExact", slab.Ceiling[int, string], 20, 20, true},
+		{"CeilingNone", slab.Ceiling[int, string], 31, 0, false},
+		{"Higher", slab.Higher[int, string], 20, 30, true},
+		{"Floor", slab.Floor[int, string], 25, 20, true},
+		{"FloorNone", slab.Floor[int, string], 5, 0, false},
+		{"Lower", slab.Lower[int, string], 20, 10, true},
+	}
+	for _, c := range cases {
+		n, ok := c.f(tree, c.key)
+		require.Equal(t, c.ok, ok, c.name)
+		if ok {
+			assert.Equal(t, c.want, n.Key(), c.name)
+		}
+	}
+	n, _ := slab.Min(tree)
+	assert.Equal(t, 10, n.Key())
+	n, _ = slab.Max(tree)
+	assert.Equal(t, 30, n.Key())
+}
+
+func TestRange(t *testing.T) {
+	tree := slab.New[int, string]()
+	for i := 0; i < 100; i += 2 {
+		slab.Insert(tree, i, "")
+	}
+	var got []int
+	for n := range slab.Range(tree, 13, 21) {
+		got = append(got, n.Key())
+	}
+	assert.Equal(t, []int{14, 16, 18, 20}, got)
+
+	got = got[:0]
+	for n := range slab.Range(tree, 0, 100) {
+		if n.Key() == 6 {
+			break
+		}
+		got = append(got, n.Key())
+	}
+	assert.Equal(t, []int{0, 2, 4}, got)
+}
+
+func TestRankAndKth(t *testing.T) {
+	tree := slab.New[int, string]()
+	for i := 0; i < 100; i += 2 {
+		slab.Insert(tree, i, "")
+	}
+	assert.Equal(t, 0, slab.Rank(tree, 0))
+	assert.Equal(t, 5, slab.Rank(tree, 9))
+	assert.Equal(t, 50, slab.Rank(tree, 1000))
+
+	n, ok := slab.Kth(tree, 5)
+	require.True(t, ok)
+	assert.Equal(t, 10, n.Key())
+	_, ok = slab.Kth(tree, 50)
+	assert.False(t, ok)
+}
+
+func TestRandomAgainstTree(t *testing.T) {
+	r := rand.New(rand.NewPCG(7, 8))
+	tree := slab.New[int, int]()
+	want := avlts.New[int, int]()
+	for range 20000 {
+		k := r.IntN(500)
+		if r.IntN(3) == 0 {
+			assert.Equal(t, avlts.Delete(want, k), slab.Delete(tree, k))
+		} else {
+			assert.Equal(t, avlts.Insert(want, k, k), slab.Insert(tree, k, k))
+		}
+	}
+	assert.Equal(t, avlts.Len(want), slab.Len(tree))
+	assert.Equal(t, avlts.AppendKeys(want, nil), keys(tree))
+	assert.LessOrEqual(t, slab.Height(tree), avlts.Height(want)+1)
+	for k := range 500 {
+		assert.Equal(t, avlts.Rank(want, k), slab.Rank(tree, k))
+	}
+}
+
+func TestNodeSize(t *testing.T) {
+	assert.LessOrEqual(t, unsafe.Sizeof(slab.Node[int, int]{}), unsafe.Sizeof(avlts.Node[int, int]{})*2/3)
+}
+
+func ExampleInsert() {
+	tree := slab.New[string, int]()
+	slab.Insert(tree, "b", 2)
+	slab.Insert(tree, "a", 1)
+	slab.Insert(tree, "c", 3)
+	for n := range slab.InOrder(tree) {
+		fmt.Println(n.Key(), n.Value())
+	}
+	// Output:
+	// a 1
+	// b 2
+	// c 3
+}
+
+func ExampleRange() {
+	tree := slab.New[int, string]()
+	for i := 1; i <= 10; i++ {
+		slab.Insert(tree, i, "")
+	}
+	var got []int
+	for n := range slab.Range(tree, 4, 8) {
+		got = append(got, n.Key())
+	}
+	fmt.Println(got)
+	// Output: [4 5 6 7]
+}
+
+func BenchmarkInsert(b *testing.B) {
+	keys := rand.New(rand.NewPCG(1, 1)).Perm(100_000)
+	b.Run("slab", func(b *testing.B) {
+		for range b.N {
+			tree := slab.New[int, int]()
+			for _, k := range keys {
+				slab.Insert(tree, k, k)
+			}
+		}
+	})
+	b.Run("avltrees", func(b *testing.B) {
+		for range b.N {
+			tree := avlts.New[int, int]()
+			for _, k := range keys {
+				avlts.Insert(tree, k, k)
+			}
+		}
+	})
+}
+
+func BenchmarkSearch(b *testing.B) {
+	keys := rand.New(rand.NewPCG(1, 1)).Perm(100_000)
+	st, at := slab.New[int, int](), avlts.New[int, int]()
+	for _, k := range keys {
+		slab.Insert(st, k, k)
+		avlts.Insert(at, k, k)
+	}
+	b.Run("slab", func(b *testing.B) {
+		for i := range b.N {
+			slab.Search(st, keys[i%len(keys)])
+		}
+	})
+	b.Run("avltrees", func(b *testing.B) {
+		for i := range b.N {
+			avlts.Search(at, keys[i%len(keys)])
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package sorted
+
+import (
+	"cmp"
+	"iter"
+
+	avlts "github.com/byExist/avltrees"
+)
+
+// SortedDict is a map whose keys are kept in ascending order, like
+// sortedcontainers.SortedDict.
+// Use NewSortedDict to create one; the zero value is not usable.
+type SortedDict[K cmp.Ordered, V any] struct {
+	tree *avlts.Tree[K, V]
+}
+
+// NewSortedDict returns a new empty SortedDict.
+func NewSortedDict[K cmp.Ordered, V any]() *SortedDict[K, V] {
+	return &SortedDict[K, V]{tree: avlts.New[K, V]()}
+}
+
+// Len returns the number of keys in the dict.
+func (d *SortedDict[K, V]) Len() int {
+	return avlts.Len(d.tree)
+}
+
+// Set maps key to value, replacing any previous value.
+func (d *SortedDict[K, V]) Set(key K, value V) {
+	avlts.Insert(d.tree, key, value)
+}
+
+// Get returns the value of key.
+// Returns false if key is not in the dict.
+func (d *SortedDict[K, V]) Get(key K) (V, bool) {
+	return avlts.Get(d.tree, key)
+}
+
+// Contains reports whether key is in the dict.
+func (d *SortedDict[K, V]) Contains(key K) bool {
+	return avlts.Contains(d.tree, key)
+}
+
+// Pop removes key and returns its value.
+// Returns false if key is not in the dict.
+func (d *SortedDict[K, V]) Pop(key K) (V, bool) {
+	v, ok := avlts.Get(d.tree, key)
+	if ok {
+		avlts.Delete(d.tree, key)
+	}
+	return v, ok
+}
+
+// Index returns the position of key.
+// Returns false if key is not in the dict.
+func (d *SortedDict[K, V]) Index(key K) (int, bool) {
+	if !avlts.Contains(d.tree, key) {
+		return 0, false
+	}
+	return avlts.Rank(d.tree, key), true
+}
+
+// BisectLeft returns the position at which key would be inserted before an
+// equal key.
+func (d *SortedDict[K, V]) BisectLeft(key K) int {
+	return avlts.BisectLeft(d.tree, key)
+}
+
+// BisectRight returns the position at which key would be inserted after an
+// equal key.
+func (d *SortedDict[K, V]) BisectRight(key K) int {
+	return avlts.BisectRight(d.tree, key)
+}
+
+// PeekItem returns the entry at position i; PeekItem(-1) returns the entry
+// with the largest key.
+// Returns false if i is out of range.
+func (d *SortedDict[K, V]) PeekItem(i int) (key K, value V, ok bool) {
+	i, ok = index(i, d.Len())
+	if !ok {
+		return key, value, false
+	}
+	n, _ := avlts.Kth(d.tree, i)
+	return n.Key(), n.Value(), true
+}
+
+// PopItem removes and returns the entry at position i; PopItem(-1)
+// removes the entry with the largest key.
+// Returns false if i is out of range.
+func (d *SortedDict[K, V]) PopItem(i int) (key K, value V, ok bool) {
+	key, value, ok = d.PeekItem(i)
+	if ok {
+		avlts.Delete(d.tree, key)
+	}
+	return key, value, ok
+}
+
+// IRange returns an iterator over the entries with lo <= key <= hi, in key
+// order.
+func (d *SortedDict[K, V]) IRange(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := range avlts.InOrderFrom(d.tree, lo) {
+			if n.Key() > hi || !yield(n.Key(), n.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// ISlice returns an iterator over the entries at positions [start, stop),
+// with Python slice semantics for negative and out-of-range positions.
+func (d *SortedDict[K, V]) ISlice(start, stop int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		start, stop := bounds(start, stop, d.Len())
+		for n := range avlts.RangeByRank(d.tree, start, stop) {
+			if !yield(n.Key(), n.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the entries in key order.
+func (d *SortedDict[K, V]) All() iter.Seq2[K, V] {
+	return d.ISlice(0, d.Len())
+}
@@ -0,0 +1,130 @@
+package sorted_test
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"testing"
+
+	"github.com/byExist/avltrees/sorted"
+	"github.com/stretchr/testify/assert"
+)
+
+func dictKeys(seq iter.Seq2[string, int]) []string {
+	var keys []string
+	for k := range seq {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestSortedDictSet(t *testing.T) {
+	d := sorted.NewSortedDict[string, int]()
+	for i, k := range []string{"c", "a", "d", "b"} {
+		d.Set(k, i)
+	}
+	d.Set("a", 10)
+	assert.Equal(t, 4, d.Len())
+	v, ok := d.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 10, v)
+	_, ok = d.Get("z")
+	assert.False(t, ok)
+	assert.True(t, d.Contains("d"))
+	assert.Equal(t, []string{"a", "b", "c", "d"}, dictKeys(d.All()))
+}
+
+func TestSortedDictPop(t *testing.T) {
+	d := sorted.NewSortedDict[string, int]()
+	for i, k := range []string{"c", "a", "d", "b"} {
+		d.Set(k, i)
+	}
+	v, ok := d.Pop("c")
+	assert.True(t, ok)
+	assert.Equal(t, 0, v)
+	_, ok = d.Pop("c")
+	assert.False(t, ok)
+	assert.Equal(t, 3, d.Len())
+}
+
+func TestSortedDictIndex(t *testing.T) {
+	d := sorted.NewSortedDict[string, int]()
+	for i, k := range []string{"c", "a", "d", "b"} {
+		d.Set(k, i)
+	}
+	i, ok := d.Index("c")
+	assert.True(t, ok)
+	assert.Equal(t, 2, i)
+	_, ok = d.Index("bb")
+	assert.False(t, ok)
+	assert.Equal(t, 2, d.BisectLeft("bb"))
+	assert.Equal(t, 2, d.BisectRight("b"))
+}
+
+func TestSortedDictPeekItem(t *testing.T) {
+	d := sorted.NewSortedDict[string, int]()
+	for i, k := range []string{"c", "a", "d", "b"} {
+		d.Set(k, i)
+	}
+	k, v, ok := d.PeekItem(-1)
+	assert.True(t, ok)
+	assert.Equal(t, "d", k)
+	assert.Equal(t, 2, v)
+	k, _, ok = d.PeekItem(0)
+	assert.True(t, ok)
+	assert.Equal(t, "a", k)
+	_, _, ok = d.PeekItem(4)
+	assert.False(t, ok)
+	assert.Equal(t, 4, d.Len())
+}
+
+func TestSortedDictPopItem(t *testing.T) {
+	d := sorted.NewSortedDict[string, int]()
+	for i, k := range []string{"c", "a", "d", "b"} {
+		d.Set(k, i)
+	}
+	k, v, ok := d.PopItem(-1)
+	assert.True(t, ok)
+	assert.Equal(t, "d", k)
+	assert.Equal(t, 2, v)
+	k, _, ok = d.PopItem(1)
+	assert.True(t, ok)
+	assert.Equal(t, "b", k)
+	_, _, ok = d.PopItem(-3)
+	assert.False(t, ok)
+	assert.Equal(t, []string{"a", "c"}, dictKeys(d.All()))
+}
+
+func TestSortedDictIRange(t *testing.T) {
+	d := sorted.NewSortedDict[string, int]()
+	for i, k := range []string{"c", "a", "d", "b"} {
+		d.Set(k, i)
+	}
+	assert.Equal(t, map[string]int{"b": 3, "c": 0}, maps.Collect(d.IRange("b", "c")))
+	assert.Empty(t, maps.Collect(d.IRange("x", "z")))
+}
+
+func TestSortedDictISlice(t *testing.T) {
+	d := sorted.NewSortedDict[string, int]()
+	for i, k := range []string{"c", "a", "d", "b"} {
+		d.Set(k, i)
+	}
+	assert.Equal(t, []string{"b", "c"}, dictKeys(d.ISlice(1, 3)))
+	assert.Equal(t, []string{"d"}, dictKeys(d.ISlice(-1, 10)))
+}
+
+func ExampleSortedDict() {
+	d := sorted.NewSortedDict[int, string]()
+	d.Set(30, "c")
+	d.Set(10, "a")
+	d.Set(20, "b")
+	k, v, _ := d.PeekItem(-1)
+	fmt.Println(k, v)
+	for k, v := range d.IRange(10, 20) {
+		fmt.Println(k, v)
+	}
+	// Output:
+	// 30 c
+	// 10 a
+	// 20 b
+}
@@ -0,0 +1,125 @@
+package sorted
+
+import (
+	"cmp"
+	"iter"
+
+	avlts "github.com/byExist/avltrees"
+)
+
+// SortedList is a list of values kept in ascending order, like
+// sortedcontainers.SortedList. It may hold equal values more than once.
+// It wraps avltrees.SortedList with Python's method names and position
+// rules. Use NewSortedList to create one; the zero value is not usable.
+type SortedList[T cmp.Ordered] struct {
+	list *avlts.SortedList[T]
+}
+
+// NewSortedList returns a new SortedList holding values.
+func NewSortedList[T cmp.Ordered](values ...T) *SortedList[T] {
+	l := &SortedList[T]{list: avlts.NewSortedList[T]()}
+	for _, v := range values {
+		l.list.Add(v)
+	}
+	return l
+}
+
+// Len returns the number of values in the list, counting duplicates.
+func (l *SortedList[T]) Len() int {
+	return l.list.Len()
+}
+
+// Add inserts v into the list after any values equal to it.
+func (l *SortedList[T]) Add(v T) {
+	l.list.Add(v)
+}
+
+// Remove removes one occurrence of v, like discard.
+// Returns false if v is not in the list.
+func (l *SortedList[T]) Remove(v T) bool {
+	return l.list.Remove(v)
+}
+
+// Count returns the number of occurrences of v.
+func (l *SortedList[T]) Count(v T) int {
+	return l.list.Count(v)
+}
+
+// At returns the value at position i.
+// Returns false if i is out of range.
+func (l *SortedList[T]) At(i int) (T, bool) {
+	i, ok := index(i, l.Len())
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return l.list.At(i)
+}
+
+// Index returns the position of the first occurrence of v.
+// Returns false if v is not in the list.
+func (l *SortedList[T]) Index(v T) (int, bool) {
+	i := l.list.IndexOf(v)
+	return i, i >= 0
+}
+
+// BisectLeft returns the position at which v would be inserted before any
+// equal values.
+func (l *SortedList[T]) BisectLeft(v T) int {
+	return l.list.Rank(v)
+}
+
+// BisectRight returns the position at which v would be inserted after any
+// equal values.
+func (l *SortedList[T]) BisectRight(v T) int {
+	return l.list.Rank(v) + l.list.Count(v)
+}
+
+// Pop removes and returns the value at position i; Pop(-1) removes the
+// largest value.
+// Returns false if i is out of range.
+func (l *SortedList[T]) Pop(i int) (T, bool) {
+	i, ok := index(i, l.Len())
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return l.list.DeleteAt(i)
+}
+
+// IRange returns an iterator over the values v with lo <= v <= hi, in
+// ascending order.
+func (l *SortedList[T]) IRange(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range l.list.AllFromIndex(l.list.Rank(lo)) {
+			if v > hi || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ISlice returns an iterator over the values at positions [start, stop),
+// with Python slice semantics for negative and out-of-range positions.
+func (l *SortedList[T]) ISlice(start, stop int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		start, stop := bounds(start, stop, l.Len())
+		if start >= stop {
+			return
+		}
+		i := start
+		for v := range l.list.AllFromIndex(start) {
+			if !yield(v) {
+				return
+			}
+			if i++; i == stop {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the values in ascending order.
+func (l *SortedList[T]) All() iter.Seq[T] {
+	return l.list.All()
+}
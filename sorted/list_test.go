@@ -0,0 +1,149 @@
+package sorted_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/byExist/avltrees/sorted"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedListAdd(t *testing.T) {
+	l := sorted.NewSortedList(3, 1, 2, 1)
+	l.Add(2)
+	assert.Equal(t, 5, l.Len())
+	assert.Equal(t, []int{1, 1, 2, 2, 3}, slices.Collect(l.All()))
+	assert.Equal(t, 2, l.Count(1))
+	assert.Equal(t, 0, l.Count(9))
+}
+
+func TestSortedListRemove(t *testing.T) {
+	l := sorted.NewSortedList(1, 1, 2)
+	assert.True(t, l.Remove(1))
+	assert.True(t, l.Remove(1))
+	assert.False(t, l.Remove(1))
+	assert.Equal(t, []int{2}, slices.Collect(l.All()))
+}
+
+func TestSortedListAt(t *testing.T) {
+	l := sorted.NewSortedList(10, 20, 20, 30)
+	for i, want := range map[int]int{0: 10, 1: 20, 2: 20, 3: 30, -1: 30, -4: 10} {
+		v, ok := l.At(i)
+		require.True(t, ok, "i=%d", i)
+		assert.Equal(t, want, v, "i=%d", i)
+	}
+	_, ok := l.At(4)
+	assert.False(t, ok)
+	_, ok = l.At(-5)
+	assert.False(t, ok)
+}
+
+func TestSortedListIndex(t *testing.T) {
+	l := sorted.NewSortedList(10, 20, 20, 30)
+	i, ok := l.Index(20)
+	assert.True(t, ok)
+	assert.Equal(t, 1, i)
+	_, ok = l.Index(25)
+	assert.False(t, ok)
+
+	assert.Equal(t, 1, l.BisectLeft(20))
+	assert.Equal(t, 3, l.BisectRight(20))
+	assert.Equal(t, 3, l.BisectLeft(25))
+	assert.Equal(t, 3, l.BisectRight(25))
+}
+
+func TestSortedListPop(t *testing.T) {
+	l := sorted.NewSortedList(10, 20, 20, 30)
+	v, ok := l.Pop(-1)
+	assert.True(t, ok)
+	assert.Equal(t, 30, v)
+	v, ok = l.Pop(1)
+	assert.True(t, ok)
+	assert.Equal(t, 20, v)
+	_, ok = l.Pop(5)
+	assert.False(t, ok)
+	assert.Equal(t, []int{10, 20}, slices.Collect(l.All()))
+}
+
+func TestSortedListIRange(t *testing.T) {
+	l := sorted.NewSortedList(5, 10, 10, 15, 20)
+	assert.Equal(t, []int{10, 10, 15}, slices.Collect(l.IRange(10, 15)))
+	assert.Equal(t, []int{10, 10}, slices.Collect(l.IRange(6, 14)))
+	assert.Empty(t, slices.Collect(l.IRange(16, 19)))
+	for v := range l.IRange(0, 100) {
+		assert.Equal(t, 5, v)
+		break
+	}
+}
+
+func TestSortedListISlice(t *testing.T) {
+	l := sorted.NewSortedList(1, 2, 2, 2, 3, 4)
+	assert.Equal(t, []int{2, 2, 3}, slices.Collect(l.ISlice(2, 5)))
+	assert.Equal(t, []int{3, 4}, slices.Collect(l.ISlice(-2, 100)))
+	assert.Equal(t, []int{1, 2}, slices.Collect(l.ISlice(-100, 2)))
+	assert.Empty(t, slices.Collect(l.ISlice(4, 2)))
+}
+
+func TestSortedListRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	l := sorted.NewSortedList[int]()
+	var want []int
+	for range 2000 {
+		v := r.Intn(50)
+		switch r.Intn(3) {
+		case 0:
+			i := r.Intn(len(want)+2) - 1
+			got, ok := l.Pop(i)
+			if j, inRange := pyIndex(i, len(want)); inRange {
+				require.True(t, ok)
+				assert.Equal(t, want[j], got)
+				want = slices.Delete(want, j, j+1)
+			} else {
+				assert.False(t, ok)
+			}
+		default:
+			l.Add(v)
+			i, _ := slices.BinarySearch(want, v+1)
+			want = slices.Insert(want, i, v)
+		}
+	}
+	assert.Equal(t, want, slices.Collect(l.All()))
+	for range 100 {
+		start, stop := r.Intn(len(want)+10)-5, r.Intn(len(want)+10)-5
+		a, b := pySlice(start, stop, len(want))
+		assert.Equal(t, want[a:b], append([]int{}, slices.Collect(l.ISlice(start, stop))...), "[%d:%d]", start, stop)
+	}
+}
+
+func pyIndex(i, n int) (int, bool) {
+	if i < 0 {
+		i += n
+	}
+	return i, i >= 0 && i < n
+}
+
+func pySlice(start, stop, n int) (int, int) {
+	clamp := func(i int) int {
+		if i < 0 {
+			i += n
+		}
+		return min(max(i, 0), n)
+	}
+	a, b := clamp(start), clamp(stop)
+	return a, max(a, b)
+}
+
+func ExampleSortedList() {
+	l := sorted.NewSortedList(5, 1, 3, 3)
+	fmt.Println(slices.Collect(l.All()))
+	fmt.Println(l.BisectLeft(3), l.BisectRight(3))
+	v, _ := l.Pop(-1)
+	fmt.Println(v, slices.Collect(l.ISlice(-2, 10)))
+	// Output:
+	// [1 3 3 5]
+	// 1 3
+	// 5 [3 3]
+}
@@ -0,0 +1,127 @@
+package sorted
+
+import (
+	"cmp"
+	"iter"
+
+	avlts "github.com/byExist/avltrees"
+)
+
+// SortedSet is a set of distinct values kept in ascending order, like
+// sortedcontainers.SortedSet.
+// Use NewSortedSet to create one; the zero value is not usable.
+type SortedSet[T cmp.Ordered] struct {
+	tree *avlts.Tree[T, struct{}]
+}
+
+// NewSortedSet returns a new SortedSet holding values.
+func NewSortedSet[T cmp.Ordered](values ...T) *SortedSet[T] {
+	s := &SortedSet[T]{tree: avlts.New[T, struct{}]()}
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+// Len returns the number of values in the set.
+func (s *SortedSet[T]) Len() int {
+	return avlts.Len(s.tree)
+}
+
+// Add inserts v into the set.
+// Returns false if v was already present.
+func (s *SortedSet[T]) Add(v T) bool {
+	return avlts.InsertIfAbsent(s.tree, v, struct{}{})
+}
+
+// Discard removes v from the set.
+// Returns false if v was not present.
+func (s *SortedSet[T]) Discard(v T) bool {
+	return avlts.Delete(s.tree, v)
+}
+
+// Contains reports whether v is in the set.
+func (s *SortedSet[T]) Contains(v T) bool {
+	return avlts.Contains(s.tree, v)
+}
+
+// At returns the value at position i.
+// Returns false if i is out of range.
+func (s *SortedSet[T]) At(i int) (T, bool) {
+	n, ok := s.seek(i)
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return n.Key(), true
+}
+
+// Index returns the position of v.
+// Returns false if v is not in the set.
+func (s *SortedSet[T]) Index(v T) (int, bool) {
+	if !avlts.Contains(s.tree, v) {
+		return 0, false
+	}
+	return avlts.Rank(s.tree, v), true
+}
+
+// BisectLeft returns the position at which v would be inserted before an
+// equal value.
+func (s *SortedSet[T]) BisectLeft(v T) int {
+	return avlts.BisectLeft(s.tree, v)
+}
+
+// BisectRight returns the position at which v would be inserted after an
+// equal value.
+func (s *SortedSet[T]) BisectRight(v T) int {
+	return avlts.BisectRight(s.tree, v)
+}
+
+// Pop removes and returns the value at position i; Pop(-1) removes the
+// largest value.
+// Returns false if i is out of range.
+func (s *SortedSet[T]) Pop(i int) (T, bool) {
+	v, ok := s.At(i)
+	if ok {
+		avlts.Delete(s.tree, v)
+	}
+	return v, ok
+}
+
+// IRange returns an iterator over the values v with lo <= v <= hi, in
+// ascending order.
+func (s *SortedSet[T]) IRange(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := range avlts.InOrderFrom(s.tree, lo) {
+			if n.Key() > hi || !yield(n.Key()) {
+				return
+			}
+		}
+	}
+}
+
+// ISlice returns an iterator over the values at positions [start, stop),
+// with Python slice semantics for negative and out-of-range positions.
+func (s *SortedSet[T]) ISlice(start, stop int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		start, stop := bounds(start, stop, s.Len())
+		for n := range avlts.RangeByRank(s.tree, start, stop) {
+			if !yield(n.Key()) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the values in ascending order.
+func (s *SortedSet[T]) All() iter.Seq[T] {
+	return s.ISlice(0, s.Len())
+}
+
+func (s *SortedSet[T]) seek(i int) (*avlts.Node[T, struct{}], bool) {
+	i, ok := index(i, s.Len())
+	if !ok {
+		return nil, false
+	}
+	return avlts.Kth(s.tree, i)
+}
@@ -0,0 +1,80 @@
+package sorted_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/byExist/avltrees/sorted"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedSetAdd(t *testing.T) {
+	s := sorted.NewSortedSet(3, 1, 3)
+	assert.Equal(t, 2, s.Len())
+	assert.True(t, s.Add(2))
+	assert.False(t, s.Add(2))
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(s.All()))
+}
+
+func TestSortedSetDiscard(t *testing.T) {
+	s := sorted.NewSortedSet(1, 2)
+	assert.True(t, s.Discard(1))
+	assert.False(t, s.Discard(1))
+	assert.False(t, s.Contains(1))
+	assert.True(t, s.Contains(2))
+}
+
+func TestSortedSetIndex(t *testing.T) {
+	s := sorted.NewSortedSet(10, 20, 30)
+	i, ok := s.Index(30)
+	assert.True(t, ok)
+	assert.Equal(t, 2, i)
+	_, ok = s.Index(25)
+	assert.False(t, ok)
+
+	v, ok := s.At(-1)
+	assert.True(t, ok)
+	assert.Equal(t, 30, v)
+	_, ok = s.At(3)
+	assert.False(t, ok)
+
+	assert.Equal(t, 1, s.BisectLeft(20))
+	assert.Equal(t, 2, s.BisectRight(20))
+}
+
+func TestSortedSetPop(t *testing.T) {
+	s := sorted.NewSortedSet(10, 20, 30)
+	v, ok := s.Pop(0)
+	assert.True(t, ok)
+	assert.Equal(t, 10, v)
+	v, ok = s.Pop(-1)
+	assert.True(t, ok)
+	assert.Equal(t, 30, v)
+	_, ok = s.Pop(-2)
+	assert.False(t, ok)
+	assert.Equal(t, 1, s.Len())
+}
+
+func TestSortedSetIRange(t *testing.T) {
+	s := sorted.NewSortedSet(1, 3, 5, 7)
+	assert.Equal(t, []int{3, 5}, slices.Collect(s.IRange(2, 5)))
+	assert.Empty(t, slices.Collect(s.IRange(8, 9)))
+}
+
+func TestSortedSetISlice(t *testing.T) {
+	s := sorted.NewSortedSet(1, 3, 5, 7)
+	assert.Equal(t, []int{3, 5}, slices.Collect(s.ISlice(1, 3)))
+	assert.Equal(t, []int{5, 7}, slices.Collect(s.ISlice(-2, 10)))
+	assert.Empty(t, slices.Collect(s.ISlice(3, 1)))
+}
+
+func ExampleSortedSet() {
+	s := sorted.NewSortedSet("pear", "apple", "fig", "apple")
+	fmt.Println(slices.Collect(s.All()))
+	i, _ := s.Index("fig")
+	fmt.Println(i, slices.Collect(s.IRange("b", "g")))
+	// Output:
+	// [apple fig pear]
+	// 1 [fig]
+}
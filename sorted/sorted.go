@@ -0,0 +1,31 @@
+// Package sorted mirrors the API of Python's sortedcontainers package,
+// SortedList, SortedSet and SortedDict, on top of avltrees, to ease porting
+// Python code that relies on it.
+//
+// Method names follow the Python ones where Go allows: IRange and ISlice
+// for irange and islice, Pop and PeekItem for pop and peekitem. Positions
+// may be negative to count from the end, as in Python, and methods that
+// would raise IndexError, KeyError or ValueError in Python return false
+// instead. IRange includes both bounds, matching the Python default.
+package sorted
+
+// index resolves a possibly negative position in a sequence of length n.
+// Returns false if it is out of range.
+func index(i, n int) (int, bool) {
+	if i < 0 {
+		i += n
+	}
+	return i, i >= 0 && i < n
+}
+
+// bounds resolves the start and stop of a Python slice over a sequence of
+// length n, clamping them to [0, n].
+func bounds(start, stop, n int) (int, int) {
+	clamp := func(i int) int {
+		if i < 0 {
+			i += n
+		}
+		return min(max(i, 0), n)
+	}
+	return clamp(start), clamp(stop)
+}
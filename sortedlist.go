@@ -0,0 +1,145 @@
+package avltrees
+
+import (
+	"cmp"
+	"iter"
+)
+
+// SortedList is a list of values kept in ascending order that can also be
+// addressed by position, like Python's sortedcontainers.SortedList.
+// Unlike a Tree, it may hold equal values more than once.
+// Use NewSortedList to create one; the zero value is not usable.
+type SortedList[T cmp.Ordered] struct {
+	tree Tree[T, int] // value -> number of occurrences
+}
+
+// NewSortedList returns a new empty SortedList.
+func NewSortedList[T cmp.Ordered]() *SortedList[T] {
+	l := &SortedList[T]{}
+	l.tree.aug = &augment[T, int]{weight: func(_ T, count int) int64 { return int64(count) }}
+	return l
+}
+
+// Len returns the number of values in the list, counting duplicates.
+func (l *SortedList[T]) Len() int {
+	return int(weightSum(l.tree.Root))
+}
+
+// Add inserts v into the list after any values equal to it.
+func (l *SortedList[T]) Add(v T) {
+	Entry(&l.tree, v).AndModify(func(count int) int { return count + 1 }).OrInsert(1)
+}
+
+// Remove removes one occurrence of v.
+// Returns false if v is not in the list.
+func (l *SortedList[T]) Remove(v T) bool {
+	n, ok := Search(&l.tree, v)
+	if !ok {
+		return false
+	}
+	if n.value == 1 {
+		Delete(&l.tree, v)
+		return true
+	}
+	n.value--
+	refreshUp(&l.tree, n)
+	return true
+}
+
+// Count returns the number of occurrences of v.
+func (l *SortedList[T]) Count(v T) int {
+	n, ok := Search(&l.tree, v)
+	if !ok {
+		return 0
+	}
+	return n.value
+}
+
+// At returns the value at index i.
+// Returns false if i is out of range.
+func (l *SortedList[T]) At(i int) (T, bool) {
+	n, _ := l.seek(i)
+	if n == nil {
+		var zero T
+		return zero, false
+	}
+	return n.key, true
+}
+
+// IndexOf returns the index of the first occurrence of v, or -1 if v is
+// not in the list.
+func (l *SortedList[T]) IndexOf(v T) int {
+	if _, ok := Search(&l.tree, v); !ok {
+		return -1
+	}
+	return int(weightBefore(&l.tree, v))
+}
+
+// Rank returns the number of values in the list less than v, which is the
+// index v would have if it were added before any equal values.
+func (l *SortedList[T]) Rank(v T) int {
+	return int(weightBefore(&l.tree, v))
+}
+
+// DeleteAt removes and returns the value at index i.
+// Returns false if i is out of range.
+func (l *SortedList[T]) DeleteAt(i int) (T, bool) {
+	v, ok := l.At(i)
+	if ok {
+		l.Remove(v)
+	}
+	return v, ok
+}
+
+// Slice returns the values with indexes in [i, j) as a new slice.
+// Panics if the indexes are out of range, as slicing would.
+func (l *SortedList[T]) Slice(i, j int) []T {
+	if i < 0 || j < i || j > l.Len() {
+		panic("avltrees: SortedList.Slice bounds out of range")
+	}
+	out := make([]T, 0, j-i)
+	n, offset := l.seek(i)
+	for len(out) < j-i {
+		for ; offset < n.value && len(out) < j-i; offset++ {
+			out = append(out, n.key)
+		}
+		n, _ = Successor(n)
+		offset = 0
+	}
+	return out
+}
+
+// All returns an iterator over the values in ascending order.
+func (l *SortedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := range InOrder(&l.tree) {
+			for range n.value {
+				if !yield(n.key) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// AllFromIndex returns an iterator over the values with indexes i and up,
+// in ascending order. It yields nothing if i is out of range.
+func (l *SortedList[T]) AllFromIndex(i int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n, offset := l.seek(i); n != nil; n, _ = Successor(n) {
+			for ; offset < n.value; offset++ {
+				if !yield(n.key) {
+					return
+				}
+			}
+			offset = 0
+		}
+	}
+}
+
+// seek returns the node holding index i and the position of i among that
+// node's occurrences, or nil if i is out of range.
+func (l *SortedList[T]) seek(i int) (*Node[T, int], int) {
+	n, offset := seekWeight(&l.tree, int64(i))
+	return n, int(offset)
+}
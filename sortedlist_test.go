@@ -0,0 +1,182 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedListAdd(t *testing.T) {
+	l := avlts.NewSortedList[int]()
+	for _, k := range []int{5, 1, 3, 3, 1} {
+		l.Add(k)
+	}
+	assert.Equal(t, 5, l.Len())
+	assert.Equal(t, []int{1, 1, 3, 3, 5}, slices.Collect(l.All()))
+}
+
+func TestSortedListRemove(t *testing.T) {
+	l := avlts.NewSortedList[int]()
+	for _, k := range []int{2, 2, 1} {
+		l.Add(k)
+	}
+	assert.True(t, l.Remove(2))
+	assert.Equal(t, []int{1, 2}, slices.Collect(l.All()))
+	assert.True(t, l.Remove(2))
+	assert.False(t, l.Remove(2))
+	assert.Equal(t, 1, l.Len())
+}
+
+func TestSortedListCount(t *testing.T) {
+	l := avlts.NewSortedList[int]()
+	for _, k := range []int{4, 4, 4, 7} {
+		l.Add(k)
+	}
+	assert.Equal(t, 3, l.Count(4))
+	assert.Equal(t, 1, l.Count(7))
+	assert.Equal(t, 0, l.Count(5))
+}
+
+func TestSortedListAt(t *testing.T) {
+	l := avlts.NewSortedList[int]()
+	for _, k := range []int{30, 10, 20, 20} {
+		l.Add(k)
+	}
+	for i, want := range []int{10, 20, 20, 30} {
+		v, ok := l.At(i)
+		require.True(t, ok)
+		assert.Equal(t, want, v)
+	}
+	_, ok := l.At(-1)
+	assert.False(t, ok)
+	_, ok = l.At(4)
+	assert.False(t, ok)
+}
+
+func TestSortedListIndexOf(t *testing.T) {
+	l := avlts.NewSortedList[int]()
+	for _, k := range []int{30, 10, 20, 20} {
+		l.Add(k)
+	}
+	assert.Equal(t, 0, l.IndexOf(10))
+	assert.Equal(t, 1, l.IndexOf(20))
+	assert.Equal(t, 3, l.IndexOf(30))
+	assert.Equal(t, -1, l.IndexOf(25))
+}
+
+func TestSortedListRank(t *testing.T) {
+	l := avlts.NewSortedList[int]()
+	for _, k := range []int{30, 10, 20, 20} {
+		l.Add(k)
+	}
+	assert.Equal(t, 0, l.Rank(5))
+	assert.Equal(t, 1, l.Rank(20))
+	assert.Equal(t, 3, l.Rank(25))
+	assert.Equal(t, 4, l.Rank(99))
+}
+
+func TestSortedListDeleteAt(t *testing.T) {
+	l := avlts.NewSortedList[int]()
+	for _, k := range []int{1, 2, 2, 3} {
+		l.Add(k)
+	}
+	v, ok := l.DeleteAt(2)
+	require.True(t, ok)
+	assert.Equal(t, 2, v)
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(l.All()))
+
+	_, ok = l.DeleteAt(3)
+	assert.False(t, ok)
+}
+
+func TestSortedListSlice(t *testing.T) {
+	l := avlts.NewSortedList[int]()
+	for _, k := range []int{1, 2, 2, 2, 3, 4} {
+		l.Add(k)
+	}
+	assert.Equal(t, []int{2, 2, 3}, l.Slice(2, 5))
+	assert.Equal(t, []int{}, l.Slice(3, 3))
+	assert.Equal(t, []int{1, 2, 2, 2, 3, 4}, l.Slice(0, 6))
+	assert.Panics(t, func() { l.Slice(4, 7) })
+	assert.Panics(t, func() { l.Slice(3, 2) })
+}
+
+func TestSortedListAllFromIndex(t *testing.T) {
+	l := avlts.NewSortedList[int]()
+	for _, k := range []int{1, 2, 2, 2, 3, 4} {
+		l.Add(k)
+	}
+	assert.Equal(t, []int{2, 2, 3, 4}, slices.Collect(l.AllFromIndex(2)))
+	assert.Equal(t, []int{1, 2, 2, 2, 3, 4}, slices.Collect(l.AllFromIndex(0)))
+	assert.Empty(t, slices.Collect(l.AllFromIndex(6)))
+	assert.Empty(t, slices.Collect(l.AllFromIndex(-1)))
+	for v := range l.AllFromIndex(1) {
+		assert.Equal(t, 2, v)
+		break
+	}
+}
+
+func TestSortedListRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	l := avlts.NewSortedList[int]()
+	var oracle []int
+	for range 2000 {
+		if len(oracle) > 0 && r.Intn(3) == 0 {
+			i := r.Intn(len(oracle))
+			v, ok := l.DeleteAt(i)
+			require.True(t, ok)
+			require.Equal(t, oracle[i], v)
+			oracle = slices.Delete(oracle, i, i+1)
+			continue
+		}
+		v := r.Intn(100)
+		l.Add(v)
+		i, _ := slices.BinarySearch(oracle, v)
+		oracle = slices.Insert(oracle, i, v)
+		require.Equal(t, i, l.IndexOf(v))
+	}
+	assert.Equal(t, len(oracle), l.Len())
+	assert.Equal(t, oracle, slices.Collect(l.All()))
+	assert.Equal(t, oracle[10:50], l.Slice(10, 50))
+}
+
+func ExampleSortedList() {
+	l := avlts.NewSortedList[int]()
+	for _, v := range []int{40, 10, 30, 10, 20} {
+		l.Add(v)
+	}
+	fmt.Println(slices.Collect(l.All()))
+	fmt.Println(l.IndexOf(30))
+	fmt.Println(l.Slice(1, 4))
+	// Output:
+	// [10 10 20 30 40]
+	// 3
+	// [10 20 30]
+}
+
+func ExampleSortedList_At() {
+	l := avlts.NewSortedList[int]()
+	for _, k := range []int{5, 3, 9} {
+		l.Add(k)
+	}
+	v, _ := l.At(1)
+	fmt.Println(v)
+	// Output:
+	// 5
+}
+
+func ExampleSortedList_DeleteAt() {
+	l := avlts.NewSortedList[int]()
+	for _, k := range []int{5, 3, 9} {
+		l.Add(k)
+	}
+	v, _ := l.DeleteAt(0)
+	fmt.Println(v, slices.Collect(l.All()))
+	// Output:
+	// 3 [5 9]
+}
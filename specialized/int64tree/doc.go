@@ -0,0 +1,10 @@
+// Package int64tree is the slab AVL tree from package
+// github.com/byExist/avltrees/slab specialized to int64 keys.
+//
+// It is generated from the slab source with the key type fixed, so key
+// comparisons compile to plain int64 comparisons instead of going through
+// the generic implementation. The API matches package slab with the K type
+// parameter removed: slab.Tree[int64, V] becomes int64tree.Tree[V].
+package int64tree
+
+//go:generate go run ../../internal/specialize -src ../../slab/slab.go -pkg int64tree -key int64 -out int64tree.go
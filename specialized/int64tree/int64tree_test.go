@@ -0,0 +1,64 @@
+package int64tree_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/byExist/avltrees/slab"
+	"github.com/byExist/avltrees/specialized/int64tree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgainstSlab(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 3))
+	tree := int64tree.New[int]()
+	want := slab.New[int64, int]()
+	for i := range 5000 {
+		k := r.Int64N(300)
+		if r.IntN(3) == 0 {
+			assert.Equal(t, slab.Delete(want, k), int64tree.Delete(tree, k))
+		} else {
+			assert.Equal(t, slab.Insert(want, k, i), int64tree.Insert(tree, k, i))
+		}
+	}
+	require.Equal(t, slab.Len(want), int64tree.Len(tree))
+	for n := range slab.InOrder(want) {
+		v, ok := int64tree.Get(tree, n.Key())
+		require.True(t, ok)
+		assert.Equal(t, n.Value(), v)
+	}
+	assert.Equal(t, slab.Rank(want, 150), int64tree.Rank(tree, 150))
+}
+
+func ExampleInsert() {
+	tree := int64tree.New[string]()
+	int64tree.Insert(tree, 20, "b")
+	int64tree.Insert(tree, 10, "a")
+	for n := range int64tree.InOrder(tree) {
+		fmt.Println(n.Key(), n.Value())
+	}
+	// Output:
+	// 10 a
+	// 20 b
+}
+
+func BenchmarkSearch(b *testing.B) {
+	keys := rand.New(rand.NewPCG(1, 1)).Perm(100_000)
+	st, it := slab.New[int64, int](), int64tree.New[int]()
+	for _, k := range keys {
+		slab.Insert(st, int64(k), k)
+		int64tree.Insert(it, int64(k), k)
+	}
+	b.Run("slab", func(b *testing.B) {
+		for i := range b.N {
+			slab.Search(st, int64(keys[i%len(keys)]))
+		}
+	})
+	b.Run("int64tree", func(b *testing.B) {
+		for i := range b.N {
+			int64tree.Search(it, int64(keys[i%len(keys)]))
+		}
+	})
+}
@@ -0,0 +1,10 @@
+// Package stringtree is the slab AVL tree from package
+// github.com/byExist/avltrees/slab specialized to string keys.
+//
+// It is generated from the slab source with the key type fixed, so key
+// comparisons compile to plain string comparisons instead of going through
+// the generic implementation. The API matches package slab with the K type
+// parameter removed: slab.Tree[string, V] becomes stringtree.Tree[V].
+package stringtree
+
+//go:generate go run ../../internal/specialize -src ../../slab/slab.go -pkg stringtree -key string -out stringtree.go
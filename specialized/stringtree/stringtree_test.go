@@ -0,0 +1,68 @@
+package stringtree_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/byExist/avltrees/slab"
+	"github.com/byExist/avltrees/specialized/stringtree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgainstSlab(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 3))
+	tree := stringtree.New[int]()
+	want := slab.New[string, int]()
+	for i := range 5000 {
+		k := fmt.Sprint(r.IntN(300))
+		if r.IntN(3) == 0 {
+			assert.Equal(t, slab.Delete(want, k), stringtree.Delete(tree, k))
+		} else {
+			assert.Equal(t, slab.Insert(want, k, i), stringtree.Insert(tree, k, i))
+		}
+	}
+	require.Equal(t, slab.Len(want), stringtree.Len(tree))
+	for n := range slab.InOrder(want) {
+		v, ok := stringtree.Get(tree, n.Key())
+		require.True(t, ok)
+		assert.Equal(t, n.Value(), v)
+	}
+	assert.Equal(t, slab.Rank(want, "150"), stringtree.Rank(tree, "150"))
+}
+
+func ExampleInsert() {
+	tree := stringtree.New[string]()
+	stringtree.Insert(tree, "b", "beta")
+	stringtree.Insert(tree, "a", "alpha")
+	for n := range stringtree.InOrder(tree) {
+		fmt.Println(n.Key(), n.Value())
+	}
+	// Output:
+	// a alpha
+	// b beta
+}
+
+func BenchmarkSearch(b *testing.B) {
+	keys := rand.New(rand.NewPCG(1, 1)).Perm(100_000)
+	st, it := slab.New[string, int](), stringtree.New[int]()
+	for _, k := range keys {
+		slab.Insert(st, fmt.Sprint(k), k)
+		stringtree.Insert(it, fmt.Sprint(k), k)
+	}
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = fmt.Sprint(k)
+	}
+	b.Run("slab", func(b *testing.B) {
+		for i := range b.N {
+			slab.Search(st, names[i%len(names)])
+		}
+	})
+	b.Run("stringtree", func(b *testing.B) {
+		for i := range b.N {
+			stringtree.Search(it, names[i%len(names)])
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package avltrees
+
+import (
+	"cmp"
+	"unsafe"
+)
+
+// TreeStats summarizes the shape of an AVL tree.
+type TreeStats struct {
+	// Count is the number of nodes in the tree.
+	Count int
+	// Height is the height of the tree, 0 if empty.
+	Height int
+	// AvgDepth is the average depth of the nodes, with the root at depth 0.
+	AvgDepth float64
+	// Leaves is the number of nodes without children.
+	Leaves int
+	// LeftHeavy is the number of nodes with balance factor +1.
+	LeftHeavy int
+	// Balanced is the number of nodes with balance factor 0.
+	Balanced int
+	// RightHeavy is the number of nodes with balance factor -1.
+	RightHeavy int
+}
+
+// Stats walks the AVL tree and returns statistics about its shape.
+func Stats[K cmp.Ordered, V any](t *Tree[K, V]) TreeStats {
+	var s TreeStats
+	if t.Root == nil {
+		return s
+	}
+	s.Height = height(t.Root)
+	totalDepth := 0
+	type entry struct {
+		n     *Node[K, V]
+		depth int
+	}
+	stack := []entry{{t.Root, 0}}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		s.Count++
+		totalDepth += e.depth
+		if e.n.left == nil && e.n.right == nil {
+			s.Leaves++
+		}
+		switch bf := balanceFactor(e.n); {
+		case bf > 0:
+			s.LeftHeavy++
+		case bf < 0:
+			s.RightHeavy++
+		default:
+			s.Balanced++
+		}
+		if e.n.left != nil {
+			stack = append(stack, entry{e.n.left, e.depth + 1})
+		}
+		if e.n.right != nil {
+			stack = append(stack, entry{e.n.right, e.depth + 1})
+		}
+	}
+	s.AvgDepth = float64(totalDepth) / float64(s.Count)
+	return s
+}
+
+// SizeOf estimates the number of bytes held by the nodes of the AVL tree,
+// including the aggregates that options such as WithWeight add to each
+// node and the nodes reserved by NewWithCapacity that are not yet in use.
+// Memory referenced by keys and values, such as string or slice contents,
+// is not included; use SizeOfFunc to account for it.
+func SizeOf[K cmp.Ordered, V any](t *Tree[K, V]) uintptr {
+	spare := uintptr(len(t.spare))*unsafe.Sizeof(Node[K, V]{}) +
+		uintptr(len(t.spareAug))*unsafe.Sizeof(augNode[K, V]{})
+	if t.aug != nil {
+		return spare + uintptr(Len(t))*unsafe.Sizeof(augNode[K, V]{})
+	}
+	return spare + uintptr(Len(t))*unsafe.Sizeof(Node[K, V]{})
+}
+
+// SizeOfFunc estimates the number of bytes held by the AVL tree like SizeOf,
+// adding the result of extra for every entry.
+func SizeOfFunc[K cmp.Ordered, V any](t *Tree[K, V], extra func(key K, value V) uintptr) uintptr {
+	size := SizeOf(t)
+	for n := range InOrder(t) {
+		size += extra(n.key, n.value)
+	}
+	return size
+}
@@ -0,0 +1,92 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"testing"
+	"unsafe"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	tree := avlts.New[int, string]()
+	assert.Equal(t, avlts.TreeStats{}, avlts.Stats(tree))
+
+	for _, v := range []int{20, 10, 30, 5} {
+		avlts.Insert(tree, v, "")
+	}
+
+	s := avlts.Stats(tree)
+	assert.Equal(t, 4, s.Count)
+	assert.Equal(t, 3, s.Height)
+	assert.InDelta(t, 1.0, s.AvgDepth, 1e-9)
+	assert.Equal(t, 2, s.Leaves)
+	assert.Equal(t, 2, s.LeftHeavy)
+	assert.Equal(t, 2, s.Balanced)
+	assert.Equal(t, 0, s.RightHeavy)
+}
+
+func TestSizeOf(t *testing.T) {
+	tree := avlts.New[int, string]()
+	assert.Equal(t, uintptr(0), avlts.SizeOf(tree))
+
+	for i := range 10 {
+		avlts.Insert(tree, i, "")
+	}
+	assert.Equal(t, 10*unsafe.Sizeof(avlts.Node[int, string]{}), avlts.SizeOf(tree))
+}
+
+func TestSizeOfWithCapacity(t *testing.T) {
+	node := unsafe.Sizeof(avlts.Node[int, string]{})
+	tree := avlts.NewWithCapacity[int, string](8)
+	assert.Equal(t, 8*node, avlts.SizeOf(tree))
+
+	for i := range 3 {
+		avlts.Insert(tree, i, "")
+	}
+	assert.Equal(t, 8*node, avlts.SizeOf(tree))
+
+	for i := 3; i < 10; i++ {
+		avlts.Insert(tree, i, "")
+	}
+	assert.Equal(t, 10*node, avlts.SizeOf(tree))
+
+	weighted := avlts.NewWithCapacity[int, string](8, avlts.WithWeight(func(k int, v string) int64 { return 1 }))
+	assert.Greater(t, avlts.SizeOf(weighted), 8*node)
+}
+
+func TestSizeOfAugmented(t *testing.T) {
+	keys := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	plain := treeOf[int, int](keys, nil)
+	weighted := treeOf[int, int](keys, nil, avlts.WithWeight(func(k, v int) int64 { return 1 }))
+	// Each node carries a weight, two value-extreme pointers, a hash, and a sum.
+	ext := unsafe.Sizeof(int64(0)) + 2*unsafe.Sizeof(uintptr(0)) + unsafe.Sizeof(uint64(0)) + unsafe.Sizeof(int(0))
+	assert.Equal(t, avlts.SizeOf(plain)+10*ext, avlts.SizeOf(weighted))
+}
+
+func TestSizeOfFunc(t *testing.T) {
+	tree := avlts.New[int, string]()
+	avlts.Insert(tree, 1, "abc")
+	avlts.Insert(tree, 2, "de")
+
+	size := avlts.SizeOfFunc(tree, func(k int, v string) uintptr { return uintptr(len(v)) })
+	assert.Equal(t, avlts.SizeOf(tree)+5, size)
+}
+
+func ExampleSizeOf() {
+	tree := avlts.New[int, struct{}]()
+	avlts.Insert(tree, 1, struct{}{})
+	fmt.Println(avlts.SizeOf(tree) == unsafe.Sizeof(avlts.Node[int, struct{}]{}))
+	// Output: true
+}
+
+func ExampleStats() {
+	tree := avlts.New[int, string]()
+	for i := range 7 {
+		avlts.Insert(tree, i, "")
+	}
+	s := avlts.Stats(tree)
+	fmt.Println(s.Count, s.Height, s.Leaves)
+	// Output: 7 3 4
+}
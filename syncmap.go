@@ -0,0 +1,141 @@
+package avltrees
+
+import (
+	"cmp"
+	"sync"
+)
+
+// SyncMap is an ordered map with the method set of sync.Map, backed by an
+// AVL tree guarded by a read-write mutex. It is safe for concurrent use.
+// Unlike sync.Map, Range visits keys in ascending order.
+type SyncMap[K cmp.Ordered, V any] struct {
+	mu   sync.RWMutex
+	tree Tree[K, V]
+}
+
+// Load returns the value stored under key, if any.
+func (m *SyncMap[K, V]) Load(key K) (value V, ok bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if n, found := Search(&m.tree, key); found {
+		return n.value, true
+	}
+	return value, false
+}
+
+// Store sets the value for key.
+func (m *SyncMap[K, V]) Store(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	Insert(&m.tree, key, value)
+}
+
+// LoadOrStore returns the existing value for key if present. Otherwise it
+// stores and returns the given value. loaded is true if the value was loaded.
+func (m *SyncMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := Entry(&m.tree, key)
+	if n, found := e.Node(); found {
+		return n.value, true
+	}
+	e.OrInsert(value)
+	return value, false
+}
+
+// LoadAndDelete deletes the value for key, returning the previous value if any.
+func (m *SyncMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n, found := Search(&m.tree, key)
+	if !found {
+		return value, false
+	}
+	value = n.value
+	Delete(&m.tree, key)
+	return value, true
+}
+
+// Delete deletes the value for key.
+func (m *SyncMap[K, V]) Delete(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	Delete(&m.tree, key)
+}
+
+// Swap swaps the value for key and returns the previous value if any.
+// loaded reports whether the key was present.
+func (m *SyncMap[K, V]) Swap(key K, value V) (previous V, loaded bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := Entry(&m.tree, key)
+	if n, found := e.Node(); found {
+		previous = n.value
+		SetValue(&m.tree, n, value)
+		return previous, true
+	}
+	e.OrInsert(value)
+	return previous, false
+}
+
+// CompareAndSwap swaps the old and new values for key if the value stored
+// in the map is equal to old. As with sync.Map, the old value must be of a
+// comparable type.
+func (m *SyncMap[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n, found := Search(&m.tree, key)
+	if !found || any(n.value) != any(old) {
+		return false
+	}
+	SetValue(&m.tree, n, new)
+	return true
+}
+
+// CompareAndDelete deletes the entry for key if its value is equal to old.
+// The old value must be of a comparable type. If there is no current value
+// for key in the map, CompareAndDelete returns false.
+func (m *SyncMap[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n, found := Search(&m.tree, key)
+	if !found || any(n.value) != any(old) {
+		return false
+	}
+	return Delete(&m.tree, key)
+}
+
+// Clear deletes all the entries.
+func (m *SyncMap[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	Clear(&m.tree)
+}
+
+// Range calls f for each key and value in ascending key order until f returns false.
+// As with sync.Map, Range does not hold the lock while calling f, so f may call
+// other methods on the map, and the iteration does not correspond to a consistent
+// snapshot: each step resumes at the next key greater than the last one visited.
+func (m *SyncMap[K, V]) Range(f func(key K, value V) bool) {
+	key, value, ok := m.next(nil)
+	for ok && f(key, value) {
+		key, value, ok = m.next(&key)
+	}
+}
+
+// next returns the entry with the smallest key greater than *after,
+// or the smallest entry if after is nil.
+func (m *SyncMap[K, V]) next(after *K) (key K, value V, ok bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var n *Node[K, V]
+	if after == nil {
+		n, ok = Min(&m.tree)
+	} else {
+		n, ok = Higher(&m.tree, *after)
+	}
+	if ok {
+		key, value = n.key, n.value
+	}
+	return key, value, ok
+}
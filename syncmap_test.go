@@ -0,0 +1,181 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncMapLoadStore(t *testing.T) {
+	var m avlts.SyncMap[string, int]
+	_, ok := m.Load("a")
+	assert.False(t, ok)
+
+	m.Store("a", 1)
+	m.Store("a", 2)
+	v, ok := m.Load("a")
+	require.True(t, ok)
+	assert.Equal(t, 2, v)
+}
+
+func TestSyncMapLoadOrStore(t *testing.T) {
+	var m avlts.SyncMap[string, int]
+	actual, loaded := m.LoadOrStore("a", 1)
+	assert.False(t, loaded)
+	assert.Equal(t, 1, actual)
+
+	actual, loaded = m.LoadOrStore("a", 2)
+	assert.True(t, loaded)
+	assert.Equal(t, 1, actual)
+}
+
+func TestSyncMapLoadAndDelete(t *testing.T) {
+	var m avlts.SyncMap[string, int]
+	m.Store("a", 1)
+
+	v, loaded := m.LoadAndDelete("a")
+	assert.True(t, loaded)
+	assert.Equal(t, 1, v)
+
+	_, loaded = m.LoadAndDelete("a")
+	assert.False(t, loaded)
+}
+
+func TestSyncMapDelete(t *testing.T) {
+	var m avlts.SyncMap[string, int]
+	m.Store("a", 1)
+	m.Delete("a")
+	m.Delete("missing")
+	_, ok := m.Load("a")
+	assert.False(t, ok)
+}
+
+func TestSyncMapSwap(t *testing.T) {
+	var m avlts.SyncMap[string, int]
+	prev, loaded := m.Swap("a", 1)
+	assert.False(t, loaded)
+	assert.Zero(t, prev)
+
+	prev, loaded = m.Swap("a", 2)
+	assert.True(t, loaded)
+	assert.Equal(t, 1, prev)
+	v, _ := m.Load("a")
+	assert.Equal(t, 2, v)
+}
+
+func TestSyncMapCompareAndSwap(t *testing.T) {
+	var m avlts.SyncMap[string, int]
+	assert.False(t, m.CompareAndSwap("a", 0, 1), "missing keys are not swapped")
+	_, ok := m.Load("a")
+	assert.False(t, ok)
+
+	m.Store("a", 1)
+	assert.False(t, m.CompareAndSwap("a", 2, 3))
+	assert.True(t, m.CompareAndSwap("a", 1, 3))
+	v, _ := m.Load("a")
+	assert.Equal(t, 3, v)
+
+	var anys avlts.SyncMap[string, any]
+	anys.Store("s", []int{1})
+	assert.Panics(t, func() { anys.CompareAndSwap("s", []int{1}, nil) }, "old must be comparable")
+}
+
+func TestSyncMapCompareAndDelete(t *testing.T) {
+	var m avlts.SyncMap[string, int]
+	assert.False(t, m.CompareAndDelete("a", 0), "missing keys are not deleted")
+
+	m.Store("a", 1)
+	assert.False(t, m.CompareAndDelete("a", 2))
+	assert.True(t, m.CompareAndDelete("a", 1))
+	_, ok := m.Load("a")
+	assert.False(t, ok)
+}
+
+func TestSyncMapClear(t *testing.T) {
+	var m avlts.SyncMap[int, int]
+	for i := range 10 {
+		m.Store(i, i)
+	}
+	m.Clear()
+	m.Range(func(int, int) bool {
+		t.Fatal("Range visited an entry after Clear")
+		return false
+	})
+	m.Store(1, 1)
+	v, ok := m.Load(1)
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+}
+
+func TestSyncMapRange(t *testing.T) {
+	var m avlts.SyncMap[int, string]
+	for _, k := range []int{3, 1, 2, 5, 4} {
+		m.Store(k, fmt.Sprint(k))
+	}
+
+	var keys []int
+	m.Range(func(k int, v string) bool {
+		assert.Equal(t, fmt.Sprint(k), v)
+		keys = append(keys, k)
+		return true
+	})
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, keys)
+
+	keys = nil
+	m.Range(func(k int, v string) bool {
+		keys = append(keys, k)
+		m.Delete(k + 1)
+		return k < 4
+	})
+	assert.Equal(t, []int{1, 3, 5}, keys, "Range may call other methods")
+
+	var empty avlts.SyncMap[int, string]
+	empty.Range(func(int, string) bool {
+		t.Fatal("unexpected entry")
+		return true
+	})
+}
+
+func TestSyncMapConcurrent(t *testing.T) {
+	var m avlts.SyncMap[int, int]
+	var wg sync.WaitGroup
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 200 {
+				m.Store(w*1000+i, i)
+				m.LoadOrStore(-i-1, w)
+				m.Range(func(k, v int) bool { return k < 10 })
+				if i%2 == 0 {
+					m.LoadAndDelete(w*1000 + i)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	count := 0
+	m.Range(func(int, int) bool { count++; return true })
+	assert.Equal(t, 4*100+200, count)
+}
+
+func ExampleSyncMap() {
+	var m avlts.SyncMap[string, int]
+	m.Store("b", 2)
+	m.Store("a", 1)
+	actual, loaded := m.LoadOrStore("a", 10)
+	fmt.Println(actual, loaded)
+	m.Range(func(k string, v int) bool {
+		fmt.Println(k, v)
+		return true
+	})
+	// Output:
+	// 1 true
+	// a 1
+	// b 2
+}
@@ -0,0 +1,100 @@
+package avltrees
+
+import "cmp"
+
+// Filter returns a new AVL tree holding the entries of t for which pred
+// returns true. The result is built in O(n) from the matching entries in
+// key order rather than by repeated insertion. It keeps the
+// order-statistics setting and aggregates of t but has no capacity bound,
+// hooks or metrics.
+func Filter[K cmp.Ordered, V any](t *Tree[K, V], pred func(key K, value V) bool) *Tree[K, V] {
+	out := newTreeLike(t)
+	var nodes []*Node[K, V]
+	for n := range InOrder(t) {
+		if pred(n.key, n.value) {
+			nodes = append(nodes, copyNode(out, &n))
+		}
+	}
+	out.Root = buildFromNodes(out, nodes, nil)
+	out.count = len(nodes)
+	return out
+}
+
+// Partition returns two new AVL trees holding the entries of t for which
+// pred returns true and false, respectively. Both are built in O(n) in a
+// single pass over t, which is left unchanged. Like Filter, they keep the
+// order-statistics setting and aggregates of t but have no capacity bound,
+// hooks or metrics.
+func Partition[K cmp.Ordered, V any](t *Tree[K, V], pred func(key K, value V) bool) (matched, rest *Tree[K, V]) {
+	matched, rest = newTreeLike(t), newTreeLike(t)
+	var in, out []*Node[K, V]
+	for n := range InOrder(t) {
+		if pred(n.key, n.value) {
+			in = append(in, copyNode(matched, &n))
+		} else {
+			out = append(out, copyNode(rest, &n))
+		}
+	}
+	matched.Root = buildFromNodes(matched, in, nil)
+	matched.count = len(in)
+	rest.Root = buildFromNodes(rest, out, nil)
+	rest.count = len(out)
+	return matched, rest
+}
+
+// MapValues returns a new AVL tree with the keys of t and values produced by f.
+// The new tree mirrors the shape of t node for node, so it is built in O(n)
+// without any rebalancing. It keeps the order-statistics setting of t but
+// has no capacity bound or hooks.
+func MapValues[K cmp.Ordered, V, V2 any](t *Tree[K, V], f func(key K, value V) V2) *Tree[K, V2] {
+	return &Tree[K, V2]{
+		Root:         mapNode(t.Root, nil, f),
+		count:        Len(t),
+		noOrderStats: t.noOrderStats,
+	}
+}
+
+// CloneWith returns a copy of the AVL tree whose values are produced by
+// cloneV, so trees holding pointers, slices, or maps can be deep-copied
+// for snapshot isolation. If cloneV is nil, values are copied by
+// assignment. The copy has the same shape, options, and capacity bound as
+// t, but no hooks, and it is not frozen.
+func CloneWith[K cmp.Ordered, V any](t *Tree[K, V], cloneV func(V) V) *Tree[K, V] {
+	if cloneV == nil {
+		cloneV = func(v V) V { return v }
+	}
+	out := &Tree[K, V]{
+		count:        t.count,
+		capacity:     t.capacity,
+		evict:        t.evict,
+		noOrderStats: t.noOrderStats,
+		aug:          t.aug,
+	}
+	out.Root = cloneNode(out, t.Root, nil, cloneV)
+	return out
+}
+
+func cloneNode[K cmp.Ordered, V any](t *Tree[K, V], n, parent *Node[K, V], cloneV func(V) V) *Node[K, V] {
+	if n == nil {
+		return nil
+	}
+	m := allocNode(t)
+	m.key, m.value, m.balance, m.size, m.parent = n.key, cloneV(n.value), n.balance, n.size, parent
+	m.left = cloneNode(t, n.left, m, cloneV)
+	m.right = cloneNode(t, n.right, m, cloneV)
+	if t.aug != nil {
+		t.aug.update(m)
+	}
+	return m
+}
+
+func mapNode[K cmp.Ordered, V, V2 any](n *Node[K, V], parent *Node[K, V2], f func(K, V) V2) *Node[K, V2] {
+	if n == nil {
+		return nil
+	}
+	m := &Node[K, V2]{key: n.key, balance: n.balance, size: n.size, parent: parent}
+	m.left = mapNode(n.left, m, f)
+	m.value = f(n.key, n.value)
+	m.right = mapNode(n.right, m, f)
+	return m
+}
@@ -0,0 +1,211 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	tree := avlts.New[int, string]()
+	for i := range 100 {
+		avlts.Insert(tree, i, fmt.Sprint(i))
+	}
+	even := avlts.Filter(tree, func(k int, _ string) bool { return k%2 == 0 })
+	assert.Equal(t, 50, avlts.Len(even))
+	assert.Equal(t, 100, avlts.Len(tree), "source tree is unchanged")
+	assertBalanced(t, even)
+	n, _ := avlts.Kth(even, 10)
+	assert.Equal(t, 20, n.Key())
+	assert.Equal(t, "20", n.Value())
+
+	avlts.Insert(even, 1, "1")
+	_, ok := avlts.Search(tree, 1)
+	assert.True(t, ok)
+	assert.Equal(t, 100, avlts.Len(tree))
+
+	none := avlts.Filter(tree, func(int, string) bool { return false })
+	assert.Equal(t, 0, avlts.Len(none))
+	assert.Nil(t, none.Root)
+}
+
+func TestFilterWithoutOrderStatistics(t *testing.T) {
+	tree := avlts.New[int, int](avlts.WithoutOrderStatistics())
+	for i := range 10 {
+		avlts.Insert(tree, i, i)
+	}
+	small := avlts.Filter(tree, func(k, _ int) bool { return k < 3 })
+	assert.Equal(t, 3, avlts.Len(small))
+	assert.Equal(t, -1, avlts.Rank(small, 1))
+}
+
+func TestFilterKeepsAggregates(t *testing.T) {
+	tree := avlts.New[int, int](avlts.WithPrefixSums[int]())
+	for i := range 10 {
+		avlts.Insert(tree, i, i)
+	}
+	small := avlts.Filter(tree, func(k, _ int) bool { return k < 5 })
+	require.NoError(t, avlts.Validate(small))
+	sum, ok := avlts.PrefixSum(small, 100)
+	assert.True(t, ok)
+	assert.Equal(t, 10, sum)
+}
+
+func TestPartition(t *testing.T) {
+	tree := avlts.New[int, string]()
+	for i := range 100 {
+		avlts.Insert(tree, i, fmt.Sprint(i))
+	}
+	small, large := avlts.Partition(tree, func(k int, _ string) bool { return k < 30 })
+	assert.Equal(t, 30, avlts.Len(small))
+	assert.Equal(t, 70, avlts.Len(large))
+	assert.Equal(t, 100, avlts.Len(tree), "source tree is unchanged")
+	assertBalanced(t, small)
+	assertBalanced(t, large)
+	n, _ := avlts.Min(large)
+	assert.Equal(t, 30, n.Key())
+	assert.Equal(t, "30", n.Value())
+
+	all, none := avlts.Partition(tree, func(int, string) bool { return true })
+	assert.Equal(t, 100, avlts.Len(all))
+	assert.Nil(t, none.Root)
+	assert.Equal(t, 0, avlts.Len(none))
+}
+
+func TestPartitionKeepsAggregates(t *testing.T) {
+	tree := avlts.New[int, int](avlts.WithPrefixSums[int]())
+	for i := range 10 {
+		avlts.Insert(tree, i, i)
+	}
+	small, large := avlts.Partition(tree, func(k, _ int) bool { return k < 5 })
+	for tree, want := range map[*avlts.Tree[int, int]]int{small: 10, large: 35} {
+		require.NoError(t, avlts.Validate(tree))
+		sum, ok := avlts.PrefixSum(tree, 100)
+		assert.True(t, ok)
+		assert.Equal(t, want, sum)
+	}
+}
+
+func TestCloneWith(t *testing.T) {
+	tree := avlts.New[int, []int](avlts.WithWeight(func(_ int, v []int) int64 { return int64(len(v)) }))
+	for i := range 20 {
+		avlts.Insert(tree, i, []int{i})
+	}
+	avlts.Freeze(tree)
+
+	clone := avlts.CloneWith(tree, slices.Clone[[]int])
+	require.NoError(t, avlts.Validate(clone))
+	assert.Equal(t, treeKeys(tree), treeKeys(clone))
+	assert.False(t, avlts.Frozen(clone))
+	assert.Equal(t, int64(20), avlts.TotalWeight(clone), "options carry over")
+
+	n, _ := avlts.Search(clone, 3)
+	n.Value()[0] = 99
+	orig, _ := avlts.Get(tree, 3)
+	assert.Equal(t, []int{3}, orig, "values are deep-copied")
+
+	avlts.Insert(clone, 100, []int{1, 2})
+	assert.Equal(t, 20, avlts.Len(tree))
+	assert.Equal(t, 21, avlts.Len(clone))
+	assert.Equal(t, int64(22), avlts.TotalWeight(clone))
+}
+
+func TestCloneWithNil(t *testing.T) {
+	tree := avlts.NewBounded[int, string](3, avlts.EvictMin)
+	for i := range 3 {
+		avlts.Insert(tree, i, fmt.Sprint(i))
+	}
+	var deletes int
+	avlts.OnDelete(tree, func(int, string) { deletes++ })
+
+	clone := avlts.CloneWith(tree, nil)
+	avlts.Insert(clone, 3, "3")
+	assert.Equal(t, []int{1, 2, 3}, treeKeys(clone), "capacity bound carries over")
+	assert.Equal(t, []int{0, 1, 2}, treeKeys(tree))
+	assert.Zero(t, deletes, "hooks are not copied")
+}
+
+func TestMapValues(t *testing.T) {
+	tree := avlts.New[int, int]()
+	for i := range 50 {
+		avlts.Insert(tree, i, i)
+	}
+	var visited []int
+	squares := avlts.MapValues(tree, func(k, v int) string {
+		visited = append(visited, k)
+		return fmt.Sprint(v * v)
+	})
+	assert.Equal(t, treeKeys(tree), visited, "f is applied in key order")
+	assert.Equal(t, avlts.Len(tree), avlts.Len(squares))
+	assert.Equal(t, avlts.Sprint(tree), avlts.Sprint(squares), "shape is preserved")
+	assertBalanced(t, squares)
+
+	n, ok := avlts.Search(squares, 7)
+	assert.True(t, ok)
+	assert.Equal(t, "49", n.Value())
+	n, _ = avlts.Kth(squares, 12)
+	assert.Equal(t, "144", n.Value())
+
+	avlts.Delete(squares, 7)
+	assert.Equal(t, 49, avlts.Len(squares))
+	assert.Equal(t, 50, avlts.Len(tree))
+}
+
+func ExampleFilter() {
+	stock := avlts.New[string, int]()
+	avlts.Insert(stock, "apple", 0)
+	avlts.Insert(stock, "banana", 12)
+	avlts.Insert(stock, "cherry", 5)
+
+	inStock := avlts.Filter(stock, func(_ string, qty int) bool { return qty > 0 })
+	for n := range avlts.InOrder(inStock) {
+		fmt.Println(n.Key(), n.Value())
+	}
+	// Output:
+	// banana 12
+	// cherry 5
+}
+
+func ExamplePartition() {
+	sessions := avlts.New[string, int]()
+	avlts.Insert(sessions, "alice", 120)
+	avlts.Insert(sessions, "bob", 5)
+	avlts.Insert(sessions, "carol", 300)
+
+	expired, live := avlts.Partition(sessions, func(_ string, ttl int) bool { return ttl < 60 })
+	fmt.Println(avlts.AppendKeys(expired, nil), avlts.AppendKeys(live, nil))
+	// Output: [bob] [alice carol]
+}
+
+func ExampleCloneWith() {
+	tree := avlts.New[string, []string]()
+	avlts.Insert(tree, "admins", []string{"alice"})
+
+	snapshot := avlts.CloneWith(tree, slices.Clone[[]string])
+	n, _ := avlts.Search(tree, "admins")
+	n.Value()[0] = "mallory"
+
+	v, _ := avlts.Get(snapshot, "admins")
+	fmt.Println(v)
+	// Output: [alice]
+}
+
+func ExampleMapValues() {
+	prices := avlts.New[string, float64]()
+	avlts.Insert(prices, "apple", 1.25)
+	avlts.Insert(prices, "pear", 2.5)
+
+	labels := avlts.MapValues(prices, func(name string, p float64) string {
+		return fmt.Sprintf("%s: $%.2f", name, p)
+	})
+	for n := range avlts.InOrder(labels) {
+		fmt.Println(n.Value())
+	}
+	// Output:
+	// apple: $1.25
+	// pear: $2.50
+}
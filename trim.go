@@ -0,0 +1,131 @@
+package avltrees
+
+import "cmp"
+
+// DeleteBefore removes all keys less than key from the AVL tree and
+// returns the number of keys removed. The tree is split at key in
+// O(log n) and the lower part is discarded, so the cost does not grow with
+// the number of removed keys unless delete hooks are registered or the
+// tree was created WithoutOrderStatistics.
+func DeleteBefore[K cmp.Ordered, V any](t *Tree[K, V], key K) int {
+	checkMutable(t)
+	lower, _, upper, _ := split(t, t.Root, height(t.Root), key)
+	t.Root = upper
+	return discard(t, lower)
+}
+
+// DeleteAfter removes all keys greater than key from the AVL tree and
+// returns the number of keys removed. Like DeleteBefore, it splits the
+// tree instead of deleting keys one at a time.
+func DeleteAfter[K cmp.Ordered, V any](t *Tree[K, V], key K) int {
+	checkMutable(t)
+	var upper *Node[K, V]
+	if n, ok := Higher(t, key); ok {
+		t.Root, _, upper, _ = split(t, t.Root, height(t.Root), n.key)
+	}
+	return discard(t, upper)
+}
+
+// discard accounts for the removal of the detached subtree rooted at n.
+func discard[K cmp.Ordered, V any](t *Tree[K, V], n *Node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	var removed int
+	if t.noOrderStats || t.hooks != nil {
+		nodes := appendNodes(nil, n)
+		removed = len(nodes)
+		t.count -= removed
+		recordDeletes(t, removed)
+		for _, n := range nodes {
+			notifyDelete(t, n.key, n.value)
+		}
+		return removed
+	}
+	removed = size(n)
+	t.count -= removed
+	recordDeletes(t, removed)
+	t.version++
+	return removed
+}
+
+// split divides the subtree rooted at n, of height h, into a subtree with
+// the keys less than key and a subtree with the remaining keys. Both
+// results are detached, balanced roots and are returned with their heights.
+func split[K cmp.Ordered, V any](t *Tree[K, V], n *Node[K, V], h int, key K) (*Node[K, V], int, *Node[K, V], int) {
+	if n == nil {
+		return nil, 0, nil, 0
+	}
+	hl, hr := childHeights(n, h)
+	l, r := detachChildren(n)
+	if key <= n.key {
+		ll, hll, lr, hlr := split(t, l, hl, key)
+		r, hr = join(t, lr, hlr, n, r, hr)
+		return ll, hll, r, hr
+	}
+	rl, hrl, rr, hrr := split(t, r, hr, key)
+	l, hl = join(t, l, hl, n, rl, hrl)
+	return l, hl, rr, hrr
+}
+
+// join links l, mid, and r, of heights hl and hr, into one balanced
+// subtree and returns its detached root and height. Every key in l must be
+// less than mid.key, and every key in r greater. mid must not be linked to
+// any other node. Knowing the heights lets join descend only as far as the
+// height difference, so it costs O(|hl - hr| + 1).
+func join[K cmp.Ordered, V any](t *Tree[K, V], l *Node[K, V], hl int, mid *Node[K, V], r *Node[K, V], hr int) (*Node[K, V], int) {
+	var root *Node[K, V]
+	var h int
+	switch {
+	case hl > hr+1:
+		hll, hlr := childHeights(l, hl)
+		sub, hs := join(t, l.right, hlr, mid, r, hr)
+		l.right, sub.parent = sub, l
+		l.balance = int8(hll - hs)
+		updateSize(t, l)
+		root, h = joined(t, l, hll, hs)
+	case hr > hl+1:
+		hrl, hrr := childHeights(r, hr)
+		sub, hs := join(t, l, hl, mid, r.left, hrl)
+		r.left, sub.parent = sub, r
+		r.balance = int8(hs - hrr)
+		updateSize(t, r)
+		root, h = joined(t, r, hs, hrr)
+	default:
+		mid.left, mid.right = l, r
+		if l != nil {
+			l.parent = mid
+		}
+		if r != nil {
+			r.parent = mid
+		}
+		mid.balance = int8(hl - hr)
+		updateSize(t, mid)
+		root, h = mid, max(hl, hr)+1
+	}
+	root.parent = nil
+	return root, h
+}
+
+// joined rebalances n, whose subtrees have heights hl and hr, after join
+// relinked one of them, and returns the new subtree root and its height.
+func joined[K cmp.Ordered, V any](t *Tree[K, V], n *Node[K, V], hl, hr int) (*Node[K, V], int) {
+	h := max(hl, hr) + 1
+	n, shrunk := rebalance(t, n)
+	if shrunk {
+		h--
+	}
+	return n, h
+}
+
+func detachChildren[K cmp.Ordered, V any](n *Node[K, V]) (*Node[K, V], *Node[K, V]) {
+	l, r := n.left, n.right
+	if l != nil {
+		l.parent = nil
+	}
+	if r != nil {
+		r.parent = nil
+	}
+	n.left, n.right, n.parent = nil, nil, nil
+	return l, r
+}
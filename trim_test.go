@@ -0,0 +1,104 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteBefore(t *testing.T) {
+	tree := treeOf[int, struct{}]([]int{10, 20, 30, 40, 50, 60, 70}, nil)
+	assert.Equal(t, 3, avlts.DeleteBefore(tree, 35))
+	assert.Equal(t, []int{40, 50, 60, 70}, treeKeys(tree))
+	assertBalanced(t, tree)
+
+	assert.Equal(t, 1, avlts.DeleteBefore(tree, 50))
+	assert.Equal(t, 0, avlts.DeleteBefore(tree, 50))
+	assert.Equal(t, 3, avlts.DeleteBefore(tree, 100))
+	assert.Equal(t, 0, avlts.Len(tree))
+	assert.Nil(t, tree.Root)
+}
+
+func TestDeleteAfter(t *testing.T) {
+	tree := treeOf[int, struct{}]([]int{10, 20, 30, 40, 50, 60, 70}, nil)
+	assert.Equal(t, 4, avlts.DeleteAfter(tree, 30))
+	assert.Equal(t, []int{10, 20, 30}, treeKeys(tree))
+	assertBalanced(t, tree)
+
+	assert.Equal(t, 0, avlts.DeleteAfter(tree, 30))
+	assert.Equal(t, 3, avlts.DeleteAfter(tree, 0))
+	assert.Equal(t, 0, avlts.Len(tree))
+}
+
+func TestDeleteBeforeRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	for range 50 {
+		tree := avlts.New[int, struct{}]()
+		var keys []int
+		for range r.Intn(300) {
+			k := r.Intn(1000)
+			if avlts.Insert(tree, k, struct{}{}) {
+				keys = append(keys, k)
+			}
+		}
+		slices.Sort(keys)
+		lo, hi := r.Intn(1000), r.Intn(1000)
+		i, _ := slices.BinarySearch(keys, lo)
+		require.Equal(t, i, avlts.DeleteBefore(tree, lo))
+		keys = keys[i:]
+		j, found := slices.BinarySearch(keys, hi)
+		if found {
+			j++
+		}
+		require.Equal(t, len(keys)-j, avlts.DeleteAfter(tree, hi))
+		keys = keys[:j]
+
+		require.True(t, slices.Equal(keys, treeKeys(tree)))
+		assertBalanced(t, tree)
+		for rank, k := range keys {
+			require.Equal(t, rank, avlts.Rank(tree, k))
+		}
+	}
+}
+
+func TestDeleteBeforeHooks(t *testing.T) {
+	tree := treeOf[int, struct{}]([]int{1, 2, 3, 4}, nil)
+	var deleted []int
+	avlts.OnDelete(tree, func(k int, _ struct{}) { deleted = append(deleted, k) })
+	avlts.DeleteBefore(tree, 3)
+	avlts.DeleteAfter(tree, 3)
+	assert.Equal(t, []int{1, 2, 4}, deleted)
+}
+
+func TestDeleteBeforeWithoutOrderStatistics(t *testing.T) {
+	tree := avlts.New[int, int](avlts.WithoutOrderStatistics())
+	for i := range 10 {
+		avlts.Insert(tree, i, i)
+	}
+	assert.Equal(t, 4, avlts.DeleteBefore(tree, 4))
+	assert.Equal(t, 3, avlts.DeleteAfter(tree, 6))
+	assert.Equal(t, 3, avlts.Len(tree))
+}
+
+func ExampleDeleteBefore() {
+	events := avlts.New[int, string]() // unix time -> event
+	for ts := 100; ts <= 500; ts += 100 {
+		avlts.Insert(events, ts, fmt.Sprint("event@", ts))
+	}
+	removed := avlts.DeleteBefore(events, 300)
+	fmt.Println(removed, avlts.Len(events))
+	// Output:
+	// 2 3
+}
+
+func ExampleDeleteAfter() {
+	tree := treeOf[int, struct{}]([]int{1, 2, 3, 4, 5}, nil)
+	fmt.Println(avlts.DeleteAfter(tree, 2))
+	// Output:
+	// 3
+}
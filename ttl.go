@@ -0,0 +1,153 @@
+package avltrees
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+)
+
+type ttlEntry[V any] struct {
+	value    V
+	deadline int64
+}
+
+// TTLTree is an ordered map whose entries expire at a deadline.
+// A second tree keyed by deadline lets ExpireBefore remove k expired
+// entries in O(k log n). TTLTree is safe for concurrent use.
+type TTLTree[K cmp.Ordered, V any] struct {
+	mu      sync.Mutex
+	entries Tree[K, ttlEntry[V]]
+	expiry  Tree[int64, []K]
+}
+
+// NewTTLTree returns a new empty TTLTree.
+func NewTTLTree[K cmp.Ordered, V any]() *TTLTree[K, V] {
+	return &TTLTree[K, V]{}
+}
+
+// Set inserts or replaces the entry for key, expiring at deadline.
+// Returns true if the key was inserted, or false if it replaced an existing key.
+func (t *TTLTree[K, V]) Set(key K, value V, deadline time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	inserted := !t.unlinkExpiry(key)
+	d := deadline.UnixNano()
+	Insert(&t.entries, key, ttlEntry[V]{value, d})
+	if n, ok := Search(&t.expiry, d); ok {
+		n.value = append(n.value, key)
+	} else {
+		Insert(&t.expiry, d, []K{key})
+	}
+	return inserted
+}
+
+// Get returns the value for key if it exists and has not expired.
+func (t *TTLTree[K, V]) Get(key K) (V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n, ok := Search(&t.entries, key)
+	if !ok || n.value.deadline <= time.Now().UnixNano() {
+		var zero V
+		return zero, false
+	}
+	return n.value.value, true
+}
+
+// Deadline returns the expiry deadline of key, if present.
+func (t *TTLTree[K, V]) Deadline(key K) (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n, ok := Search(&t.entries, key)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(0, n.value.deadline), true
+}
+
+// Delete removes key. Returns true if the key existed.
+func (t *TTLTree[K, V]) Delete(key K) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.unlinkExpiry(key) {
+		return false
+	}
+	return Delete(&t.entries, key)
+}
+
+// Len returns the number of entries, including expired ones not yet removed.
+func (t *TTLTree[K, V]) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return Len(&t.entries)
+}
+
+// Range calls visit for unexpired entries with keys in [from, to) in ascending
+// order until visit returns false. The tree is locked during the call, so visit
+// must not call other TTLTree methods.
+func (t *TTLTree[K, V]) Range(from, to K, visit func(key K, value V) bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now().UnixNano()
+	for n := range Range(&t.entries, from, to) {
+		if n.value.deadline <= now {
+			continue
+		}
+		if !visit(n.key, n.value.value) {
+			return
+		}
+	}
+}
+
+// ExpireBefore removes all entries whose deadline is not after now and
+// returns the number of entries removed.
+func (t *TTLTree[K, V]) ExpireBefore(now time.Time) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	cutoff := now.UnixNano()
+	removed := 0
+	for {
+		n, ok := Min(&t.expiry)
+		if !ok || n.key > cutoff {
+			return removed
+		}
+		for _, key := range n.value {
+			Delete(&t.entries, key)
+		}
+		removed += len(n.value)
+		Delete(&t.expiry, n.key)
+	}
+}
+
+// StartExpiry starts a background goroutine that calls ExpireBefore with the
+// current time every interval. Calling the returned function stops it.
+func (t *TTLTree[K, V]) StartExpiry(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				t.ExpireBefore(now)
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+func (t *TTLTree[K, V]) unlinkExpiry(key K) bool {
+	n, ok := Search(&t.entries, key)
+	if !ok {
+		return false
+	}
+	bucket, _ := Search(&t.expiry, n.value.deadline)
+	bucket.value = slices.DeleteFunc(bucket.value, func(k K) bool { return k == key })
+	if len(bucket.value) == 0 {
+		Delete(&t.expiry, n.value.deadline)
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTTLTreeSetGet(t *testing.T) {
+	tt := avlts.NewTTLTree[string, int]()
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	assert.True(t, tt.Set("a", 1, future))
+	assert.False(t, tt.Set("a", 2, future))
+	assert.True(t, tt.Set("b", 3, past))
+
+	v, ok := tt.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, 2, v)
+
+	_, ok = tt.Get("b")
+	assert.False(t, ok, "expired entry should not be visible")
+	assert.Equal(t, 2, tt.Len())
+
+	d, ok := tt.Deadline("a")
+	require.True(t, ok)
+	assert.Equal(t, future.UnixNano(), d.UnixNano())
+	_, ok = tt.Deadline("c")
+	assert.False(t, ok)
+}
+
+func TestTTLTreeDelete(t *testing.T) {
+	tt := avlts.NewTTLTree[string, int]()
+	deadline := time.Now().Add(time.Hour)
+	tt.Set("a", 1, deadline)
+	tt.Set("b", 2, deadline)
+
+	assert.True(t, tt.Delete("a"))
+	assert.False(t, tt.Delete("a"))
+	assert.Equal(t, 1, tt.Len())
+	assert.Equal(t, 0, tt.ExpireBefore(deadline.Add(-time.Second)))
+	assert.Equal(t, 1, tt.ExpireBefore(deadline))
+	assert.Equal(t, 0, tt.Len())
+}
+
+func TestTTLTreeExpireBefore(t *testing.T) {
+	tt := avlts.NewTTLTree[int, string]()
+	base := time.Unix(1000, 0)
+	for i := range 10 {
+		tt.Set(i, "", base.Add(time.Duration(i)*time.Second))
+	}
+	// Resetting a key moves its deadline.
+	tt.Set(0, "", base.Add(time.Hour))
+
+	assert.Equal(t, 4, tt.ExpireBefore(base.Add(4*time.Second)))
+	assert.Equal(t, 6, tt.Len())
+	_, ok := tt.Deadline(0)
+	assert.True(t, ok)
+	_, ok = tt.Deadline(4)
+	assert.False(t, ok)
+	assert.Equal(t, 0, tt.ExpireBefore(base.Add(4*time.Second)))
+}
+
+func TestTTLTreeRange(t *testing.T) {
+	tt := avlts.NewTTLTree[int, string]()
+	future := time.Now().Add(time.Hour)
+	for i := range 5 {
+		tt.Set(i, fmt.Sprint(i), future)
+	}
+	tt.Set(2, "expired", time.Now().Add(-time.Hour))
+
+	var keys []int
+	tt.Range(1, 4, func(k int, v string) bool {
+		keys = append(keys, k)
+		return true
+	})
+	assert.Equal(t, []int{1, 3}, keys)
+}
+
+func TestTTLTreeStartExpiry(t *testing.T) {
+	tt := avlts.NewTTLTree[int, string]()
+	tt.Set(1, "", time.Now().Add(-time.Second))
+	stop := tt.StartExpiry(time.Millisecond)
+	defer stop()
+
+	assert.Eventually(t, func() bool { return tt.Len() == 0 }, time.Second, time.Millisecond)
+	stop()
+}
+
+func ExampleTTLTree() {
+	tt := avlts.NewTTLTree[string, string]()
+	now := time.Unix(0, 0)
+	tt.Set("session-1", "alice", now.Add(time.Minute))
+	tt.Set("session-2", "bob", now.Add(time.Hour))
+	fmt.Println(tt.ExpireBefore(now.Add(30*time.Minute)), tt.Len())
+	// Output: 1 1
+}
@@ -0,0 +1,91 @@
+package avltrees
+
+import (
+	"cmp"
+	"errors"
+)
+
+// ErrTxnDone is returned when a transaction is used after Commit or Rollback.
+var ErrTxnDone = errors.New("avltrees: transaction has already been committed or rolled back")
+
+// Txn stages inserts and deletes against a tree without modifying it.
+// Staged changes become visible in the tree only when Commit is called.
+type Txn[K cmp.Ordered, V any] struct {
+	t      *Tree[K, V]
+	staged Tree[K, txnOp[V]]
+	done   bool
+}
+
+type txnOp[V any] struct {
+	value   V
+	deleted bool
+}
+
+// Begin starts a new transaction on the AVL tree.
+func Begin[K cmp.Ordered, V any](t *Tree[K, V]) *Txn[K, V] {
+	return &Txn[K, V]{t: t}
+}
+
+// Insert stages the insertion of a key-value pair.
+func (x *Txn[K, V]) Insert(key K, value V) error {
+	if x.done {
+		return ErrTxnDone
+	}
+	Insert(&x.staged, key, txnOp[V]{value: value})
+	return nil
+}
+
+// Delete stages the removal of a key.
+func (x *Txn[K, V]) Delete(key K) error {
+	if x.done {
+		return ErrTxnDone
+	}
+	Insert(&x.staged, key, txnOp[V]{deleted: true})
+	return nil
+}
+
+// Get returns the value of key as seen by the transaction,
+// including its own staged changes.
+func (x *Txn[K, V]) Get(key K) (V, bool) {
+	if op, ok := Search(&x.staged, key); ok {
+		return op.value.value, !op.value.deleted
+	}
+	if n, ok := Search(x.t, key); ok {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Commit applies all staged changes to the tree.
+func (x *Txn[K, V]) Commit() error {
+	if x.done {
+		return ErrTxnDone
+	}
+	x.done = true
+	var inserts []Item[K, V]
+	var deletes []K
+	for n := range InOrder(&x.staged) {
+		if n.value.deleted {
+			deletes = append(deletes, n.key)
+		} else {
+			inserts = append(inserts, Item[K, V]{n.key, n.value.value})
+		}
+	}
+	for _, key := range deletes {
+		Delete(x.t, key)
+	}
+	InsertBatch(x.t, inserts)
+	Clear(&x.staged)
+	return nil
+}
+
+// Rollback discards all staged changes.
+func (x *Txn[K, V]) Rollback() error {
+	if x.done {
+		return ErrTxnDone
+	}
+	x.done = true
+	Clear(&x.staged)
+	return nil
+}
@@ -0,0 +1,94 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxnCommit(t *testing.T) {
+	tree := avlts.New[int, string]()
+	avlts.Insert(tree, 1, "one")
+	avlts.Insert(tree, 2, "two")
+
+	txn := avlts.Begin(tree)
+	require.NoError(t, txn.Insert(3, "three"))
+	require.NoError(t, txn.Delete(1))
+	require.NoError(t, txn.Insert(2, "TWO"))
+
+	_, found := avlts.Search(tree, 3)
+	assert.False(t, found, "staged insert must not be visible before commit")
+
+	v, ok := txn.Get(2)
+	require.True(t, ok)
+	assert.Equal(t, "TWO", v)
+	_, ok = txn.Get(1)
+	assert.False(t, ok)
+
+	require.NoError(t, txn.Commit())
+	assert.Equal(t, 2, avlts.Len(tree))
+	_, found = avlts.Search(tree, 1)
+	assert.False(t, found)
+	n, found := avlts.Search(tree, 2)
+	require.True(t, found)
+	assert.Equal(t, "TWO", n.Value())
+
+	assert.ErrorIs(t, txn.Commit(), avlts.ErrTxnDone)
+	assert.ErrorIs(t, txn.Insert(4, ""), avlts.ErrTxnDone)
+}
+
+func TestTxnRollback(t *testing.T) {
+	tree := avlts.New[int, string]()
+	avlts.Insert(tree, 1, "one")
+
+	txn := avlts.Begin(tree)
+	txn.Delete(1)
+	txn.Insert(2, "two")
+	require.NoError(t, txn.Rollback())
+
+	assert.Equal(t, 1, avlts.Len(tree))
+	_, found := avlts.Search(tree, 1)
+	assert.True(t, found)
+
+	assert.ErrorIs(t, txn.Rollback(), avlts.ErrTxnDone)
+	assert.ErrorIs(t, txn.Delete(1), avlts.ErrTxnDone)
+}
+
+func TestTxnGet(t *testing.T) {
+	tree := avlts.New[int, string]()
+	avlts.Insert(tree, 1, "one")
+
+	txn := avlts.Begin(tree)
+	v, ok := txn.Get(1)
+	require.True(t, ok)
+	assert.Equal(t, "one", v)
+
+	_, ok = txn.Get(2)
+	assert.False(t, ok)
+
+	txn.Delete(2)
+	txn.Insert(2, "two")
+	v, ok = txn.Get(2)
+	require.True(t, ok)
+	assert.Equal(t, "two", v)
+}
+
+func ExampleBegin() {
+	tree := avlts.New[string, int]()
+	avlts.Insert(tree, "a", 1)
+
+	txn := avlts.Begin(tree)
+	txn.Insert("b", 2)
+	txn.Delete("a")
+	fmt.Println(avlts.Len(tree))
+	txn.Commit()
+	for n := range avlts.InOrder(tree) {
+		fmt.Println(n.Key(), n.Value())
+	}
+	// Output:
+	// 1
+	// b 2
+}
@@ -0,0 +1,118 @@
+package avltrees
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Validate checks the structural invariants of the AVL tree: keys are in
+// strictly increasing order, parent links match child links, stored heights
+// and subtree sizes are correct, every balance factor is within [-1, 1],
+// the aggregates kept by options such as WithWeight, WithMultisetHash,
+// WithValueOrder, and WithPrefixSums are up to date, no weight is negative,
+// and Len agrees with the number of nodes. It returns a description of the
+// first violation found, or nil. Validate walks the whole tree and is meant
+// for tests and debugging.
+func Validate[K cmp.Ordered, V any](t *Tree[K, V]) error {
+	if t.Root != nil && t.Root.parent != nil {
+		return fmt.Errorf("avltrees: root %v has a parent", t.Root.key)
+	}
+	count, _, err := validateNode(t, t.Root, nil, nil)
+	if err != nil {
+		return err
+	}
+	if n := Len(t); n != count {
+		return fmt.Errorf("avltrees: Len is %d but the tree has %d nodes", n, count)
+	}
+	if t.count != count {
+		return fmt.Errorf("avltrees: entry count is %d but the tree has %d nodes", t.count, count)
+	}
+	return nil
+}
+
+// validateNode checks the subtree rooted at n, whose keys must lie strictly
+// between lo and hi when those are set, and returns its node count and height.
+func validateNode[K cmp.Ordered, V any](t *Tree[K, V], n *Node[K, V], lo, hi *K) (int, int, error) {
+	if n == nil {
+		return 0, 0, nil
+	}
+	if (lo != nil && n.key <= *lo) || (hi != nil && n.key >= *hi) {
+		return 0, 0, fmt.Errorf("avltrees: key %v is out of order", n.key)
+	}
+	for _, child := range []*Node[K, V]{n.left, n.right} {
+		if child != nil && child.parent != n {
+			return 0, 0, fmt.Errorf("avltrees: node %v has a wrong parent link", child.key)
+		}
+	}
+	lc, lh, err := validateNode(t, n.left, lo, &n.key)
+	if err != nil {
+		return 0, 0, err
+	}
+	rc, rh, err := validateNode(t, n.right, &n.key, hi)
+	if err != nil {
+		return 0, 0, err
+	}
+	h := max(lh, rh) + 1
+	switch {
+	case int(n.balance) != lh-rh:
+		return 0, 0, fmt.Errorf("avltrees: node %v stores balance factor %d, want %d", n.key, n.balance, lh-rh)
+	case lh-rh > 1 || rh-lh > 1:
+		return 0, 0, fmt.Errorf("avltrees: node %v has balance factor %d", n.key, lh-rh)
+	case !t.noOrderStats && int(n.size) != lc+rc+1:
+		return 0, 0, fmt.Errorf("avltrees: node %v stores size %d, want %d", n.key, n.size, lc+rc+1)
+	}
+	if t.aug != nil {
+		if err := validateAugment(t.aug, n); err != nil {
+			return 0, 0, err
+		}
+	}
+	return lc + rc + 1, h, nil
+}
+
+// validateAugment checks the aggregates stored in n against those computed
+// from its entry and its children, which have already been checked.
+func validateAugment[K cmp.Ordered, V any](a *augment[K, V], n *Node[K, V]) error {
+	e := ext(n)
+	if a.weight != nil {
+		w := a.weight(n.key, n.value)
+		if w < 0 {
+			return fmt.Errorf("avltrees: node %v has negative weight %d", n.key, w)
+		}
+		if e.weight != w+weightSum(n.left)+weightSum(n.right) {
+			return fmt.Errorf("avltrees: node %v stores a stale weight", n.key)
+		}
+	}
+	if a.hash != nil && e.hash != mix64(a.hash(n.key, n.value))+hashSum(n.left)+hashSum(n.right) {
+		return fmt.Errorf("avltrees: node %v stores a stale hash", n.key)
+	}
+	if a.valueOrder != nil {
+		lo, hi := n, n
+		for _, child := range []*Node[K, V]{n.left, n.right} {
+			if child != nil {
+				lo = a.lesser(lo, ext(child).minValue)
+				hi = a.greater(hi, ext(child).maxValue)
+			}
+		}
+		if e.minValue == nil || a.valueOrder(e.minValue.value, lo.value) != 0 {
+			return fmt.Errorf("avltrees: node %v stores a stale minimum value", n.key)
+		}
+		if e.maxValue == nil || a.valueOrder(e.maxValue.value, hi.value) != 0 {
+			return fmt.Errorf("avltrees: node %v stores a stale maximum value", n.key)
+		}
+	}
+	if a.add != nil {
+		sum := n.value
+		if n.left != nil {
+			sum = a.add(ext(n.left).sum, sum)
+		}
+		if n.right != nil {
+			sum = a.add(sum, ext(n.right).sum)
+		}
+		// Sums are only kept for Number values, which are comparable. A NaN
+		// sum never equals itself and is not checked.
+		if any(e.sum) != any(sum) && any(sum) == any(sum) {
+			return fmt.Errorf("avltrees: node %v stores a stale sum", n.key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package avltrees_test
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, avlts.Validate(avlts.New[int, int]()))
+
+	r := rand.New(rand.NewSource(11))
+	for _, opts := range [][]avlts.Option{
+		nil,
+		{avlts.WithoutOrderStatistics()},
+		{avlts.WithMultisetHash(entryHash), avlts.WithWeight(func(k, v int) int64 { return int64(v) })},
+		{avlts.WithValueOrder(cmp.Compare[int]), avlts.WithPrefixSums[int]()},
+	} {
+		tree := avlts.New[int, int](opts...)
+		for range 2000 {
+			k := r.Intn(300)
+			if r.Intn(3) == 0 {
+				avlts.Delete(tree, k)
+			} else {
+				avlts.Insert(tree, k, r.Intn(10))
+			}
+		}
+		assert.NoError(t, avlts.Validate(tree))
+	}
+}
+
+func TestValidateDetectsCorruption(t *testing.T) {
+	tree := treeOf[int, struct{}]([]int{1, 2, 3}, nil)
+	other := treeOf[int, struct{}]([]int{4, 5, 6, 7, 8}, nil)
+	tree.Root = other.Root
+	assert.Error(t, avlts.Validate(tree), "root replaced without updating the count")
+
+	tree.Root = nil
+	assert.Error(t, avlts.Validate(tree))
+}
+
+func TestValidateDetectsStaleAggregates(t *testing.T) {
+	for name, opt := range map[string]avlts.Option{
+		"weight":     avlts.WithWeight(func(k, v int) int64 { return int64(v) }),
+		"hash":       avlts.WithMultisetHash(entryHash),
+		"valueOrder": avlts.WithValueOrder(cmp.Compare[int]),
+		"sum":        avlts.WithPrefixSums[int](),
+	} {
+		tree := treeOf([]int{1, 2, 3, 4, 5}, []int{10, 20, 30, 40, 50}, opt)
+		require.NoError(t, avlts.Validate(tree), name)
+
+		n, _ := avlts.Search(tree, 4)
+		n.SetValue(99)
+		assert.ErrorContains(t, avlts.Validate(tree), "stale", name)
+	}
+}
+
+func ExampleValidate() {
+	tree := avlts.New[int, string]()
+	for i := range 100 {
+		avlts.Insert(tree, i, "")
+	}
+	fmt.Println(avlts.Validate(tree))
+	// Output:
+	// <nil>
+}
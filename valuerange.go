@@ -0,0 +1,58 @@
+package avltrees
+
+import "cmp"
+
+// WithValueOrder makes the tree track the smallest and largest value in
+// every subtree according to compare, enabling MinValueInRange and
+// MaxValueInRange. The type parameter V must match the tree's value type;
+// New panics otherwise.
+func WithValueOrder[V any](compare func(a, b V) int) Option {
+	return func(o *options) {
+		o.valueOrder = compare
+	}
+}
+
+// MinValueInRange returns the node with the smallest value among the keys in
+// the range [from, to), in O(log n). Ties go to the smallest key.
+// Returns false if the range is empty or the tree was not created
+// WithValueOrder.
+func MinValueInRange[K cmp.Ordered, V any](t *Tree[K, V], from, to K) (*Node[K, V], bool) {
+	if t.aug == nil || t.aug.valueOrder == nil {
+		return nil, false
+	}
+	n := extremeInRange(t.Root, from, to, true, true, t.aug.lesser, func(n *Node[K, V]) *Node[K, V] { return ext(n).minValue })
+	return n, n != nil
+}
+
+// MaxValueInRange returns the node with the largest value among the keys in
+// the range [from, to), in O(log n). Ties go to the smallest key.
+// Returns false if the range is empty or the tree was not created
+// WithValueOrder.
+func MaxValueInRange[K cmp.Ordered, V any](t *Tree[K, V], from, to K) (*Node[K, V], bool) {
+	if t.aug == nil || t.aug.valueOrder == nil {
+		return nil, false
+	}
+	n := extremeInRange(t.Root, from, to, true, true, t.aug.greater, func(n *Node[K, V]) *Node[K, V] { return ext(n).maxValue })
+	return n, n != nil
+}
+
+// extremeInRange combines the in-range nodes of the subtree rooted at n with
+// pick, in key order. Bounds that every key of the subtree already satisfies
+// are switched off, so whole subtrees are answered from their aggregate.
+func extremeInRange[K cmp.Ordered, V any](n *Node[K, V], from, to K, checkFrom, checkTo bool, pick func(x, y *Node[K, V]) *Node[K, V], whole func(*Node[K, V]) *Node[K, V]) *Node[K, V] {
+	if n == nil {
+		return nil
+	}
+	if !checkFrom && !checkTo {
+		return whole(n)
+	}
+	if checkFrom && n.key < from {
+		return extremeInRange(n.right, from, to, checkFrom, checkTo, pick, whole)
+	}
+	if checkTo && n.key >= to {
+		return extremeInRange(n.left, from, to, checkFrom, checkTo, pick, whole)
+	}
+	best := extremeInRange(n.left, from, to, checkFrom, false, pick, whole)
+	best = pick(best, n)
+	return pick(best, extremeInRange(n.right, from, to, false, checkTo, pick, whole))
+}
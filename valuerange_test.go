@@ -0,0 +1,129 @@
+package avltrees_test
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinValueInRange(t *testing.T) {
+	tree := avlts.New[int, int](avlts.WithValueOrder(cmp.Compare[int]))
+	for k, v := range map[int]int{1: 50, 2: 20, 3: 70, 4: 20, 5: 10, 6: 90} {
+		avlts.Insert(tree, k, v)
+	}
+	n, ok := avlts.MinValueInRange(tree, 1, 5)
+	require.True(t, ok)
+	assert.Equal(t, 2, n.Key(), "ties go to the smallest key")
+	assert.Equal(t, 20, n.Value())
+
+	n, _ = avlts.MinValueInRange(tree, 0, 100)
+	assert.Equal(t, 5, n.Key())
+
+	_, ok = avlts.MinValueInRange(tree, 7, 10)
+	assert.False(t, ok)
+
+	avlts.Insert(tree, 3, 0)
+	n, _ = avlts.MinValueInRange(tree, 1, 5)
+	assert.Equal(t, 3, n.Key(), "overwrites refresh the aggregates")
+}
+
+func TestMaxValueInRange(t *testing.T) {
+	tree := avlts.New[int, int](avlts.WithValueOrder(cmp.Compare[int]))
+	for k, v := range map[int]int{1: 50, 2: 20, 3: 70, 4: 70, 5: 10, 6: 90} {
+		avlts.Insert(tree, k, v)
+	}
+	n, ok := avlts.MaxValueInRange(tree, 1, 6)
+	require.True(t, ok)
+	assert.Equal(t, 3, n.Key())
+
+	avlts.Delete(tree, 3)
+	n, _ = avlts.MaxValueInRange(tree, 1, 6)
+	assert.Equal(t, 4, n.Key())
+}
+
+func TestValueInRangeWithoutOption(t *testing.T) {
+	tree := avlts.New[int, int]()
+	avlts.Insert(tree, 1, 1)
+	_, ok := avlts.MinValueInRange(tree, 0, 10)
+	assert.False(t, ok)
+	_, ok = avlts.MaxValueInRange(tree, 0, 10)
+	assert.False(t, ok)
+
+	assert.Panics(t, func() {
+		avlts.New[int, string](avlts.WithValueOrder(cmp.Compare[int]))
+	})
+}
+
+func TestValueInRangeRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(9))
+	tree := avlts.New[int, int](avlts.WithValueOrder(cmp.Compare[int]))
+	oracle := map[int]int{}
+	for i := range 3000 {
+		k := r.Intn(200)
+		switch r.Intn(3) {
+		case 0:
+			avlts.Delete(tree, k)
+			delete(oracle, k)
+		default:
+			v := r.Intn(1000)
+			avlts.Insert(tree, k, v)
+			oracle[k] = v
+		}
+		if i%10 != 0 {
+			continue
+		}
+		from := r.Intn(200)
+		to := from + r.Intn(50)
+		wantMin, wantMax, found := -1, -1, false
+		for k := from; k < to; k++ {
+			v, ok := oracle[k]
+			if !ok {
+				continue
+			}
+			if !found || v < oracle[wantMin] {
+				wantMin = k
+			}
+			if !found || v > oracle[wantMax] {
+				wantMax = k
+			}
+			found = true
+		}
+		n, ok := avlts.MinValueInRange(tree, from, to)
+		require.Equal(t, found, ok)
+		if found {
+			require.Equal(t, wantMin, n.Key())
+			n, _ = avlts.MaxValueInRange(tree, from, to)
+			require.Equal(t, wantMax, n.Key())
+		}
+	}
+}
+
+func ExampleMinValueInRange() {
+	offers := avlts.New[int, float64](avlts.WithValueOrder(cmp.Compare[float64])) // price band -> cost
+	avlts.Insert(offers, 100, 9.5)
+	avlts.Insert(offers, 120, 7.25)
+	avlts.Insert(offers, 150, 6.0)
+	avlts.Insert(offers, 180, 8.0)
+
+	n, _ := avlts.MinValueInRange(offers, 100, 150)
+	fmt.Println(n.Key(), n.Value())
+	// Output:
+	// 120 7.25
+}
+
+func ExampleMaxValueInRange() {
+	tree := avlts.New[string, int](avlts.WithValueOrder(cmp.Compare[int]))
+	avlts.Insert(tree, "a", 3)
+	avlts.Insert(tree, "b", 8)
+	avlts.Insert(tree, "c", 5)
+
+	n, _ := avlts.MaxValueInRange(tree, "a", "z")
+	fmt.Println(n.Key())
+	// Output:
+	// b
+}
@@ -0,0 +1,187 @@
+package avltrees
+
+import (
+	"cmp"
+	"iter"
+)
+
+// View is a read-only window onto the keys of a tree within a half-open range.
+// It holds no data of its own, so later changes to the tree are visible through it.
+type View[K cmp.Ordered, V any] struct {
+	t       *Tree[K, V]
+	from    K
+	to      K
+	hasFrom bool
+	hasTo   bool
+}
+
+// HeadMap returns a view of the entries with keys less than to.
+func HeadMap[K cmp.Ordered, V any](t *Tree[K, V], to K) *View[K, V] {
+	return &View[K, V]{t: t, to: to, hasTo: true}
+}
+
+// TailMap returns a view of the entries with keys greater than or equal to from.
+func TailMap[K cmp.Ordered, V any](t *Tree[K, V], from K) *View[K, V] {
+	return &View[K, V]{t: t, from: from, hasFrom: true}
+}
+
+// SubMap returns a view of the entries with keys in the range [from, to).
+func SubMap[K cmp.Ordered, V any](t *Tree[K, V], from, to K) *View[K, V] {
+	return &View[K, V]{t: t, from: from, to: to, hasFrom: true, hasTo: true}
+}
+
+// Search returns the node with the given key if it exists and lies within the view.
+func (v *View[K, V]) Search(key K) (*Node[K, V], bool) {
+	if !v.contains(key) {
+		return nil, false
+	}
+	return Search(v.t, key)
+}
+
+// Min returns the node with the smallest key in the view.
+func (v *View[K, V]) Min() (*Node[K, V], bool) {
+	var n *Node[K, V]
+	var ok bool
+	if v.hasFrom {
+		n, ok = Ceiling(v.t, v.from)
+	} else {
+		n, ok = Min(v.t)
+	}
+	if !ok || !v.contains(n.key) {
+		return nil, false
+	}
+	return n, true
+}
+
+// Max returns the node with the largest key in the view.
+func (v *View[K, V]) Max() (*Node[K, V], bool) {
+	var n *Node[K, V]
+	var ok bool
+	if v.hasTo {
+		n, ok = Lower(v.t, v.to)
+	} else {
+		n, ok = Max(v.t)
+	}
+	if !ok || !v.contains(n.key) {
+		return nil, false
+	}
+	return n, true
+}
+
+// Len returns the number of entries in the view.
+// It runs in O(log n) when the tree maintains order statistics,
+// and in time proportional to the view size otherwise.
+func (v *View[K, V]) Len() int {
+	if v.hasFrom && v.hasTo && v.from >= v.to {
+		return 0
+	}
+	if v.t.noOrderStats {
+		count := 0
+		for range v.InOrder() {
+			count++
+		}
+		return count
+	}
+	hi := Len(v.t)
+	if v.hasTo {
+		hi = Rank(v.t, v.to)
+	}
+	lo := 0
+	if v.hasFrom {
+		lo = Rank(v.t, v.from)
+	}
+	return hi - lo
+}
+
+// InOrder returns an iterator for in-order traversal of the view.
+func (v *View[K, V]) InOrder() iter.Seq[Node[K, V]] {
+	return func(yield func(Node[K, V]) bool) {
+		for n, ok := v.Min(); ok && v.contains(n.key); n, ok = Successor(n) {
+			if !yield(*n) {
+				return
+			}
+		}
+	}
+}
+
+func (v *View[K, V]) contains(key K) bool {
+	return (!v.hasFrom || key >= v.from) && (!v.hasTo || key < v.to)
+}
+
+// DescendingView presents a tree in reversed key order: "smaller" means a
+// larger key. Code written against ascending semantics can use it to work
+// largest-first without negating keys.
+type DescendingView[K cmp.Ordered, V any] struct {
+	t *Tree[K, V]
+}
+
+// Descending returns a view of the AVL tree in reversed key order.
+func Descending[K cmp.Ordered, V any](t *Tree[K, V]) *DescendingView[K, V] {
+	return &DescendingView[K, V]{t: t}
+}
+
+// Search finds and returns the node with the given key.
+func (d *DescendingView[K, V]) Search(key K) (*Node[K, V], bool) {
+	return Search(d.t, key)
+}
+
+// Len returns the number of nodes in the underlying tree.
+func (d *DescendingView[K, V]) Len() int {
+	return Len(d.t)
+}
+
+// Min returns the first node in descending order, which has the largest key.
+func (d *DescendingView[K, V]) Min() (*Node[K, V], bool) {
+	return Max(d.t)
+}
+
+// Max returns the last node in descending order, which has the smallest key.
+func (d *DescendingView[K, V]) Max() (*Node[K, V], bool) {
+	return Min(d.t)
+}
+
+// Floor returns the node with the smallest key greater than or equal to key,
+// that is, the closest node at or before key in descending order.
+func (d *DescendingView[K, V]) Floor(key K) (*Node[K, V], bool) {
+	return Ceiling(d.t, key)
+}
+
+// Ceiling returns the node with the largest key less than or equal to key,
+// that is, the closest node at or after key in descending order.
+func (d *DescendingView[K, V]) Ceiling(key K) (*Node[K, V], bool) {
+	return Floor(d.t, key)
+}
+
+// Higher returns the node with the largest key less than key.
+func (d *DescendingView[K, V]) Higher(key K) (*Node[K, V], bool) {
+	return Lower(d.t, key)
+}
+
+// Lower returns the node with the smallest key greater than key.
+func (d *DescendingView[K, V]) Lower(key K) (*Node[K, V], bool) {
+	return Higher(d.t, key)
+}
+
+// InOrder returns an iterator over all nodes from the largest key to the smallest.
+func (d *DescendingView[K, V]) InOrder() iter.Seq[Node[K, V]] {
+	return func(yield func(Node[K, V]) bool) {
+		for n, ok := Max(d.t); ok; n, ok = Predecessor(n) {
+			if !yield(*n) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over nodes with keys k such that from >= k > to,
+// from the largest key to the smallest. This mirrors the half-open [from, to)
+// range of Range in descending order.
+func (d *DescendingView[K, V]) Range(from, to K) iter.Seq[Node[K, V]] {
+	return func(yield func(Node[K, V]) bool) {
+		for n, ok := Floor(d.t, from); ok && n.key > to; n, ok = Predecessor(n) {
+			if !yield(*n) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func viewKeys(v *avlts.View[int, string]) []int {
+	var keys []int
+	for n := range v.InOrder() {
+		keys = append(keys, n.Key())
+	}
+	return keys
+}
+
+func TestHeadMap(t *testing.T) {
+	tree := treeOf([]int{10, 20, 30, 40, 50}, []string{"10", "20", "30", "40", "50"})
+	v := avlts.HeadMap(tree, 30)
+	assert.Equal(t, []int{10, 20}, viewKeys(v))
+	assert.Equal(t, 2, v.Len())
+
+	_, ok := v.Search(30)
+	assert.False(t, ok)
+	n, ok := v.Search(20)
+	require.True(t, ok)
+	assert.Equal(t, "20", n.Value())
+
+	m, ok := v.Max()
+	require.True(t, ok)
+	assert.Equal(t, 20, m.Key())
+
+	assert.Zero(t, avlts.HeadMap(tree, 10).Len())
+	_, ok = avlts.HeadMap(tree, 10).Max()
+	assert.False(t, ok)
+}
+
+func TestTailMap(t *testing.T) {
+	tree := treeOf([]int{10, 20, 30, 40, 50}, []string{"10", "20", "30", "40", "50"})
+	v := avlts.TailMap(tree, 30)
+	assert.Equal(t, []int{30, 40, 50}, viewKeys(v))
+	assert.Equal(t, 3, v.Len())
+
+	m, ok := v.Min()
+	require.True(t, ok)
+	assert.Equal(t, 30, m.Key())
+	m, ok = v.Max()
+	require.True(t, ok)
+	assert.Equal(t, 50, m.Key())
+
+	_, ok = avlts.TailMap(tree, 60).Min()
+	assert.False(t, ok)
+}
+
+func TestSubMap(t *testing.T) {
+	tree := treeOf([]int{10, 20, 30, 40, 50}, []string{"10", "20", "30", "40", "50"})
+	v := avlts.SubMap(tree, 15, 45)
+	assert.Equal(t, []int{20, 30, 40}, viewKeys(v))
+	assert.Equal(t, 3, v.Len())
+
+	_, ok := v.Search(10)
+	assert.False(t, ok)
+	_, ok = v.Search(40)
+	assert.True(t, ok)
+
+	// Views are backed by the tree.
+	avlts.Insert(tree, 35, "35")
+	avlts.Delete(tree, 20)
+	assert.Equal(t, []int{30, 35, 40}, viewKeys(v))
+	assert.Equal(t, 3, v.Len())
+
+	empty := avlts.SubMap(tree, 31, 34)
+	assert.Zero(t, empty.Len())
+	_, ok = empty.Min()
+	assert.False(t, ok)
+	_, ok = empty.Max()
+	assert.False(t, ok)
+	assert.Empty(t, viewKeys(empty))
+	assert.Zero(t, avlts.SubMap(tree, 40, 30).Len())
+}
+
+func TestViewWithoutOrderStatistics(t *testing.T) {
+	tree := treeOf([]int{10, 20, 30, 40, 50}, []string{"10", "20", "30", "40", "50"}, avlts.WithoutOrderStatistics())
+	assert.Equal(t, 3, avlts.SubMap(tree, 15, 45).Len())
+	assert.Equal(t, 2, avlts.HeadMap(tree, 25).Len())
+}
+
+func ExampleSubMap() {
+	tree := treeOf([]int{10, 20, 30, 40, 50}, []string{"10", "20", "30", "40", "50"})
+	v := avlts.SubMap(tree, 20, 40)
+	for n := range v.InOrder() {
+		fmt.Print(n.Key(), " ")
+	}
+	fmt.Println(v.Len())
+	// Output: 20 30 2
+}
+
+func ExampleHeadMap() {
+	tree := treeOf([]int{10, 20, 30, 40, 50}, []string{"10", "20", "30", "40", "50"})
+	v := avlts.HeadMap(tree, 30)
+	m, _ := v.Max()
+	fmt.Println(m.Key(), v.Len())
+	// Output: 20 2
+}
+
+func ExampleTailMap() {
+	tree := treeOf([]int{10, 20, 30, 40, 50}, []string{"10", "20", "30", "40", "50"})
+	v := avlts.TailMap(tree, 30)
+	m, _ := v.Min()
+	fmt.Println(m.Key(), v.Len())
+	// Output: 30 3
+}
+
+func TestDescending(t *testing.T) {
+	tree := treeOf([]int{10, 20, 30, 40, 50}, []string{"10", "20", "30", "40", "50"})
+	d := avlts.Descending(tree)
+	assert.Equal(t, 5, d.Len())
+
+	n, ok := d.Min()
+	require.True(t, ok)
+	assert.Equal(t, 50, n.Key())
+	n, ok = d.Max()
+	require.True(t, ok)
+	assert.Equal(t, 10, n.Key())
+
+	n, ok = d.Floor(25)
+	require.True(t, ok)
+	assert.Equal(t, 30, n.Key())
+	n, ok = d.Ceiling(25)
+	require.True(t, ok)
+	assert.Equal(t, 20, n.Key())
+	n, ok = d.Higher(30)
+	require.True(t, ok)
+	assert.Equal(t, 20, n.Key())
+	n, ok = d.Lower(30)
+	require.True(t, ok)
+	assert.Equal(t, 40, n.Key())
+	_, ok = d.Higher(10)
+	assert.False(t, ok)
+
+	n, ok = d.Search(40)
+	require.True(t, ok)
+	assert.Equal(t, "40", n.Value())
+}
+
+func TestDescendingInOrder(t *testing.T) {
+	d := avlts.Descending(treeOf([]int{10, 20, 30, 40, 50}, []string{"10", "20", "30", "40", "50"}))
+	var keys []int
+	for n := range d.InOrder() {
+		keys = append(keys, n.Key())
+	}
+	assert.Equal(t, []int{50, 40, 30, 20, 10}, keys)
+
+	keys = nil
+	for n := range d.InOrder() {
+		keys = append(keys, n.Key())
+		if len(keys) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []int{50, 40}, keys)
+}
+
+func TestDescendingRange(t *testing.T) {
+	d := avlts.Descending(treeOf([]int{10, 20, 30, 40, 50}, []string{"10", "20", "30", "40", "50"}))
+	var keys []int
+	for n := range d.Range(40, 10) {
+		keys = append(keys, n.Key())
+	}
+	assert.Equal(t, []int{40, 30, 20}, keys)
+
+	keys = nil
+	for n := range d.Range(45, 15) {
+		keys = append(keys, n.Key())
+		if len(keys) == 1 {
+			break
+		}
+	}
+	assert.Equal(t, []int{40}, keys)
+
+	for range d.Range(10, 40) {
+		t.Fatal("expected empty range")
+	}
+}
+
+func ExampleDescending() {
+	tree := treeOf([]int{10, 20, 30, 40, 50}, []string{"10", "20", "30", "40", "50"})
+	d := avlts.Descending(tree)
+	first, _ := d.Min()
+	fmt.Println(first.Key())
+	for n := range d.Range(40, 10) {
+		fmt.Print(n.Key(), " ")
+	}
+	fmt.Println()
+	// Output:
+	// 50
+	// 40 30 20
+}
@@ -0,0 +1,103 @@
+package avltrees
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	walInsert = "insert"
+	walDelete = "delete"
+)
+
+type walRecord[K cmp.Ordered, V any] struct {
+	Op    string `json:"op"`
+	Key   K      `json:"key"`
+	Value V      `json:"value,omitempty"`
+}
+
+// WAL is a write-ahead log attached to a tree. Every insert, update, and
+// delete applied to the tree is appended to the underlying writer as one
+// JSON record per line, so the tree can be rebuilt with Recover.
+type WAL[K cmp.Ordered, V any] struct {
+	enc    *json.Encoder
+	err    error
+	detach []func()
+}
+
+// AttachWAL starts logging every mutation of the AVL tree to w.
+// Keys and values must be encodable with encoding/json.
+// Write errors are sticky and reported by Err; once a write fails,
+// later records are dropped.
+func AttachWAL[K cmp.Ordered, V any](t *Tree[K, V], w io.Writer) *WAL[K, V] {
+	l := &WAL[K, V]{enc: json.NewEncoder(w)}
+	l.detach = []func(){
+		OnInsert(t, func(key K, value V) { l.write(walInsert, key, value) }),
+		OnUpdate(t, func(key K, _, value V) { l.write(walInsert, key, value) }),
+		OnDelete(t, func(key K, _ V) {
+			var zero V
+			l.write(walDelete, key, zero)
+		}),
+	}
+	return l
+}
+
+// Err returns the first error encountered while writing to the log.
+func (l *WAL[K, V]) Err() error {
+	return l.err
+}
+
+// Detach stops logging mutations of the tree.
+func (l *WAL[K, V]) Detach() {
+	for _, remove := range l.detach {
+		remove()
+	}
+	l.detach = nil
+}
+
+func (l *WAL[K, V]) write(op string, key K, value V) {
+	if l.err != nil {
+		return
+	}
+	l.err = l.enc.Encode(walRecord[K, V]{op, key, value})
+}
+
+// WriteSnapshot writes the current contents of the AVL tree to w in the
+// WAL record format. A snapshot followed by the log written after it
+// can be replayed with Recover to rebuild the tree.
+func WriteSnapshot[K cmp.Ordered, V any](t *Tree[K, V], w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for n := range InOrder(t) {
+		if err := enc.Encode(walRecord[K, V]{walInsert, n.key, n.value}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Recover rebuilds an AVL tree by replaying WAL records read from r.
+func Recover[K cmp.Ordered, V any](r io.Reader) (*Tree[K, V], error) {
+	t := New[K, V]()
+	dec := json.NewDecoder(r)
+	for {
+		var rec walRecord[K, V]
+		err := dec.Decode(&rec)
+		if errors.Is(err, io.EOF) {
+			return t, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch rec.Op {
+		case walInsert:
+			Insert(t, rec.Key, rec.Value)
+		case walDelete:
+			Delete(t, rec.Key)
+		default:
+			return nil, fmt.Errorf("avltrees: unknown WAL operation %q", rec.Op)
+		}
+	}
+}
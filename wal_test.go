@@ -0,0 +1,125 @@
+package avltrees_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachWAL(t *testing.T) {
+	var log bytes.Buffer
+	tree := avlts.New[string, int]()
+	wal := avlts.AttachWAL(tree, &log)
+
+	avlts.Insert(tree, "a", 1)
+	avlts.Insert(tree, "b", 2)
+	avlts.Insert(tree, "a", 3)
+	avlts.Delete(tree, "b")
+	require.NoError(t, wal.Err())
+
+	expected := `{"op":"insert","key":"a","value":1}
+{"op":"insert","key":"b","value":2}
+{"op":"insert","key":"a","value":3}
+{"op":"delete","key":"b"}
+`
+	assert.Equal(t, expected, log.String())
+
+	wal.Detach()
+	avlts.Insert(tree, "c", 4)
+	assert.Equal(t, expected, log.String())
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestWALErr(t *testing.T) {
+	tree := avlts.New[int, int]()
+	wal := avlts.AttachWAL(tree, failingWriter{})
+	avlts.Insert(tree, 1, 1)
+	avlts.Insert(tree, 2, 2)
+	assert.EqualError(t, wal.Err(), "disk full")
+	assert.Equal(t, 2, avlts.Len(tree))
+}
+
+func TestRecover(t *testing.T) {
+	var log bytes.Buffer
+	tree := avlts.New[int, string]()
+	avlts.AttachWAL(tree, &log)
+	for i := range 20 {
+		avlts.Insert(tree, i, fmt.Sprint(i))
+	}
+	for i := 0; i < 20; i += 3 {
+		avlts.Delete(tree, i)
+	}
+	avlts.Insert(tree, 5, "five")
+
+	recovered, err := avlts.Recover[int, string](&log)
+	require.NoError(t, err)
+	assert.Equal(t, avlts.Len(tree), avlts.Len(recovered))
+	for n := range avlts.InOrder(tree) {
+		r, ok := avlts.Search(recovered, n.Key())
+		require.True(t, ok)
+		assert.Equal(t, n.Value(), r.Value())
+	}
+
+	_, err = avlts.Recover[int, string](strings.NewReader(`{"op":"upsert","key":1}`))
+	assert.Error(t, err)
+	_, err = avlts.Recover[int, string](strings.NewReader(`{"op":"insert","key":"x"}`))
+	assert.Error(t, err)
+}
+
+func TestWriteSnapshot(t *testing.T) {
+	tree := avlts.New[int, string]()
+	avlts.Insert(tree, 2, "two")
+	avlts.Insert(tree, 1, "one")
+
+	var buf bytes.Buffer
+	require.NoError(t, avlts.WriteSnapshot(tree, &buf))
+	wal := avlts.AttachWAL(tree, &buf)
+	avlts.Delete(tree, 1)
+	avlts.Insert(tree, 3, "three")
+	require.NoError(t, wal.Err())
+
+	recovered, err := avlts.Recover[int, string](&buf)
+	require.NoError(t, err)
+	var keys []int
+	for n := range avlts.InOrder(recovered) {
+		keys = append(keys, n.Key())
+	}
+	assert.Equal(t, []int{2, 3}, keys)
+
+	assert.Error(t, avlts.WriteSnapshot(tree, failingWriter{}))
+}
+
+func ExampleAttachWAL() {
+	tree := avlts.New[string, int]()
+	avlts.AttachWAL(tree, os.Stdout)
+	avlts.Insert(tree, "a", 1)
+	avlts.Delete(tree, "a")
+	// Output:
+	// {"op":"insert","key":"a","value":1}
+	// {"op":"delete","key":"a"}
+}
+
+func ExampleRecover() {
+	log := strings.NewReader(`{"op":"insert","key":"a","value":1}
+{"op":"insert","key":"b","value":2}
+{"op":"delete","key":"a"}
+`)
+	tree, err := avlts.Recover[string, int](log)
+	if err != nil {
+		panic(err)
+	}
+	for n := range avlts.InOrder(tree) {
+		fmt.Println(n.Key(), n.Value())
+	}
+	// Output: b 2
+}
@@ -0,0 +1,126 @@
+package avltrees
+
+import (
+	"cmp"
+	"sync"
+	"sync/atomic"
+)
+
+// EventKind is the kind of change reported by an Event.
+type EventKind uint8
+
+const (
+	// EventInsert reports a new key. New holds its value.
+	EventInsert EventKind = iota + 1
+	// EventUpdate reports an overwritten value. Old and New hold both values.
+	EventUpdate
+	// EventDelete reports a removed key. Old holds its last value.
+	EventDelete
+)
+
+// Event is a change to a key of a watched tree.
+type Event[K cmp.Ordered, V any] struct {
+	Kind EventKind
+	Key  K
+	Old  V
+	New  V
+}
+
+// Watch returns a channel that receives an Event for every change to a key
+// in the range [from, to) of the AVL tree, in the order the changes were
+// made. Events are queued without limit, so a slow receiver never blocks
+// changes to the tree. Calling cancel stops the watch, drops any events
+// not yet received, and closes the channel; it must be called to release
+// the goroutine that delivers the events.
+//
+// cancel may be called from any goroutine, including one that is receiving
+// events while another changes the tree. It does not touch the tree
+// itself: the hooks behind the watch unregister themselves on the next
+// change made to the tree.
+func Watch[K cmp.Ordered, V any](t *Tree[K, V], from, to K) (events <-chan Event[K, V], cancel func()) {
+	w := &watcher[K, V]{
+		out:  make(chan Event[K, V]),
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+	}
+	// detach is only used by the goroutine changing the tree.
+	var detach []func()
+	emit := func(e Event[K, V]) {
+		if w.cancelled.Load() {
+			for _, remove := range detach {
+				remove()
+			}
+			detach = nil
+			return
+		}
+		if e.Key >= from && e.Key < to {
+			w.push(e)
+		}
+	}
+	detach = []func(){
+		OnInsert(t, func(key K, value V) {
+			emit(Event[K, V]{Kind: EventInsert, Key: key, New: value})
+		}),
+		OnUpdate(t, func(key K, old, new V) {
+			emit(Event[K, V]{Kind: EventUpdate, Key: key, Old: old, New: new})
+		}),
+		OnDelete(t, func(key K, value V) {
+			emit(Event[K, V]{Kind: EventDelete, Key: key, Old: value})
+		}),
+	}
+	go w.pump()
+
+	var once sync.Once
+	return w.out, func() {
+		once.Do(func() {
+			w.cancelled.Store(true)
+			close(w.done)
+		})
+	}
+}
+
+// watcher hands events from the goroutine changing the tree to the
+// receiver through an unbounded queue.
+type watcher[K cmp.Ordered, V any] struct {
+	mu        sync.Mutex
+	queue     []Event[K, V]
+	out       chan Event[K, V]
+	wake      chan struct{}
+	done      chan struct{}
+	cancelled atomic.Bool
+}
+
+func (w *watcher[K, V]) push(e Event[K, V]) {
+	w.mu.Lock()
+	w.queue = append(w.queue, e)
+	w.mu.Unlock()
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (w *watcher[K, V]) pump() {
+	defer close(w.out)
+	for {
+		w.mu.Lock()
+		batch := w.queue
+		w.queue = nil
+		w.mu.Unlock()
+		if len(batch) == 0 {
+			select {
+			case <-w.wake:
+				continue
+			case <-w.done:
+				return
+			}
+		}
+		for _, e := range batch {
+			select {
+			case w.out <- e:
+			case <-w.done:
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type strEvent = avlts.Event[string, int]
+
+func receive(t *testing.T, events <-chan strEvent, n int) []strEvent {
+	t.Helper()
+	var got []strEvent
+	for range n {
+		select {
+		case e := <-events:
+			got = append(got, e)
+		case <-time.After(time.Second):
+			t.Fatalf("received %d of %d events", len(got), n)
+		}
+	}
+	return got
+}
+
+func TestWatch(t *testing.T) {
+	tree := avlts.New[string, int]()
+	events, cancel := avlts.Watch(tree, "b", "d")
+	defer cancel()
+
+	avlts.Insert(tree, "a", 1) // outside the range
+	avlts.Insert(tree, "b", 2)
+	avlts.Insert(tree, "c", 3)
+	avlts.Insert(tree, "b", 20)
+	avlts.Insert(tree, "d", 4) // outside the range
+	avlts.Delete(tree, "c")
+
+	assert.Equal(t, []strEvent{
+		{Kind: avlts.EventInsert, Key: "b", New: 2},
+		{Kind: avlts.EventInsert, Key: "c", New: 3},
+		{Kind: avlts.EventUpdate, Key: "b", Old: 2, New: 20},
+		{Kind: avlts.EventDelete, Key: "c", Old: 3},
+	}, receive(t, events, 4))
+
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected event %+v", e)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestWatchDoesNotBlockWriter(t *testing.T) {
+	tree := avlts.New[string, int]()
+	events, cancel := avlts.Watch(tree, "", "\xff")
+	defer cancel()
+	for i := range 1000 {
+		avlts.Insert(tree, fmt.Sprint(i), i)
+	}
+	got := receive(t, events, 1000)
+	assert.Equal(t, "0", got[0].Key)
+	assert.Equal(t, 999, got[999].New)
+}
+
+func TestWatchCancel(t *testing.T) {
+	tree := avlts.New[string, int]()
+	events, cancel := avlts.Watch(tree, "a", "z")
+	avlts.Insert(tree, "k", 1)
+	cancel()
+	cancel() // idempotent
+
+	avlts.Insert(tree, "m", 2)
+	for e := range events {
+		// An event queued before cancel may or may not be delivered.
+		assert.Equal(t, "k", e.Key)
+	}
+	_, ok := <-events
+	require.False(t, ok, "the channel is closed")
+}
+
+func TestWatchCancelConcurrently(t *testing.T) {
+	tree := avlts.New[int, int]()
+	events, cancel := avlts.Watch(tree, 0, math.MaxInt)
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+				avlts.Insert(tree, i, i)
+			}
+		}
+	}()
+	for range 10 {
+		<-events
+	}
+	cancel()
+	for range events {
+	}
+	close(stop)
+	<-done
+}
+
+func ExampleWatch() {
+	orders := avlts.New[int, string]()
+	events, cancel := avlts.Watch(orders, 100, 200)
+	defer cancel()
+
+	avlts.Insert(orders, 150, "pending")
+	avlts.Insert(orders, 250, "pending")
+	avlts.Insert(orders, 150, "shipped")
+
+	for range 2 {
+		e := <-events
+		fmt.Println(e.Kind == avlts.EventUpdate, e.Key, e.New)
+	}
+	// Output:
+	// false 150 pending
+	// true 150 shipped
+}
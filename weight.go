@@ -0,0 +1,48 @@
+package avltrees
+
+import "cmp"
+
+// WithWeight makes the tree maintain the total weight of every subtree,
+// where weight assigns a weight to each entry. This enables WeightedRank,
+// SelectByWeight, and TotalWeight in O(log n). Weights must be
+// non-negative: the tree does not check them as entries change, but
+// WeightedRank and SelectByWeight return wrong results for negative
+// weights, and Validate reports them. The type parameters must match the
+// tree's key and value types; New panics otherwise.
+func WithWeight[K cmp.Ordered, V any](weight func(key K, value V) int64) Option {
+	return func(o *options) {
+		o.weight = weight
+	}
+}
+
+// TotalWeight returns the sum of the weights of all entries.
+// Always returns 0 if the tree was not created WithWeight.
+func TotalWeight[K cmp.Ordered, V any](t *Tree[K, V]) int64 {
+	if t.aug == nil || t.aug.weight == nil {
+		return 0
+	}
+	return weightSum(t.Root)
+}
+
+// WeightedRank returns the sum of the weights of the entries with keys less
+// than key. Returns -1 if the tree was not created WithWeight.
+func WeightedRank[K cmp.Ordered, V any](t *Tree[K, V], key K) int64 {
+	if t.aug == nil || t.aug.weight == nil {
+		return -1
+	}
+	return weightBefore(t, key)
+}
+
+// SelectByWeight returns the node whose share of the cumulative weight
+// covers w: the entry e for which WeightedRank(e) <= w and w is less than
+// WeightedRank(e) plus the weight of e. Drawing w uniformly from
+// [0, TotalWeight(t)) therefore selects entries in proportion to their
+// weight. Entries with zero weight are never selected.
+// Returns false if w is out of range or the tree was not created WithWeight.
+func SelectByWeight[K cmp.Ordered, V any](t *Tree[K, V], w int64) (*Node[K, V], bool) {
+	if t.aug == nil || t.aug.weight == nil {
+		return nil, false
+	}
+	n, _ := seekWeight(t, w)
+	return n, n != nil
+}
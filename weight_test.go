@@ -0,0 +1,102 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func valueWeight(_ string, w int64) int64 { return w }
+
+func TestTotalWeight(t *testing.T) {
+	tree := treeOf([]string{"a", "b", "c", "d"}, []int64{5, 0, 3, 2}, avlts.WithWeight(valueWeight))
+	assert.Equal(t, int64(10), avlts.TotalWeight(tree))
+	avlts.Insert(tree, "a", 1)
+	assert.Equal(t, int64(6), avlts.TotalWeight(tree))
+	avlts.Delete(tree, "c")
+	assert.Equal(t, int64(3), avlts.TotalWeight(tree))
+	assert.Zero(t, avlts.TotalWeight(avlts.New[string, int64]()))
+}
+
+func TestWeightedRank(t *testing.T) {
+	tree := treeOf([]string{"a", "b", "c", "d"}, []int64{5, 0, 3, 2}, avlts.WithWeight(valueWeight))
+	for key, want := range map[string]int64{"a": 0, "b": 5, "bb": 5, "c": 5, "d": 8, "z": 10} {
+		assert.Equal(t, want, avlts.WeightedRank(tree, key), key)
+	}
+	assert.Equal(t, int64(-1), avlts.WeightedRank(avlts.New[string, int64](), "a"))
+}
+
+func TestValidateNegativeWeight(t *testing.T) {
+	tree := treeOf([]string{"a", "b", "c"}, []int64{5, 0, 3}, avlts.WithWeight(valueWeight))
+	require.NoError(t, avlts.Validate(tree))
+	avlts.Insert(tree, "b", -2)
+	assert.ErrorContains(t, avlts.Validate(tree), "negative weight")
+}
+
+func TestSelectByWeight(t *testing.T) {
+	tree := treeOf([]string{"a", "b", "c", "d"}, []int64{5, 0, 3, 2}, avlts.WithWeight(valueWeight))
+	want := []string{"a", "a", "a", "a", "a", "c", "c", "c", "d", "d"}
+	for w, key := range want {
+		n, ok := avlts.SelectByWeight(tree, int64(w))
+		require.True(t, ok)
+		assert.Equal(t, key, n.Key(), "w=%d", w)
+	}
+	_, ok := avlts.SelectByWeight(tree, 10)
+	assert.False(t, ok)
+	_, ok = avlts.SelectByWeight(tree, -1)
+	assert.False(t, ok)
+	_, ok = avlts.SelectByWeight(avlts.New[string, int64](), 0)
+	assert.False(t, ok)
+
+	assert.Panics(t, func() { avlts.New[int, int64](avlts.WithWeight(valueWeight)) })
+}
+
+func TestWeightedRankRandomized(t *testing.T) {
+	rng := rand.New(rand.NewPCG(5, 6))
+	tree := avlts.New[int, int64](avlts.WithWeight(func(_ int, w int64) int64 { return w }))
+	oracle := map[int]int64{}
+	for range 2000 {
+		k := rng.IntN(100)
+		if rng.IntN(3) == 0 {
+			avlts.Delete(tree, k)
+			delete(oracle, k)
+		} else {
+			w := rng.Int64N(10)
+			avlts.Insert(tree, k, w)
+			oracle[k] = w
+		}
+	}
+	var sum int64
+	for k := range 100 {
+		require.Equal(t, sum, avlts.WeightedRank(tree, k))
+		sum += oracle[k]
+	}
+	assert.Equal(t, sum, avlts.TotalWeight(tree))
+}
+
+func ExampleSelectByWeight() {
+	backends := avlts.New[string, int64](avlts.WithWeight(func(_ string, share int64) int64 { return share }))
+	avlts.Insert(backends, "eu-1", 70)
+	avlts.Insert(backends, "us-1", 30)
+
+	for _, w := range []int64{0, 69, 70, 99} {
+		n, _ := avlts.SelectByWeight(backends, w)
+		fmt.Println(w, n.Key())
+	}
+	// Output:
+	// 0 eu-1
+	// 69 eu-1
+	// 70 us-1
+	// 99 us-1
+}
+
+func ExampleWeightedRank() {
+	tree := treeOf([]string{"a", "b", "c", "d"}, []int64{5, 0, 3, 2}, avlts.WithWeight(valueWeight))
+	fmt.Println(avlts.WeightedRank(tree, "c"), avlts.TotalWeight(tree))
+	// Output:
+	// 5 10
+}
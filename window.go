@@ -0,0 +1,96 @@
+package avltrees
+
+import (
+	"cmp"
+	"time"
+)
+
+// Window keeps the most recent values of a stream, bounded either by count
+// or by age, and answers order queries over them in O(log n).
+// Use NewCountWindow or NewTimeWindow to create one.
+type Window[T cmp.Ordered] struct {
+	limit  int
+	maxAge time.Duration
+	values *SortedList[T]
+	queue  []windowEntry[T] // arrival order
+}
+
+type windowEntry[T cmp.Ordered] struct {
+	value T
+	at    time.Time
+}
+
+// NewCountWindow returns a Window holding the last n values.
+// Panics if n is less than 1.
+func NewCountWindow[T cmp.Ordered](n int) *Window[T] {
+	if n < 1 {
+		panic("avltrees: NewCountWindow requires n >= 1")
+	}
+	return &Window[T]{limit: n, values: NewSortedList[T]()}
+}
+
+// NewTimeWindow returns a Window holding the values added within the last d.
+// Panics if d is not positive.
+func NewTimeWindow[T cmp.Ordered](d time.Duration) *Window[T] {
+	if d <= 0 {
+		panic("avltrees: NewTimeWindow requires a positive duration")
+	}
+	return &Window[T]{maxAge: d, values: NewSortedList[T]()}
+}
+
+// Add adds v to the window at the current time.
+func (w *Window[T]) Add(v T) {
+	w.AddAt(v, time.Now())
+}
+
+// AddAt adds v to the window as if it arrived at the given time, then drops
+// the values that no longer fit. Times are expected to be non-decreasing.
+func (w *Window[T]) AddAt(v T, at time.Time) {
+	w.queue = append(w.queue, windowEntry[T]{v, at})
+	w.values.Add(v)
+	if w.limit > 0 {
+		for len(w.queue) > w.limit {
+			w.pop()
+		}
+	}
+	w.Expire(at)
+}
+
+// Expire drops the values of a time window that are older than the window
+// duration as of now. It has no effect on a count window.
+func (w *Window[T]) Expire(now time.Time) {
+	if w.maxAge == 0 {
+		return
+	}
+	cutoff := now.Add(-w.maxAge)
+	for len(w.queue) > 0 && !w.queue[0].at.After(cutoff) {
+		w.pop()
+	}
+}
+
+// Len returns the number of values in the window.
+func (w *Window[T]) Len() int {
+	return len(w.queue)
+}
+
+// Median returns the median of the values in the window.
+// For an even number of values it returns the lower of the two middle values.
+func (w *Window[T]) Median() (T, bool) {
+	return w.values.At((w.values.Len() - 1) / 2)
+}
+
+// Min returns the smallest value in the window.
+func (w *Window[T]) Min() (T, bool) {
+	return w.values.At(0)
+}
+
+// Max returns the largest value in the window.
+func (w *Window[T]) Max() (T, bool) {
+	return w.values.At(w.values.Len() - 1)
+}
+
+func (w *Window[T]) pop() {
+	w.values.Remove(w.queue[0].value)
+	w.queue[0] = windowEntry[T]{}
+	w.queue = w.queue[1:]
+}
@@ -0,0 +1,103 @@
+package avltrees_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	avlts "github.com/byExist/avltrees"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountWindow(t *testing.T) {
+	w := avlts.NewCountWindow[int](3)
+	_, ok := w.Median()
+	assert.False(t, ok)
+
+	for _, v := range []int{5, 1, 9, 3} {
+		w.Add(v)
+	}
+	assert.Equal(t, 3, w.Len())
+	median, ok := w.Median()
+	require.True(t, ok)
+	assert.Equal(t, 3, median)
+	lo, _ := w.Min()
+	hi, _ := w.Max()
+	assert.Equal(t, 1, lo)
+	assert.Equal(t, 9, hi)
+
+	w.Add(9)
+	w.Add(9)
+	lo, _ = w.Min()
+	assert.Equal(t, 3, lo)
+	median, _ = w.Median()
+	assert.Equal(t, 9, median)
+
+	assert.Panics(t, func() { avlts.NewCountWindow[int](0) })
+}
+
+func TestTimeWindow(t *testing.T) {
+	start := time.Unix(0, 0)
+	w := avlts.NewTimeWindow[float64](10 * time.Second)
+	w.AddAt(4, start)
+	w.AddAt(2, start.Add(3*time.Second))
+	w.AddAt(8, start.Add(6*time.Second))
+	median, _ := w.Median()
+	assert.Equal(t, 4.0, median)
+
+	w.AddAt(6, start.Add(11*time.Second))
+	assert.Equal(t, 3, w.Len())
+	lo, _ := w.Min()
+	assert.Equal(t, 2.0, lo)
+
+	w.Expire(start.Add(16 * time.Second))
+	assert.Equal(t, 1, w.Len())
+	median, _ = w.Median()
+	assert.Equal(t, 6.0, median)
+
+	w.Expire(start.Add(time.Minute))
+	assert.Equal(t, 0, w.Len())
+	_, ok := w.Max()
+	assert.False(t, ok)
+
+	assert.Panics(t, func() { avlts.NewTimeWindow[int](0) })
+}
+
+func TestWindowEvenMedian(t *testing.T) {
+	w := avlts.NewCountWindow[int](4)
+	for _, v := range []int{4, 1, 3, 2} {
+		w.Add(v)
+	}
+	median, _ := w.Median()
+	assert.Equal(t, 2, median, "even windows report the lower median")
+}
+
+func ExampleNewCountWindow() {
+	w := avlts.NewCountWindow[int](3)
+	for _, latency := range []int{120, 80, 300, 95, 110} {
+		w.Add(latency)
+		median, _ := w.Median()
+		fmt.Println(median)
+	}
+	// Output:
+	// 120
+	// 80
+	// 120
+	// 95
+	// 110
+}
+
+func ExampleNewTimeWindow() {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	w := avlts.NewTimeWindow[int](time.Minute)
+	w.AddAt(7, start)
+	w.AddAt(3, start.Add(40*time.Second))
+	w.AddAt(5, start.Add(90*time.Second))
+
+	lo, _ := w.Min()
+	hi, _ := w.Max()
+	fmt.Println(w.Len(), lo, hi)
+	// Output:
+	// 2 3 5
+}